@@ -2,6 +2,8 @@ package handler
 
 import (
 	"errors"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,3 +15,12 @@ func GetIDByKey(ctx *gin.Context, key string) (string, error) {
 	id := value.(string)
 	return id, nil
 }
+
+// GetPrincipal returns the authenticated user with its roles.
+func GetPrincipal(ctx *gin.Context) (*models.Principal, error) {
+	value, exists := ctx.Get(auth.AuthorizedPrincipalKey)
+	if !exists {
+		return nil, errors.New("principal not found")
+	}
+	return value.(*models.Principal), nil
+}
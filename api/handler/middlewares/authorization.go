@@ -55,6 +55,7 @@ func AuthRequired() gin.HandlerFunc {
 			return
 		}
 		c.Set(auth.AuthorizedUserIDKey, verifiedToken.UID)
+		c.Set(auth.AuthorizedAuthTenantKey, verifiedToken.Firebase.Tenant)
 		c.Next()
 	}
 }
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"fmt"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+// KioskRequired authenticates a kiosk by the token it was registered with, instead of a user.
+// The request is scoped to the tenant of the kiosk.
+func KioskRequired(service services.KioskService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Request.Header.Get("Authorization")
+		token := strings.Replace(header, "Bearer ", "", 1)
+		kiosk, err := service.AuthenticateKiosk(c, token)
+		if err != nil {
+			logger.NewWarn(logrus.Fields{"err": err}, "error verifying kiosk token")
+			err = fmt.Errorf("unauthorized")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, err)
+			return
+		}
+		c.Set(auth.AuthorizedKioskIDKey, kiosk.ID)
+		c.Set(auth.TenantIDKey, kiosk.TenantID)
+		c.Next()
+	}
+}
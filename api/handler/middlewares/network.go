@@ -0,0 +1,37 @@
+package middlewares
+
+import (
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/netutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+)
+
+const (
+	ClientIPKey      = "client_ip"
+	NetworkStatusKey = "network_status"
+)
+
+// NetworkRestricted checks the client address of a punch against the allowed networks.
+// The address and its status are set on the context to be recorded with every punch,
+// which the attendance service refuses from outside when the network restriction of the company rejects it.
+func NetworkRestricted(service services.AllowedNetworkService, trustedProxies []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := netutil.ClientIP(c.Request, trustedProxies)
+		status, err := service.CheckNetwork(c, ip)
+		if err != nil {
+			logger.NewWarn(logrus.Fields{"ip": ip.String()}, err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+			return
+		}
+		if ip != nil {
+			c.Set(ClientIPKey, ip.String())
+		}
+		c.Set(NetworkStatusKey, status)
+		c.Next()
+	}
+}
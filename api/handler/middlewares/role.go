@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+const TargetUserIDKey = "target_user_id"
+
+// PrincipalRequired loads the roles of the authenticated user after AuthRequired.
+func PrincipalRequired(service services.RoleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString(auth.AuthorizedUserIDKey)
+		if userID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.NewError(responses.BadAccessError))
+			return
+		}
+		principal, err := service.GetPrincipal(c, userID)
+		if err != nil {
+			logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+			return
+		}
+		c.Set(auth.AuthorizedPrincipalKey, principal)
+		c.Next()
+	}
+}
+
+// PermissionRequired allows the route only to the principals with all the permissions.
+func PermissionRequired(permissions ...models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(auth.AuthorizedPrincipalKey)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+			return
+		}
+		principal := value.(*models.Principal)
+		for _, p := range permissions {
+			if !principal.Can(p) {
+				logger.NewWarn(logrus.Fields{"user_id": principal.UserID, "permission": p}, models.ErrForbidden.Error())
+				c.AbortWithStatusJSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// TargetUser sets the user whose data the route is about, given by the user_id query and the principal itself by default.
+// It is allowed when the principal can access the data of the user, e.g. a manager reading the attendances of a report.
+func TargetUser(service services.RoleService, access models.Access) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(auth.AuthorizedPrincipalKey)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+			return
+		}
+		principal := value.(*models.Principal)
+		userID := c.DefaultQuery("user_id", principal.UserID)
+		if err := service.Authorize(c, principal, userID, access); err != nil {
+			logger.NewWarn(logrus.Fields{"user_id": principal.UserID, "target_user_id": userID}, err.Error())
+			if xerrors.Is(err, models.ErrForbidden) {
+				c.AbortWithStatusJSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+			return
+		}
+		c.Set(TargetUserIDKey, userID)
+		c.Next()
+	}
+}
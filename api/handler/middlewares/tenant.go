@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// TenantRequired scopes the request to the tenant of the authenticated user after AuthRequired.
+// Every query of the request is then limited to the data of the tenant.
+func TenantRequired(service services.TenantService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authTenantID := c.GetString(auth.AuthorizedAuthTenantKey)
+		tenant, err := service.ResolveTenant(c, authTenantID)
+		if err != nil {
+			logger.NewWarn(logrus.Fields{"auth_tenant_id": authTenantID, "user_id": c.GetString(auth.AuthorizedUserIDKey)}, err.Error())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.NewError(responses.BadAccessError))
+			return
+		}
+		c.Set(auth.TenantIDKey, tenant.ID)
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package anomaly
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+}
+
+type anomalyHandler struct {
+	service services.AnomalyService
+}
+
+func NewAnomalyHandler(service services.AnomalyService) Handler {
+	return &anomalyHandler{
+		service: service,
+	}
+}
+
+// ListHandler returns the anomalies of the attendances in the month found by the scan job, this month by default.
+// A manager gives the user id of a report to check theirs.
+func (h *anomalyHandler) ListHandler(c *gin.Context) {
+	month, err := timeutil.GetDefaultMonth()
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.NewAnomaliesQueryParam(month)
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetAnomaliesParameters{
+		UserID: userID,
+		Month:  query.Month,
+	}
+	anomalies, err := h.service.GetAnomalies(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID, "month": query.Month}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAnomaliesResponses(anomalies))
+}
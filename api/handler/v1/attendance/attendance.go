@@ -1,25 +1,36 @@
 package attendance
 
 import (
+	"context"
 	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
 	"github.com/KouT127/attendance-management/api/payloads"
 	"github.com/KouT127/attendance-management/api/responses"
 	"github.com/KouT127/attendance-management/application/services"
 	"github.com/KouT127/attendance-management/domain/models"
-	"github.com/KouT127/attendance-management/infrastructure/auth"
 	"github.com/KouT127/attendance-management/utilities/logger"
 	"github.com/KouT127/attendance-management/utilities/timeutil"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
 	"net/http"
+	"strconv"
 )
 
 type Handler interface {
 	ListHandler(c *gin.Context)
 	CreateHandler(c *gin.Context)
+	ClockInHandler(c *gin.Context)
+	ClockOutHandler(c *gin.Context)
+	BreakStartHandler(c *gin.Context)
+	BreakEndHandler(c *gin.Context)
 	SummaryHandler(c *gin.Context)
+	HistoryHandler(c *gin.Context)
+	WorkBreakdownHandler(c *gin.Context)
 }
 
+type pushFunc func(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+
 type attendanceService struct {
 	service services.AttendanceService
 }
@@ -50,14 +61,15 @@ func (s *attendanceService) ListHandler(c *gin.Context) {
 		return
 	}
 
-	if userID, err = handler.GetIDByKey(c, auth.AuthorizedUserIDKey); err != nil {
+	if userID, err = handler.GetIDByKey(c, middlewares.TargetUserIDKey); err != nil {
 		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
 		return
 	}
 
 	params := models.GetAttendancesParameters{
-		UserID: userID,
-		Month:  query.Month,
+		UserID:       userID,
+		Month:        query.Month,
+		WorkLocation: models.WorkLocation(query.WorkLocationID),
 	}
 
 	if res, err = s.service.GetAttendances(c, params); err != nil {
@@ -65,22 +77,43 @@ func (s *attendanceService) ListHandler(c *gin.Context) {
 		return
 	}
 
-	resps := responses.ToAttendancesResponses(res.Attendances)
+	resps := responses.ToAttendancesResponses(res)
 	c.JSON(http.StatusOK, resps)
 }
 
 func (s *attendanceService) CreateHandler(c *gin.Context) {
+	s.push(c, s.service.CreateOrUpdateAttendance)
+}
+
+func (s *attendanceService) ClockInHandler(c *gin.Context) {
+	s.push(c, s.service.ClockIn)
+}
+
+func (s *attendanceService) ClockOutHandler(c *gin.Context) {
+	s.push(c, s.service.ClockOut)
+}
+
+func (s *attendanceService) BreakStartHandler(c *gin.Context) {
+	s.push(c, s.service.StartBreak)
+}
+
+func (s *attendanceService) BreakEndHandler(c *gin.Context) {
+	s.push(c, s.service.EndBreak)
+}
+
+func (s *attendanceService) push(c *gin.Context, fn pushFunc) {
 	input := payloads.AttendancePayload{}
 	if err := c.Bind(&input); err != nil {
 		c.JSON(http.StatusBadRequest, responses.NewValidationError("user", err))
 		return
 	}
 
-	userID, err := handler.GetIDByKey(c, auth.AuthorizedUserIDKey)
+	principal, err := handler.GetPrincipal(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
 		return
 	}
+	userID := principal.UserID
 
 	if err = input.Validate(); err != nil {
 		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
@@ -88,8 +121,26 @@ func (s *attendanceService) CreateHandler(c *gin.Context) {
 	}
 
 	attendanceTime := input.ToAttendanceTime()
-	attendance, err := s.service.CreateOrUpdateAttendance(c, attendanceTime, userID)
+	attendanceTime.IPAddress = c.GetString(middlewares.ClientIPKey)
+	if status, ok := c.Get(middlewares.NetworkStatusKey); ok {
+		attendanceTime.NetworkStatusID = uint8(status.(models.NetworkStatus))
+	}
+	attendance, err := fn(c, attendanceTime, userID)
 	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		var conflictErr *models.AttendanceConflictError
+		if xerrors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, responses.NewError(conflictErr.Error()))
+			return
+		}
+		if xerrors.Is(err, models.ErrPunchOutsideGeofence) {
+			c.JSON(http.StatusForbidden, responses.NewError(responses.GeofenceError))
+			return
+		}
+		if xerrors.Is(err, models.ErrPunchOutsideNetwork) {
+			c.JSON(http.StatusForbidden, responses.NewError(responses.NetworkError))
+			return
+		}
 		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
 		return
 	}
@@ -99,7 +150,7 @@ func (s *attendanceService) CreateHandler(c *gin.Context) {
 }
 
 func (s *attendanceService) SummaryHandler(c *gin.Context) {
-	userID, err := handler.GetIDByKey(c, auth.AuthorizedUserIDKey)
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
 		return
@@ -114,3 +165,68 @@ func (s *attendanceService) SummaryHandler(c *gin.Context) {
 	resp := responses.ToAttendanceSummaryResponse(results)
 	c.JSON(http.StatusOK, resp)
 }
+
+// HistoryHandler returns every punch recorded for the attendance, including superseded ones.
+func (s *attendanceService) HistoryHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetAttendanceHistoryParameters{
+		ID:     id,
+		UserID: userID,
+	}
+	results, err := s.service.GetAttendanceHistory(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID, "attendance_id": id}, err.Error())
+		if xerrors.Is(err, models.ErrAttendanceNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAttendanceHistoryResponse(results))
+}
+
+// WorkBreakdownHandler returns the overtime and holiday work of the month for payroll.
+func (s *attendanceService) WorkBreakdownHandler(c *gin.Context) {
+	month, err := timeutil.GetDefaultMonth()
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.NewAttendancesQueryParam(month)
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetWorkBreakdownParameters{
+		UserID: userID,
+		Month:  query.Month,
+	}
+	results, err := s.service.GetWorkBreakdown(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID, "month": query.Month}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToWorkBreakdownResult(results))
+}
@@ -0,0 +1,68 @@
+package autoclockout
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+type Handler interface {
+	PolicyHandler(c *gin.Context)
+	UpdatePolicyHandler(c *gin.Context)
+}
+
+type autoClockOutHandler struct {
+	service services.AutoClockOutService
+}
+
+func NewAutoClockOutHandler(service services.AutoClockOutService) Handler {
+	return &autoClockOutHandler{
+		service: service,
+	}
+}
+
+func (h *autoClockOutHandler) PolicyHandler(c *gin.Context) {
+	policy, err := h.service.GetAutoClockOutPolicy(c)
+	if err != nil {
+		if xerrors.Is(err, models.ErrAutoClockOutPolicyNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAutoClockOutPolicyResult(policy))
+}
+
+// UpdatePolicyHandler sets the auto clock-out policy. It applies from the next run of the job.
+func (h *autoClockOutHandler) UpdatePolicyHandler(c *gin.Context) {
+	input := payloads.AutoClockOutPolicyPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("auto_clock_out_policy", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("auto_clock_out_policy", err))
+		return
+	}
+	policy, err := input.ToAutoClockOutPolicy()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("auto_clock_out_policy", err))
+		return
+	}
+
+	policy, err = h.service.UpdateAutoClockOutPolicy(c, policy)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAutoClockOutPolicyResult(policy))
+}
@@ -0,0 +1,76 @@
+package contract
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+}
+
+type contractHandler struct {
+	service services.ContractService
+}
+
+func NewContractHandler(service services.ContractService) Handler {
+	return &contractHandler{
+		service: service,
+	}
+}
+
+// ListHandler returns the contract history of the user.
+func (h *contractHandler) ListHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	contracts, err := h.service.GetEmploymentContracts(c, userID)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToContractsResponses(contracts))
+}
+
+func (h *contractHandler) CreateHandler(c *gin.Context) {
+	input := payloads.ContractPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("contract", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("contract", err))
+		return
+	}
+
+	contract, err := input.ToEmploymentContract()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if contract, err = h.service.CreateEmploymentContract(c, contract); err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": input.UserID}, err.Error())
+		if xerrors.Is(err, models.ErrContractOverlapped) {
+			c.JSON(http.StatusConflict, responses.NewError(responses.ConflictError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToContractResult(contract))
+}
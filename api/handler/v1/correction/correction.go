@@ -0,0 +1,176 @@
+package correction
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	ReviewListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	ApproveHandler(c *gin.Context)
+	RejectHandler(c *gin.Context)
+}
+
+type correctionHandler struct {
+	service services.CorrectionService
+}
+
+func NewCorrectionHandler(service services.CorrectionService) Handler {
+	return &correctionHandler{
+		service: service,
+	}
+}
+
+// ListHandler lists the correction requests submitted by the user.
+func (h *correctionHandler) ListHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.CorrectionRequestsQueryParam{}
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetCorrectionRequestsParameters{
+		UserID: userID,
+		Status: models.CorrectionStatus(query.Status),
+	}
+	requests, err := h.service.GetCorrectionRequests(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToCorrectionRequestsResponses(requests))
+}
+
+// ReviewListHandler lists the pending correction requests the user can review.
+func (h *correctionHandler) ReviewListHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	userID := principal.UserID
+
+	params := models.GetCorrectionRequestsParameters{
+		ReviewerID: userID,
+		Status:     models.CorrectionStatusPending,
+	}
+	requests, err := h.service.GetCorrectionRequests(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToCorrectionRequestsResponses(requests))
+}
+
+func (h *correctionHandler) CreateHandler(c *gin.Context) {
+	input := payloads.CorrectionRequestPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("correction_request", err))
+		return
+	}
+
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	userID := principal.UserID
+
+	if err = input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("correction_request", err))
+		return
+	}
+
+	request, err := input.ToCorrectionRequest(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if request, err = h.service.CreateCorrectionRequest(c, request); err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToCorrectionRequestResult(request))
+}
+
+func (h *correctionHandler) ApproveHandler(c *gin.Context) {
+	h.review(c, h.service.ApproveCorrectionRequest)
+}
+
+func (h *correctionHandler) RejectHandler(c *gin.Context) {
+	h.review(c, h.service.RejectCorrectionRequest)
+}
+
+type reviewFunc func(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error)
+
+func (h *correctionHandler) review(c *gin.Context, fn reviewFunc) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	input := payloads.ReviewPayload{}
+	if err = c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("review", err))
+		return
+	}
+	if err = input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("review", err))
+		return
+	}
+
+	reviewer, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	reviewerID := reviewer.UserID
+
+	params := models.ReviewCorrectionRequestParameters{
+		ID:         id,
+		ReviewerID: reviewerID,
+		Comment:    input.Comment,
+	}
+	request, err := fn(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": reviewerID, "correction_request_id": id}, err.Error())
+		switch {
+		case xerrors.Is(err, models.ErrCorrectionRequestNotFound):
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+		case xerrors.Is(err, models.ErrCorrectionRequestSelfReview),
+			xerrors.Is(err, models.ErrForbidden):
+			c.JSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+		case xerrors.Is(err, models.ErrCorrectionRequestNotPending),
+			xerrors.Is(err, models.ErrCorrectionRequestInvalidTime):
+			c.JSON(http.StatusConflict, responses.NewError(responses.ConflictError))
+		default:
+			c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		}
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToCorrectionRequestResult(request))
+}
@@ -0,0 +1,111 @@
+package flextime
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+type Handler interface {
+	RuleHandler(c *gin.Context)
+	UpdateRuleHandler(c *gin.Context)
+	SummaryHandler(c *gin.Context)
+}
+
+type flextimeHandler struct {
+	service services.FlextimeService
+}
+
+func NewFlextimeHandler(service services.FlextimeService) Handler {
+	return &flextimeHandler{
+		service: service,
+	}
+}
+
+func (h *flextimeHandler) RuleHandler(c *gin.Context) {
+	rule, err := h.service.GetFlextimeRule(c)
+	if err != nil {
+		if xerrors.Is(err, models.ErrFlextimeRuleNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToFlextimeRuleResult(rule))
+}
+
+// UpdateRuleHandler sets the flextime rule. Settlements are recalculated by the new rule, including past periods.
+func (h *flextimeHandler) UpdateRuleHandler(c *gin.Context) {
+	input := payloads.FlextimeRulePayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("flextime_rule", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("flextime_rule", err))
+		return
+	}
+	rule, err := input.ToFlextimeRule()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("flextime_rule", err))
+		return
+	}
+
+	rule, err = h.service.UpdateFlextimeRule(c, rule)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToFlextimeRuleResult(rule))
+}
+
+// SummaryHandler returns the settlement period containing the month, this month by default,
+// with the core time violations of its days.
+func (h *flextimeHandler) SummaryHandler(c *gin.Context) {
+	month, err := timeutil.GetDefaultMonth()
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.NewAttendancesQueryParam(month)
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetFlextimeSummaryParameters{
+		UserID: userID,
+		Month:  query.Month,
+	}
+	results, err := h.service.GetFlextimeSummary(c, params)
+	if err != nil {
+		if xerrors.Is(err, models.ErrFlextimeRuleNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		logger.NewWarn(logrus.Fields{"user_id": userID, "month": query.Month}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToFlextimeSummaryResult(results))
+}
@@ -0,0 +1,79 @@
+package geofence
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+}
+
+type geofenceHandler struct {
+	service services.GeofenceService
+}
+
+func NewGeofenceHandler(service services.GeofenceService) Handler {
+	return &geofenceHandler{
+		service: service,
+	}
+}
+
+func (h *geofenceHandler) ListHandler(c *gin.Context) {
+	geofences, err := h.service.GetGeofences(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToGeofencesResponses(geofences))
+}
+
+func (h *geofenceHandler) CreateHandler(c *gin.Context) {
+	input := payloads.GeofencePayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("geofence", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("geofence", err))
+		return
+	}
+
+	geofence, err := h.service.CreateGeofence(c, input.ToGeofence())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"name": input.Name}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToGeofenceResult(geofence))
+}
+
+func (h *geofenceHandler) DeleteHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteGeofence(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"geofence_id": id}, err.Error())
+		if xerrors.Is(err, models.ErrGeofenceNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
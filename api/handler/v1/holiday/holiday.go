@@ -0,0 +1,97 @@
+package holiday
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+}
+
+type holidayHandler struct {
+	service services.HolidayService
+}
+
+func NewHolidayHandler(service services.HolidayService) Handler {
+	return &holidayHandler{
+		service: service,
+	}
+}
+
+// ListHandler lists the national holidays and company closure days of the year, this year by default.
+func (h *holidayHandler) ListHandler(c *gin.Context) {
+	query := payloads.NewHolidaysQueryParam(flextime.Now().In(timezone.JSTLocation()).Year())
+	if err := c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	holidays, err := h.service.GetHolidays(c, query.Year)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"year": query.Year}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToHolidaysResponses(holidays))
+}
+
+func (h *holidayHandler) CreateHandler(c *gin.Context) {
+	input := payloads.HolidayPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("holiday", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("holiday", err))
+		return
+	}
+
+	holiday, err := input.ToHoliday()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if holiday, err = h.service.CreateHoliday(c, holiday); err != nil {
+		logger.NewWarn(logrus.Fields{"date": input.Date}, err.Error())
+		if xerrors.Is(err, models.ErrHolidayAlreadyExists) {
+			c.JSON(http.StatusConflict, responses.NewError(responses.ConflictError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToHolidayResult(holiday))
+}
+
+func (h *holidayHandler) DeleteHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteHoliday(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"holiday_id": id}, err.Error())
+		if xerrors.Is(err, models.ErrHolidayNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
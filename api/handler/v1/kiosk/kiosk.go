@@ -0,0 +1,208 @@
+package kiosk
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+	PunchCodeHandler(c *gin.Context)
+	IssuePunchCodesHandler(c *gin.Context)
+	PunchHandler(c *gin.Context)
+	ClockInHandler(c *gin.Context)
+	ClockOutHandler(c *gin.Context)
+	BreakStartHandler(c *gin.Context)
+	BreakEndHandler(c *gin.Context)
+}
+
+type pushFunc func(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+
+type kioskHandler struct {
+	service           services.KioskService
+	attendanceService services.AttendanceService
+}
+
+func NewKioskHandler(service services.KioskService, attendanceService services.AttendanceService) Handler {
+	return &kioskHandler{
+		service:           service,
+		attendanceService: attendanceService,
+	}
+}
+
+func (h *kioskHandler) ListHandler(c *gin.Context) {
+	kiosks, err := h.service.GetKiosks(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToKiosksResponses(kiosks))
+}
+
+// CreateHandler registers a kiosk and returns its token to be set on the terminal.
+func (h *kioskHandler) CreateHandler(c *gin.Context) {
+	input := payloads.KioskPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("kiosk", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("kiosk", err))
+		return
+	}
+
+	kiosk, token, err := h.service.CreateKiosk(c, input.ToKiosk())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"name": input.Name}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToKioskCreatedResult(kiosk, token))
+}
+
+func (h *kioskHandler) DeleteHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteKiosk(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"kiosk_id": id}, err.Error())
+		if xerrors.Is(err, models.ErrKioskNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
+
+// PunchCodeHandler issues a punch code of the user, to be shown as a QR code at a kiosk.
+func (h *kioskHandler) PunchCodeHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	h.issuePunchCodes(c, []string{principal.UserID})
+}
+
+// IssuePunchCodesHandler issues punch codes of several users, e.g. to print the badges of a team.
+func (h *kioskHandler) IssuePunchCodesHandler(c *gin.Context) {
+	input := payloads.PunchCodesPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("codes", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("codes", err))
+		return
+	}
+	h.issuePunchCodes(c, input.UserIDs)
+}
+
+func (h *kioskHandler) issuePunchCodes(c *gin.Context, userIDs []string) {
+	codes, err := h.service.IssuePunchCodes(c, userIDs)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_ids": userIDs}, err.Error())
+		if xerrors.Is(err, models.ErrPunchCodeUserNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToPunchCodesResponses(codes))
+}
+
+// PunchHandler clocks in or out like attendance.CreateHandler.
+func (h *kioskHandler) PunchHandler(c *gin.Context) {
+	h.push(c, h.attendanceService.CreateOrUpdateAttendance)
+}
+
+func (h *kioskHandler) ClockInHandler(c *gin.Context) {
+	h.push(c, h.attendanceService.ClockIn)
+}
+
+func (h *kioskHandler) ClockOutHandler(c *gin.Context) {
+	h.push(c, h.attendanceService.ClockOut)
+}
+
+func (h *kioskHandler) BreakStartHandler(c *gin.Context) {
+	h.push(c, h.attendanceService.StartBreak)
+}
+
+func (h *kioskHandler) BreakEndHandler(c *gin.Context) {
+	h.push(c, h.attendanceService.EndBreak)
+}
+
+// push records a punch of the employee of the punch code, as coming from the authenticated kiosk.
+func (h *kioskHandler) push(c *gin.Context, fn pushFunc) {
+	input := payloads.KioskPunchPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("punch", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("punch", err))
+		return
+	}
+
+	value, ok := c.Get(auth.AuthorizedKioskIDKey)
+	if !ok {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	kioskID := value.(int64)
+
+	userID, err := h.service.ResolvePunchCode(c, input.Code)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"kiosk_id": kioskID}, err.Error())
+		if xerrors.Is(err, models.ErrPunchCodeInvalid) || xerrors.Is(err, models.ErrPunchCodeExpired) || xerrors.Is(err, models.ErrPunchCodeUserNotFound) {
+			c.JSON(http.StatusForbidden, responses.NewError(responses.PunchCodeError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	attendanceTime := input.ToAttendanceTime(kioskID)
+	attendanceTime.IPAddress = c.GetString(middlewares.ClientIPKey)
+	if status, ok := c.Get(middlewares.NetworkStatusKey); ok {
+		attendanceTime.NetworkStatusID = uint8(status.(models.NetworkStatus))
+	}
+	attendance, err := fn(c, attendanceTime, userID)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID, "kiosk_id": kioskID}, err.Error())
+		var conflictErr *models.AttendanceConflictError
+		if xerrors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, responses.NewError(conflictErr.Error()))
+			return
+		}
+		if xerrors.Is(err, models.ErrPunchOutsideNetwork) {
+			c.JSON(http.StatusForbidden, responses.NewError(responses.NetworkError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.ToAttendanceCreatedResponse(attendance))
+}
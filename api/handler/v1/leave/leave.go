@@ -0,0 +1,199 @@
+package leave
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	ReviewListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	ApproveHandler(c *gin.Context)
+	RejectHandler(c *gin.Context)
+	BalanceHandler(c *gin.Context)
+}
+
+type leaveHandler struct {
+	service services.LeaveService
+}
+
+func NewLeaveHandler(service services.LeaveService) Handler {
+	return &leaveHandler{
+		service: service,
+	}
+}
+
+// ListHandler lists the leave requests submitted by the user.
+func (h *leaveHandler) ListHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.LeaveRequestsQueryParam{}
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetLeaveRequestsParameters{
+		UserID: userID,
+		Status: models.LeaveStatus(query.Status),
+	}
+	requests, err := h.service.GetLeaveRequests(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToLeaveRequestsResponses(requests))
+}
+
+// ReviewListHandler lists the pending leave requests the user can review.
+func (h *leaveHandler) ReviewListHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	userID := principal.UserID
+
+	params := models.GetLeaveRequestsParameters{
+		ReviewerID: userID,
+		Status:     models.LeaveStatusPending,
+	}
+	requests, err := h.service.GetLeaveRequests(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToLeaveRequestsResponses(requests))
+}
+
+func (h *leaveHandler) CreateHandler(c *gin.Context) {
+	input := payloads.LeaveRequestPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("leave_request", err))
+		return
+	}
+
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	userID := principal.UserID
+
+	if err = input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("leave_request", err))
+		return
+	}
+
+	request, err := input.ToLeaveRequest(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if request, err = h.service.CreateLeaveRequest(c, request); err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		if xerrors.Is(err, models.ErrLeaveBalanceInsufficient) {
+			c.JSON(http.StatusConflict, responses.NewError(responses.LeaveBalanceError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToLeaveRequestResult(request))
+}
+
+func (h *leaveHandler) ApproveHandler(c *gin.Context) {
+	h.review(c, h.service.ApproveLeaveRequest)
+}
+
+func (h *leaveHandler) RejectHandler(c *gin.Context) {
+	h.review(c, h.service.RejectLeaveRequest)
+}
+
+type reviewFunc func(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error)
+
+func (h *leaveHandler) review(c *gin.Context, fn reviewFunc) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	input := payloads.ReviewPayload{}
+	if err = c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("review", err))
+		return
+	}
+	if err = input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("review", err))
+		return
+	}
+
+	reviewer, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	reviewerID := reviewer.UserID
+
+	params := models.ReviewLeaveRequestParameters{
+		ID:         id,
+		ReviewerID: reviewerID,
+		Comment:    input.Comment,
+	}
+	request, err := fn(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": reviewerID, "leave_request_id": id}, err.Error())
+		switch {
+		case xerrors.Is(err, models.ErrLeaveRequestNotFound):
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+		case xerrors.Is(err, models.ErrLeaveRequestSelfReview),
+			xerrors.Is(err, models.ErrForbidden):
+			c.JSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+		case xerrors.Is(err, models.ErrLeaveBalanceInsufficient):
+			c.JSON(http.StatusConflict, responses.NewError(responses.LeaveBalanceError))
+		case xerrors.Is(err, models.ErrLeaveRequestNotPending):
+			c.JSON(http.StatusConflict, responses.NewError(responses.ConflictError))
+		default:
+			c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		}
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToLeaveRequestResult(request))
+}
+
+// BalanceHandler returns the paid leave balance of the user, granting the leave due by today.
+func (h *leaveHandler) BalanceHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	results, err := h.service.GetLeaveBalance(c, userID)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToLeaveBalanceResponse(results))
+}
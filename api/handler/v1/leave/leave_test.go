@@ -0,0 +1,88 @@
+package leave
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/contract"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_leaveHandler_BalanceHandler(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	gin.SetMode(gin.TestMode)
+	defer flextime.Restore()
+
+	tenantID, err := sqlstore.TenantID(sqlstore.NewTestContext())
+	if err != nil {
+		t.Errorf("TenantID() failed %s", err)
+		return
+	}
+	// The user has no hire date, so the service is counted from the first contract.
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: userID, Name: "insert user"}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+	startedAt := time.Date(2019, 4, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	for d := startedAt; d.Before(startedAt.AddDate(0, 6, 0)); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		if err := store.CreateAttendance(sqlstore.NewTestContext(), &models.Attendance{UserID: userID, AttendedAt: d.Add(9 * time.Hour)}); err != nil {
+			t.Errorf("CreateAttendance() %s", err)
+		}
+	}
+	flextime.Fix(time.Date(2019, 10, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(auth.TenantIDKey, tenantID)
+		c.Set(middlewares.TargetUserIDKey, userID)
+	})
+	router.POST("/v1/contracts", contract.NewContractHandler(services.NewContractService(store)).CreateHandler)
+	router.GET("/v1/leaves/balance", NewLeaveHandler(services.NewLeaveService(store)).BalanceHandler)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"user_id":            userID,
+		"employment_type_id": models.EmploymentTypeFullTime,
+		"weekly_hours":       40,
+		"workdays":           []int{1, 2, 3, 4, 5},
+		"start_date":         "2019-04-01",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/contracts", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("POST /v1/contracts status = %d, body %s", w.Code, w.Body.String())
+		return
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/leaves/balance", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /v1/leaves/balance status = %d, body %s", w.Code, w.Body.String())
+		return
+	}
+	res := responses.LeaveBalanceResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Errorf("GET /v1/leaves/balance body = %s", w.Body.String())
+		return
+	}
+	if len(res.Grants) != 1 || res.Grants[0].GrantedAt != "2019-10-01" || res.Balance != 10 {
+		t.Errorf("GET /v1/leaves/balance = %+v, want 10 days granted on 2019-10-01", res)
+	}
+}
@@ -0,0 +1,112 @@
+package network
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	CreateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+	RestrictionHandler(c *gin.Context)
+	UpdateRestrictionHandler(c *gin.Context)
+}
+
+type networkHandler struct {
+	service services.AllowedNetworkService
+}
+
+func NewNetworkHandler(service services.AllowedNetworkService) Handler {
+	return &networkHandler{
+		service: service,
+	}
+}
+
+func (h *networkHandler) ListHandler(c *gin.Context) {
+	networks, err := h.service.GetAllowedNetworks(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAllowedNetworksResponses(networks))
+}
+
+func (h *networkHandler) CreateHandler(c *gin.Context) {
+	input := payloads.AllowedNetworkPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("network", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("network", err))
+		return
+	}
+
+	network, err := h.service.CreateAllowedNetwork(c, input.ToAllowedNetwork())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"name": input.Name}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAllowedNetworkResult(network))
+}
+
+func (h *networkHandler) DeleteHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteAllowedNetwork(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"network_id": id}, err.Error())
+		if xerrors.Is(err, models.ErrAllowedNetworkNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
+
+func (h *networkHandler) RestrictionHandler(c *gin.Context) {
+	restriction, err := h.service.GetNetworkRestriction(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToNetworkRestrictionResult(restriction))
+}
+
+// UpdateRestrictionHandler sets whether punches from outside the allowed networks are flagged or rejected.
+func (h *networkHandler) UpdateRestrictionHandler(c *gin.Context) {
+	input := payloads.NetworkRestrictionPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("network_restriction", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("network_restriction", err))
+		return
+	}
+
+	restriction, err := h.service.UpdateNetworkRestriction(c, input.ToNetworkRestriction())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToNetworkRestrictionResult(restriction))
+}
@@ -0,0 +1,319 @@
+package organization
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+	"strconv"
+)
+
+type Handler interface {
+	DepartmentsHandler(c *gin.Context)
+	CreateDepartmentHandler(c *gin.Context)
+	UpdateDepartmentHandler(c *gin.Context)
+	DeleteDepartmentHandler(c *gin.Context)
+	TeamsHandler(c *gin.Context)
+	CreateTeamHandler(c *gin.Context)
+	UpdateTeamHandler(c *gin.Context)
+	DeleteTeamHandler(c *gin.Context)
+	TeamMembersHandler(c *gin.Context)
+	AssignmentsHandler(c *gin.Context)
+	CreateAssignmentHandler(c *gin.Context)
+	ReportsHandler(c *gin.Context)
+	ManagersHandler(c *gin.Context)
+}
+
+type organizationHandler struct {
+	service services.OrganizationService
+}
+
+func NewOrganizationHandler(service services.OrganizationService) Handler {
+	return &organizationHandler{
+		service: service,
+	}
+}
+
+func (h *organizationHandler) DepartmentsHandler(c *gin.Context) {
+	departments, err := h.service.GetDepartments(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToDepartmentsResponses(departments))
+}
+
+func (h *organizationHandler) CreateDepartmentHandler(c *gin.Context) {
+	h.saveDepartment(c, 0, h.service.CreateDepartment)
+}
+
+func (h *organizationHandler) UpdateDepartmentHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	h.saveDepartment(c, id, h.service.UpdateDepartment)
+}
+
+func (h *organizationHandler) DeleteDepartmentHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteDepartment(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"department_id": id}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
+
+// TeamsHandler returns the teams of the department given by department_id, or every team.
+func (h *organizationHandler) TeamsHandler(c *gin.Context) {
+	query := payloads.TeamsQueryParam{}
+	if err := c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	teams, err := h.service.GetTeams(c, query.DepartmentID)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"department_id": query.DepartmentID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToTeamsResponses(teams))
+}
+
+func (h *organizationHandler) CreateTeamHandler(c *gin.Context) {
+	h.saveTeam(c, 0, h.service.CreateTeam)
+}
+
+func (h *organizationHandler) UpdateTeamHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	h.saveTeam(c, id, h.service.UpdateTeam)
+}
+
+func (h *organizationHandler) DeleteTeamHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	if err = h.service.DeleteTeam(c, id); err != nil {
+		logger.NewWarn(logrus.Fields{"team_id": id}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.CommonResponse{IsSuccessful: true})
+}
+
+// TeamMembersHandler returns the assignments of the members of the team on the day, today by default.
+func (h *organizationHandler) TeamMembersHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	query, ok := bindOrganizationQuery(c)
+	if !ok {
+		return
+	}
+	at, err := query.At()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	members, err := h.service.GetTeamMembers(c, id, at)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"team_id": id}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAssignmentsResponses(members))
+}
+
+// AssignmentsHandler returns the history of the teams and the managers of the user.
+func (h *organizationHandler) AssignmentsHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	assignments, err := h.service.GetAssignments(c, userID)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAssignmentsResponses(assignments))
+}
+
+func (h *organizationHandler) CreateAssignmentHandler(c *gin.Context) {
+	input := payloads.AssignmentPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("assignment", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("assignment", err))
+		return
+	}
+
+	assignment, err := input.ToAssignment()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	if assignment, err = h.service.CreateAssignment(c, assignment); err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": input.UserID}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAssignmentResult(assignment))
+}
+
+// ReportsHandler returns the assignments of the users reporting to the user on the day, today by default.
+func (h *organizationHandler) ReportsHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	query, ok := bindOrganizationQuery(c)
+	if !ok {
+		return
+	}
+	at, err := query.At()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	reports, err := h.service.GetReports(c, userID, at, query.Indirect)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToAssignmentsResponses(reports))
+}
+
+// ManagersHandler returns the managers the user reports to on the day, from its own manager up.
+func (h *organizationHandler) ManagersHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	query, ok := bindOrganizationQuery(c)
+	if !ok {
+		return
+	}
+	at, err := query.At()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	managers, err := h.service.GetManagers(c, userID, at)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToManagersResponses(managers))
+}
+
+type saveDepartmentFunc func(ctx context.Context, department *models.Department) (*models.Department, error)
+
+func (h *organizationHandler) saveDepartment(c *gin.Context, id int64, fn saveDepartmentFunc) {
+	input := payloads.DepartmentPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("department", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("department", err))
+		return
+	}
+
+	department, err := fn(c, input.ToDepartment(id))
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"department_id": id, "name": input.Name}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToDepartmentResult(department))
+}
+
+type saveTeamFunc func(ctx context.Context, team *models.Team) (*models.Team, error)
+
+func (h *organizationHandler) saveTeam(c *gin.Context, id int64, fn saveTeamFunc) {
+	input := payloads.TeamPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("team", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("team", err))
+		return
+	}
+
+	team, err := fn(c, input.ToTeam(id))
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"team_id": id, "name": input.Name}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToTeamResult(team))
+}
+
+func bindOrganizationQuery(c *gin.Context) (*payloads.OrganizationQueryParam, bool) {
+	query := &payloads.OrganizationQueryParam{}
+	if err := c.BindQuery(query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return nil, false
+	}
+	if err := query.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("query", err))
+		return nil, false
+	}
+	return query, true
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case xerrors.Is(err, models.ErrDepartmentNotFound),
+		xerrors.Is(err, models.ErrTeamNotFound),
+		xerrors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+	case xerrors.Is(err, models.ErrDepartmentNotEmpty),
+		xerrors.Is(err, models.ErrDepartmentCycle),
+		xerrors.Is(err, models.ErrTeamNotEmpty),
+		xerrors.Is(err, models.ErrAssignmentOverlapped),
+		xerrors.Is(err, models.ErrReportingCycle):
+		c.JSON(http.StatusConflict, responses.NewError(responses.ConflictError))
+	default:
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+	}
+}
@@ -0,0 +1,50 @@
+package overtime
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler interface {
+	RisksHandler(c *gin.Context)
+}
+
+type overtimeHandler struct {
+	service services.OvertimeLimitService
+}
+
+func NewOvertimeHandler(service services.OvertimeLimitService) Handler {
+	return &overtimeHandler{
+		service: service,
+	}
+}
+
+// RisksHandler lists the users whose overtime approaches or exceeds the limits in the month, this month by default.
+func (h *overtimeHandler) RisksHandler(c *gin.Context) {
+	month, err := timeutil.GetDefaultMonth()
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.NewAttendancesQueryParam(month)
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	risks, err := h.service.GetOvertimeRisks(c, query.Month)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"month": query.Month}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToOvertimeRisksResponses(risks))
+}
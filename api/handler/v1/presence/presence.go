@@ -0,0 +1,129 @@
+package presence
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// keepAliveInterval is how often a comment is sent on an idle live board, so that proxies do not close it.
+const keepAliveInterval = 30 * time.Second
+
+type Handler interface {
+	TeamAttendancesHandler(c *gin.Context)
+	LiveHandler(c *gin.Context)
+}
+
+type presenceHandler struct {
+	service services.PresenceService
+}
+
+func NewPresenceHandler(service services.PresenceService) Handler {
+	return &presenceHandler{
+		service: service,
+	}
+}
+
+// TeamAttendancesHandler returns the punches and the status of the members of the team on the day, today by default.
+func (h *presenceHandler) TeamAttendancesHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	query := &payloads.TeamAttendancesQueryParam{}
+	if err := c.BindQuery(query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	if err := query.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("query", err))
+		return
+	}
+	at, err := query.At()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	presences, err := h.service.GetTeamAttendances(c, principal, id, at)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": principal.UserID, "team_id": id}, err.Error())
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToTeamAttendancesResponses(presences, at))
+}
+
+// LiveHandler streams the board of the team as Server-Sent Events.
+// The board of today is sent first as a "board" event, then a "presence" event for each punch of a member.
+func (h *presenceHandler) LiveHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+
+	presences, events, cancel, err := h.service.SubscribeTeam(c, principal, id)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": principal.UserID, "team_id": id}, err.Error())
+		respondError(c, err)
+		return
+	}
+	defer cancel()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("board", responses.ToTeamAttendancesResponses(presences, flextime.Now()))
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent("presence", responses.ToPresenceEventResponse(e))
+			return true
+		case <-ticker.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case xerrors.Is(err, models.ErrTeamNotFound):
+		c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+	case xerrors.Is(err, models.ErrForbidden):
+		c.JSON(http.StatusForbidden, responses.NewError(responses.ForbiddenError))
+	default:
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+	}
+}
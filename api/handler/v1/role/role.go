@@ -0,0 +1,64 @@
+package role
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+type Handler interface {
+	MineHandler(c *gin.Context)
+	UpdateRolesHandler(c *gin.Context)
+}
+
+type roleHandler struct {
+	service services.RoleService
+}
+
+func NewRoleHandler(service services.RoleService) Handler {
+	return &roleHandler{
+		service: service,
+	}
+}
+
+// MineHandler returns the roles of the user, for the client to show what it is allowed to.
+func (h *roleHandler) MineHandler(c *gin.Context) {
+	principal, err := handler.GetPrincipal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToPrincipalResult(principal))
+}
+
+func (h *roleHandler) UpdateRolesHandler(c *gin.Context) {
+	input := payloads.UserRolesPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("roles", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("roles", err))
+		return
+	}
+
+	userID := c.Param("id")
+	principal, err := h.service.UpdateUserRoles(c, userID, input.ToRoles())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+		if xerrors.Is(err, models.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToPrincipalResult(principal))
+}
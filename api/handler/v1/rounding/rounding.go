@@ -0,0 +1,57 @@
+package rounding
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	UpdateHandler(c *gin.Context)
+}
+
+type roundingHandler struct {
+	service services.RoundingRuleService
+}
+
+func NewRoundingHandler(service services.RoundingRuleService) Handler {
+	return &roundingHandler{
+		service: service,
+	}
+}
+
+func (h *roundingHandler) ListHandler(c *gin.Context) {
+	rules, err := h.service.GetRoundingRules(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToRoundingRulesResponses(rules))
+}
+
+// UpdateHandler replaces all the rounding rules. They apply to the punches recorded from now on.
+func (h *roundingHandler) UpdateHandler(c *gin.Context) {
+	input := payloads.RoundingRulesPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("rounding_rule", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("rounding_rule", err))
+		return
+	}
+
+	rules, err := h.service.UpdateRoundingRules(c, input.ToRoundingRules())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToRoundingRulesResponses(rules))
+}
@@ -0,0 +1,141 @@
+package shift
+
+import (
+	"github.com/KouT127/attendance-management/api/handler"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"net/http"
+)
+
+type Handler interface {
+	ListHandler(c *gin.Context)
+	RosterHandler(c *gin.Context)
+	PublishRosterHandler(c *gin.Context)
+	TemplatesHandler(c *gin.Context)
+	CreateTemplateHandler(c *gin.Context)
+}
+
+type shiftHandler struct {
+	service services.ShiftService
+}
+
+func NewShiftHandler(service services.ShiftService) Handler {
+	return &shiftHandler{
+		service: service,
+	}
+}
+
+// ListHandler returns the shifts of the user in the month, this month by default.
+func (h *shiftHandler) ListHandler(c *gin.Context) {
+	userID, err := handler.GetIDByKey(c, middlewares.TargetUserIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	h.list(c, userID)
+}
+
+// RosterHandler returns the shifts of everyone in the month, this month by default.
+func (h *shiftHandler) RosterHandler(c *gin.Context) {
+	h.list(c, "")
+}
+
+func (h *shiftHandler) list(c *gin.Context, userID string) {
+	month, err := timeutil.GetDefaultMonth()
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	query := payloads.NewAttendancesQueryParam(month)
+	if err = c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+
+	params := models.GetShiftsParameters{
+		UserID: userID,
+		Month:  query.Month,
+	}
+	checks, err := h.service.GetShifts(c, params)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID, "month": query.Month}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToShiftChecksResponses(checks))
+}
+
+// PublishRosterHandler publishes the shifts of a week or a month, replacing those published before.
+func (h *shiftHandler) PublishRosterHandler(c *gin.Context) {
+	input := payloads.ShiftRosterPayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_roster", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_roster", err))
+		return
+	}
+	roster, err := input.ToShiftRoster()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_roster", err))
+		return
+	}
+
+	roster, err = h.service.PublishShiftRoster(c, roster)
+	if err != nil {
+		if xerrors.Is(err, models.ErrShiftTemplateNotFound) {
+			c.JSON(http.StatusNotFound, responses.NewError(responses.NotFoundError))
+			return
+		}
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToShiftRosterResult(roster))
+}
+
+func (h *shiftHandler) TemplatesHandler(c *gin.Context) {
+	templates, err := h.service.GetShiftTemplates(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToShiftTemplatesResponses(templates))
+}
+
+func (h *shiftHandler) CreateTemplateHandler(c *gin.Context) {
+	input := payloads.ShiftTemplatePayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_template", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_template", err))
+		return
+	}
+	template, err := input.ToShiftTemplate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("shift_template", err))
+		return
+	}
+
+	template, err = h.service.CreateShiftTemplate(c, template)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToShiftTemplateResult(template))
+}
@@ -44,7 +44,7 @@ func (h userHandler) MineHandler(c *gin.Context) {
 	}
 
 	params := models.GetOrCreateUserParams{UserID: userID}
-	res, err := h.service.GetOrCreateUser(params)
+	res, err := h.service.GetOrCreateUser(c, params)
 	if err != nil {
 		logger.NewWarn(logrus.Fields{"Header": c.Request.Header}, err.Error())
 		c.JSON(http.StatusBadRequest, responses.NewError("ユーザーが取得できませんでした"))
@@ -77,7 +77,7 @@ func (h userHandler) UpdateHandler(c *gin.Context) {
 	user.Email = input.Email
 	user.ImageURL = input.ImageURL
 
-	if err := h.service.UpdateUser(user); err != nil {
+	if err := h.service.UpdateUser(c, user); err != nil {
 		logrus.Warnf("not exists: %s", err)
 		c.JSON(http.StatusBadRequest, responses.NewError(err.Error()))
 		return
@@ -0,0 +1,57 @@
+package workweek
+
+import (
+	"github.com/KouT127/attendance-management/api/payloads"
+	"github.com/KouT127/attendance-management/api/responses"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler interface {
+	GetHandler(c *gin.Context)
+	UpdateHandler(c *gin.Context)
+}
+
+type workWeekHandler struct {
+	service services.WorkWeekRuleService
+}
+
+func NewWorkWeekHandler(service services.WorkWeekRuleService) Handler {
+	return &workWeekHandler{
+		service: service,
+	}
+}
+
+func (h *workWeekHandler) GetHandler(c *gin.Context) {
+	rule, err := h.service.GetWorkWeekRule(c)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.BadAccessError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToWorkWeekRuleResult(rule))
+}
+
+// UpdateHandler sets the statutory holiday of the company, on which its weeks start.
+func (h *workWeekHandler) UpdateHandler(c *gin.Context) {
+	input := payloads.WorkWeekRulePayload{}
+	if err := c.Bind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("work_week_rule", err))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, responses.NewValidationError("work_week_rule", err))
+		return
+	}
+
+	rule, err := h.service.UpdateWorkWeekRule(c, input.ToWorkWeekRule())
+	if err != nil {
+		logger.NewWarn(logrus.Fields{}, err.Error())
+		c.JSON(http.StatusBadRequest, responses.NewError(responses.InvalidValueError))
+		return
+	}
+	c.JSON(http.StatusOK, responses.ToWorkWeekRuleResult(rule))
+}
@@ -0,0 +1,56 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"golang.org/x/xerrors"
+	"net"
+)
+
+// AllowedNetworkPayload is a network of an office in CIDR notation, e.g. 203.0.113.0/24.
+type AllowedNetworkPayload struct {
+	Name string `json:"name"`
+	CIDR string `json:"cidr"`
+}
+
+func (i *AllowedNetworkPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&i.CIDR, validation.Required, validation.By(isCIDR)),
+	)
+}
+
+func (i *AllowedNetworkPayload) ToAllowedNetwork() *models.AllowedNetwork {
+	n := &models.AllowedNetwork{}
+	n.Name = i.Name
+	n.CIDR = i.CIDR
+	return n
+}
+
+// NetworkRestrictionPayload is the policy for punches from outside the allowed networks: 1 flags and 2 rejects them.
+type NetworkRestrictionPayload struct {
+	NetworkRestrictionModeID uint8 `json:"network_restriction_mode_id"`
+}
+
+func (i *NetworkRestrictionPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.NetworkRestrictionModeID, validation.Required, validation.In(
+			uint8(models.NetworkRestrictionModeFlag),
+			uint8(models.NetworkRestrictionModeReject),
+		)),
+	)
+}
+
+func (i *NetworkRestrictionPayload) ToNetworkRestriction() *models.NetworkRestriction {
+	r := &models.NetworkRestriction{}
+	r.NetworkRestrictionModeID = i.NetworkRestrictionModeID
+	return r
+}
+
+func isCIDR(value interface{}) error {
+	s, _ := value.(string)
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return xerrors.New("must be a network in CIDR notation")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package payloads
+
+import "testing"
+
+func TestAllowedNetworkPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload AllowedNetworkPayload
+		wantErr bool
+	}{
+		{
+			name:    "Should validate IPv4 network",
+			payload: AllowedNetworkPayload{Name: "東京オフィス", CIDR: "203.0.113.0/24"},
+			wantErr: false,
+		},
+		{
+			name:    "Should validate IPv6 network",
+			payload: AllowedNetworkPayload{Name: "VPN", CIDR: "2001:db8::/32"},
+			wantErr: false,
+		},
+		{
+			name:    "Should not validate address without mask",
+			payload: AllowedNetworkPayload{Name: "東京オフィス", CIDR: "203.0.113.1"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not validate empty name",
+			payload: AllowedNetworkPayload{CIDR: "203.0.113.0/24"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.payload.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
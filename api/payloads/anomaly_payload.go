@@ -0,0 +1,12 @@
+package payloads
+
+// AnomaliesQueryParam selects the month of the anomalies. Whose they are is given by the user_id query of the route.
+type AnomaliesQueryParam struct {
+	Month int `form:"month"`
+}
+
+func NewAnomaliesQueryParam(month int) AnomaliesQueryParam {
+	return AnomaliesQueryParam{
+		Month: month,
+	}
+}
@@ -4,21 +4,55 @@ import (
 	"github.com/KouT127/attendance-management/domain/models"
 	"github.com/Songmu/flextime"
 	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"golang.org/x/xerrors"
 )
 
+var errLocationIncomplete = xerrors.New("latitude and longitude must be sent together")
+
+// AttendancePayload is a punch. The work location is recorded on a clock-in and defaults to the office.
+// The location of the device is optional; latitude and longitude are sent together,
+// with the accuracy in meters when the device reports it.
 type AttendancePayload struct {
-	Remark string
+	Remark         string
+	WorkLocationID uint8    `json:"work_location_id"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	Accuracy       *float64 `json:"accuracy"`
 }
 
 func (i *AttendancePayload) Validate() error {
 	return validation.ValidateStruct(i,
 		validation.Field(&i.Remark, validation.Required, validation.Length(0, 100)),
+		validation.Field(&i.WorkLocationID, validation.In(
+			uint8(models.WorkLocationOffice),
+			uint8(models.WorkLocationRemote),
+			uint8(models.WorkLocationClientSite),
+		)),
+		validation.Field(&i.Latitude, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&i.Longitude, validation.By(i.validateCoordinate), validation.Min(-180.0), validation.Max(180.0)),
+		validation.Field(&i.Accuracy, validation.Min(0.0)),
 	)
 }
 
+func (i *AttendancePayload) validateCoordinate(interface{}) error {
+	if (i.Latitude == nil) != (i.Longitude == nil) {
+		return errLocationIncomplete
+	}
+	return nil
+}
+
 func (i *AttendancePayload) ToAttendanceTime() *models.AttendanceTime {
 	t := &models.AttendanceTime{}
 	t.Remark = i.Remark
+	t.WorkLocationID = i.WorkLocationID
+	if i.Latitude != nil && i.Longitude != nil {
+		t.HasLocation = true
+		t.Latitude = *i.Latitude
+		t.Longitude = *i.Longitude
+		if i.Accuracy != nil {
+			t.LocationAccuracy = *i.Accuracy
+		}
+	}
 	t.PushedAt = flextime.Now()
 	t.CreatedAt = flextime.Now()
 	t.UpdatedAt = flextime.Now()
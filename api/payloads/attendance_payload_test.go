@@ -12,7 +12,11 @@ func TestAttendancePayload_ToAttendanceTime(t *testing.T) {
 	flextime.Fix(time.Date(2020, 01, 01, 1, 1, 1, 1, time.Local))
 
 	type fields struct {
-		Remark string
+		Remark         string
+		WorkLocationID uint8
+		Latitude       *float64
+		Longitude      *float64
+		Accuracy       *float64
 	}
 	tests := []struct {
 		name   string
@@ -31,11 +35,48 @@ func TestAttendancePayload_ToAttendanceTime(t *testing.T) {
 				UpdatedAt: flextime.Now(),
 			},
 		},
+		{
+			name: "Should convert work location",
+			fields: fields{
+				Remark:         "remark",
+				WorkLocationID: uint8(models.WorkLocationRemote),
+			},
+			want: &models.AttendanceTime{
+				Remark:         "remark",
+				WorkLocationID: uint8(models.WorkLocationRemote),
+				PushedAt:       flextime.Now(),
+				CreatedAt:      flextime.Now(),
+				UpdatedAt:      flextime.Now(),
+			},
+		},
+		{
+			name: "Should convert location",
+			fields: fields{
+				Remark:    "remark",
+				Latitude:  float(35.681236),
+				Longitude: float(139.767125),
+				Accuracy:  float(15),
+			},
+			want: &models.AttendanceTime{
+				Remark:           "remark",
+				HasLocation:      true,
+				Latitude:         35.681236,
+				Longitude:        139.767125,
+				LocationAccuracy: 15,
+				PushedAt:         flextime.Now(),
+				CreatedAt:        flextime.Now(),
+				UpdatedAt:        flextime.Now(),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			i := &AttendancePayload{
-				Remark: tt.fields.Remark,
+				Remark:         tt.fields.Remark,
+				WorkLocationID: tt.fields.WorkLocationID,
+				Latitude:       tt.fields.Latitude,
+				Longitude:      tt.fields.Longitude,
+				Accuracy:       tt.fields.Accuracy,
 			}
 			if got := i.ToAttendanceTime(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ToAttendanceTime() = %v, want %v", got, tt.want)
@@ -46,7 +87,11 @@ func TestAttendancePayload_ToAttendanceTime(t *testing.T) {
 
 func TestAttendancePayload_Validate(t *testing.T) {
 	type fields struct {
-		Remark string
+		Remark         string
+		WorkLocationID uint8
+		Latitude       *float64
+		Longitude      *float64
+		Accuracy       *float64
 	}
 	tests := []struct {
 		name    string
@@ -67,11 +112,68 @@ func TestAttendancePayload_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should validate work location",
+			fields: fields{
+				Remark:         "test",
+				WorkLocationID: uint8(models.WorkLocationClientSite),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not validate unknown work location",
+			fields: fields{
+				Remark:         "test",
+				WorkLocationID: 9,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should validate location",
+			fields: fields{
+				Remark:    "test",
+				Latitude:  float(-33.856784),
+				Longitude: float(151.215297),
+				Accuracy:  float(30),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not validate latitude out of range",
+			fields: fields{
+				Remark:    "test",
+				Latitude:  float(91),
+				Longitude: float(139.767125),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not validate longitude without latitude",
+			fields: fields{
+				Remark:    "test",
+				Longitude: float(139.767125),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not validate negative accuracy",
+			fields: fields{
+				Remark:    "test",
+				Latitude:  float(35.681236),
+				Longitude: float(139.767125),
+				Accuracy:  float(-1),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			i := &AttendancePayload{
-				Remark: tt.fields.Remark,
+				Remark:         tt.fields.Remark,
+				WorkLocationID: tt.fields.WorkLocationID,
+				Latitude:       tt.fields.Latitude,
+				Longitude:      tt.fields.Longitude,
+				Accuracy:       tt.fields.Accuracy,
 			}
 			if err := i.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -79,3 +181,7 @@ func TestAttendancePayload_Validate(t *testing.T) {
 		})
 	}
 }
+
+func float(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,32 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+// AutoClockOutPolicyPayload is the auto clock-out policy. The cutoff is given as "HH:MM" on the day after the attendance.
+type AutoClockOutPolicyPayload struct {
+	IsEnabled  bool   `json:"is_enabled"`
+	CutoffTime string `json:"cutoff_time"`
+	Remark     string `json:"remark"`
+}
+
+func (i *AutoClockOutPolicyPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.CutoffTime, validation.Required, validation.Date(clockLayout)),
+		validation.Field(&i.Remark, validation.Length(0, 100)),
+	)
+}
+
+func (i *AutoClockOutPolicyPayload) ToAutoClockOutPolicy() (*models.AutoClockOutPolicy, error) {
+	cutoff, err := clockMinutes(i.CutoffTime)
+	if err != nil {
+		return nil, err
+	}
+	p := &models.AutoClockOutPolicy{}
+	p.IsEnabled = i.IsEnabled
+	p.CutoffMinutes = cutoff
+	p.Remark = i.Remark
+	return p, nil
+}
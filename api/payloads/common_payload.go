@@ -31,7 +31,8 @@ func (i *QueryParam) HasNext(max int) bool {
 
 type AttendancesQueryParam struct {
 	QueryParam
-	Month int `form:"month"`
+	Month          int   `form:"month"`
+	WorkLocationID uint8 `form:"work_location_id"`
 }
 
 func NewAttendancesQueryParam(month int) AttendancesQueryParam {
@@ -41,5 +42,6 @@ func NewAttendancesQueryParam(month int) AttendancesQueryParam {
 			Limit: 31,
 		},
 		month,
+		uint8(models.WorkLocationNone),
 	}
 }
@@ -0,0 +1,49 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+// ContractPayload is an employment contract. Workdays are weekdays where 0 is Sunday.
+type ContractPayload struct {
+	UserID           string  `json:"user_id"`
+	EmploymentTypeID uint8   `json:"employment_type_id"`
+	WeeklyHours      float64 `json:"weekly_hours"`
+	Workdays         []int   `json:"workdays"`
+	StartDate        string  `json:"start_date"`
+}
+
+func (i *ContractPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.UserID, validation.Required),
+		validation.Field(&i.EmploymentTypeID, validation.Required, validation.In(
+			uint8(models.EmploymentTypeFullTime),
+			uint8(models.EmploymentTypePartTime),
+			uint8(models.EmploymentTypeShortTime),
+		)),
+		validation.Field(&i.WeeklyHours, validation.Required, validation.Max(float64(168))),
+		validation.Field(&i.Workdays, validation.Required, validation.Each(validation.Min(0), validation.Max(6))),
+		validation.Field(&i.StartDate, validation.Required, validation.Date(dateLayout)),
+	)
+}
+
+func (i *ContractPayload) ToEmploymentContract() (*models.EmploymentContract, error) {
+	start, err := time.ParseInLocation(dateLayout, i.StartDate, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	weekdays := make([]time.Weekday, 0, len(i.Workdays))
+	for _, d := range i.Workdays {
+		weekdays = append(weekdays, time.Weekday(d))
+	}
+	c := &models.EmploymentContract{}
+	c.UserID = i.UserID
+	c.EmploymentTypeID = i.EmploymentTypeID
+	c.WeeklyHours = i.WeeklyHours
+	c.Workdays = uint8(models.NewWorkdays(weekdays...))
+	c.StartedAt = start
+	return c, nil
+}
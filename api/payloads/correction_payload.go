@@ -0,0 +1,50 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type CorrectionRequestPayload struct {
+	Date             string `json:"date"`
+	AttendanceKindID uint8  `json:"attendance_kind_id"`
+	AttendanceTimeID int64  `json:"attendance_time_id"`
+	PushedAt         string `json:"pushed_at"`
+	Reason           string `json:"reason"`
+}
+
+func (i *CorrectionRequestPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Date, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.AttendanceKindID, validation.Required, validation.In(uint8(models.AttendanceKindClockIn), uint8(models.AttendanceKindClockOut))),
+		validation.Field(&i.PushedAt, validation.Required, validation.Date(time.RFC3339)),
+		validation.Field(&i.Reason, validation.Required, validation.Length(1, 250)),
+	)
+}
+
+func (i *CorrectionRequestPayload) ToCorrectionRequest(userID string) (*models.CorrectionRequest, error) {
+	date, err := time.ParseInLocation(dateLayout, i.Date, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	pushedAt, err := time.Parse(time.RFC3339, i.PushedAt)
+	if err != nil {
+		return nil, err
+	}
+	r := &models.CorrectionRequest{}
+	r.UserID = userID
+	r.TargetDate = date
+	r.AttendanceKindID = i.AttendanceKindID
+	r.AttendanceTimeID = i.AttendanceTimeID
+	r.PushedAt = pushedAt
+	r.Reason = i.Reason
+	return r, nil
+}
+
+type CorrectionRequestsQueryParam struct {
+	Status uint8 `form:"status"`
+}
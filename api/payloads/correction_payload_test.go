@@ -0,0 +1,111 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCorrectionRequestPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload CorrectionRequestPayload
+		wantErr bool
+	}{
+		{
+			name: "Should validate",
+			payload: CorrectionRequestPayload{
+				Date:             "2020-01-02",
+				AttendanceKindID: uint8(models.AttendanceKindClockOut),
+				PushedAt:         "2020-01-02T18:00:00+09:00",
+				Reason:           "forgot",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should not validate break kind",
+			payload: CorrectionRequestPayload{
+				Date:             "2020-01-02",
+				AttendanceKindID: uint8(models.AttendanceKindBreakStart),
+				PushedAt:         "2020-01-02T18:00:00+09:00",
+				Reason:           "forgot",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not validate when date is invalid",
+			payload: CorrectionRequestPayload{
+				Date:             "2020/01/02",
+				AttendanceKindID: uint8(models.AttendanceKindClockOut),
+				PushedAt:         "2020-01-02T18:00:00+09:00",
+				Reason:           "forgot",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not validate without reason",
+			payload: CorrectionRequestPayload{
+				Date:             "2020-01-02",
+				AttendanceKindID: uint8(models.AttendanceKindClockOut),
+				PushedAt:         "2020-01-02T18:00:00+09:00",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.payload.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCorrectionRequestPayload_ToCorrectionRequest(t *testing.T) {
+	timezone.Set("Asia/Tokyo")
+
+	tests := []struct {
+		name    string
+		payload CorrectionRequestPayload
+		want    *models.CorrectionRequest
+		wantErr bool
+	}{
+		{
+			name: "Should convert payload to correction request",
+			payload: CorrectionRequestPayload{
+				Date:             "2020-01-02",
+				AttendanceKindID: uint8(models.AttendanceKindClockOut),
+				AttendanceTimeID: 10,
+				PushedAt:         "2020-01-02T18:00:00+09:00",
+				Reason:           "forgot",
+			},
+			want: &models.CorrectionRequest{
+				UserID:           "user",
+				TargetDate:       time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
+				AttendanceKindID: uint8(models.AttendanceKindClockOut),
+				AttendanceTimeID: 10,
+				PushedAt:         time.Date(2020, 1, 2, 18, 0, 0, 0, time.FixedZone("", 9*60*60)),
+				Reason:           "forgot",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.payload.ToCorrectionRequest("user")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToCorrectionRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.TargetDate.Equal(tt.want.TargetDate) || !got.PushedAt.Equal(tt.want.PushedAt) {
+				t.Errorf("ToCorrectionRequest() = %v, want %v", got, tt.want)
+			}
+			got.TargetDate, got.PushedAt = tt.want.TargetDate, tt.want.PushedAt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToCorrectionRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
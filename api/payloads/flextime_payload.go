@@ -0,0 +1,57 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+const clockLayout = "15:04"
+
+// FlextimeRulePayload is the flextime rule. The core time is given as "HH:MM" and is empty for no core time.
+type FlextimeRulePayload struct {
+	PeriodMonths     int    `json:"period_months"`
+	StartMonth       int    `json:"start_month"`
+	CoreStart        string `json:"core_start"`
+	CoreEnd          string `json:"core_end"`
+	CarryOverSurplus bool   `json:"carry_over_surplus"`
+}
+
+func (i *FlextimeRulePayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.PeriodMonths, validation.Required, validation.Min(1), validation.Max(models.MaxFlextimePeriodMonths)),
+		validation.Field(&i.StartMonth, validation.Required, validation.Min(1), validation.Max(12)),
+		validation.Field(&i.CoreStart, validation.Date(clockLayout)),
+		validation.Field(&i.CoreEnd, validation.Date(clockLayout)),
+	)
+}
+
+func (i *FlextimeRulePayload) ToFlextimeRule() (*models.FlextimeRule, error) {
+	r := &models.FlextimeRule{}
+	r.PeriodMonths = i.PeriodMonths
+	r.StartMonth = i.StartMonth
+	r.CarryOverSurplus = i.CarryOverSurplus
+	if i.CoreStart == "" && i.CoreEnd == "" {
+		return r, nil
+	}
+	start, err := clockMinutes(i.CoreStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := clockMinutes(i.CoreEnd)
+	if err != nil {
+		return nil, err
+	}
+	r.CoreStartMinutes = start
+	r.CoreEndMinutes = end
+	return r, nil
+}
+
+// clockMinutes returns the minutes from midnight of a "HH:MM" time.
+func clockMinutes(s string) (int, error) {
+	t, err := time.Parse(clockLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
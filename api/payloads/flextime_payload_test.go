@@ -0,0 +1,56 @@
+package payloads
+
+import "testing"
+
+func TestFlextimeRulePayload_ToFlextimeRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   FlextimeRulePayload
+		wantStart int
+		wantEnd   int
+		wantErr   bool
+	}{
+		{
+			name:      "Should convert the core time to minutes",
+			payload:   FlextimeRulePayload{PeriodMonths: 1, StartMonth: 4, CoreStart: "11:00", CoreEnd: "15:30"},
+			wantStart: 660,
+			wantEnd:   930,
+		},
+		{
+			name:    "Should convert no core time",
+			payload: FlextimeRulePayload{PeriodMonths: 3, StartMonth: 4},
+		},
+		{
+			name:    "Should not validate a period over three months",
+			payload: FlextimeRulePayload{PeriodMonths: 4, StartMonth: 4},
+			wantErr: true,
+		},
+		{
+			name:    "Should not validate a malformed core time",
+			payload: FlextimeRulePayload{PeriodMonths: 1, StartMonth: 4, CoreStart: "11", CoreEnd: "15:00"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not convert only the start of the core time",
+			payload: FlextimeRulePayload{PeriodMonths: 1, StartMonth: 4, CoreStart: "11:00"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if err == nil {
+				r, convErr := tt.payload.ToFlextimeRule()
+				if err = convErr; err == nil {
+					err = r.Validate()
+				}
+				if err == nil && (r.CoreStartMinutes != tt.wantStart || r.CoreEndMinutes != tt.wantEnd) {
+					t.Errorf("ToFlextimeRule() core time = %d-%d, want %d-%d", r.CoreStartMinutes, r.CoreEndMinutes, tt.wantStart, tt.wantEnd)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
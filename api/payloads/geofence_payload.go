@@ -0,0 +1,32 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+// GeofencePayload is an office with the radius in meters around it where punches are allowed.
+type GeofencePayload struct {
+	Name         string  `json:"name"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters float64 `json:"radius"`
+}
+
+func (i *GeofencePayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&i.Latitude, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&i.Longitude, validation.Min(-180.0), validation.Max(180.0)),
+		validation.Field(&i.RadiusMeters, validation.Required, validation.Min(1.0)),
+	)
+}
+
+func (i *GeofencePayload) ToGeofence() *models.Geofence {
+	g := &models.Geofence{}
+	g.Name = i.Name
+	g.Latitude = i.Latitude
+	g.Longitude = i.Longitude
+	g.RadiusMeters = i.RadiusMeters
+	return g
+}
@@ -0,0 +1,41 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+type HolidayPayload struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+func (i *HolidayPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Date, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+func (i *HolidayPayload) ToHoliday() (*models.Holiday, error) {
+	date, err := time.ParseInLocation(dateLayout, i.Date, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	h := &models.Holiday{}
+	h.Date = date
+	h.Name = i.Name
+	return h, nil
+}
+
+type HolidaysQueryParam struct {
+	Year int `form:"year"`
+}
+
+func NewHolidaysQueryParam(year int) HolidaysQueryParam {
+	return HolidaysQueryParam{
+		Year: year,
+	}
+}
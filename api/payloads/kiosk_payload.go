@@ -0,0 +1,57 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/Songmu/flextime"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+type KioskPayload struct {
+	Name string `json:"name"`
+}
+
+func (i *KioskPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+func (i *KioskPayload) ToKiosk() *models.Kiosk {
+	k := &models.Kiosk{}
+	k.Name = i.Name
+	return k
+}
+
+// KioskPunchPayload is a punch at a kiosk by the punch code the employee presented.
+type KioskPunchPayload struct {
+	Code   string `json:"code"`
+	Remark string `json:"remark"`
+}
+
+func (i *KioskPunchPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Code, validation.Required, validation.Length(1, 200)),
+		validation.Field(&i.Remark, validation.Length(0, 100)),
+	)
+}
+
+func (i *KioskPunchPayload) ToAttendanceTime(kioskID int64) *models.AttendanceTime {
+	t := &models.AttendanceTime{}
+	t.Remark = i.Remark
+	t.KioskID = kioskID
+	t.PushedAt = flextime.Now()
+	t.CreatedAt = flextime.Now()
+	t.UpdatedAt = flextime.Now()
+	return t
+}
+
+// PunchCodesPayload is the users to issue punch codes for, e.g. to print their badges.
+type PunchCodesPayload struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+func (i *PunchCodesPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.UserIDs, validation.Required, validation.Length(1, 100)),
+	)
+}
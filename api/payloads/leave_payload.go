@@ -0,0 +1,63 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+type LeaveRequestPayload struct {
+	LeaveKindID uint8   `json:"leave_kind_id"`
+	LeaveUnitID uint8   `json:"leave_unit_id"`
+	StartDate   string  `json:"start_date"`
+	EndDate     string  `json:"end_date"`
+	Hours       float64 `json:"hours"`
+	Reason      string  `json:"reason"`
+}
+
+func (i *LeaveRequestPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.LeaveKindID, validation.Required, validation.In(
+			uint8(models.LeaveKindPaid),
+			uint8(models.LeaveKindSick),
+			uint8(models.LeaveKindSpecial),
+			uint8(models.LeaveKindUnpaid),
+		)),
+		validation.Field(&i.LeaveUnitID, validation.Required, validation.In(
+			uint8(models.LeaveUnitDay),
+			uint8(models.LeaveUnitHalfDay),
+			uint8(models.LeaveUnitHour),
+		)),
+		validation.Field(&i.StartDate, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.EndDate, validation.Date(dateLayout)),
+		validation.Field(&i.Reason, validation.Length(0, 250)),
+	)
+}
+
+// ToLeaveRequest converts the payload. Without an end date the leave is taken on the start date only.
+func (i *LeaveRequestPayload) ToLeaveRequest(userID string) (*models.LeaveRequest, error) {
+	start, err := time.ParseInLocation(dateLayout, i.StartDate, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if i.EndDate != "" {
+		if end, err = time.ParseInLocation(dateLayout, i.EndDate, timezone.JSTLocation()); err != nil {
+			return nil, err
+		}
+	}
+	r := &models.LeaveRequest{}
+	r.UserID = userID
+	r.LeaveKindID = i.LeaveKindID
+	r.LeaveUnitID = i.LeaveUnitID
+	r.StartDate = start
+	r.EndDate = end
+	r.Hours = i.Hours
+	r.Reason = i.Reason
+	return r, nil
+}
+
+type LeaveRequestsQueryParam struct {
+	Status uint8 `form:"status"`
+}
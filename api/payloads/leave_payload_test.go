@@ -0,0 +1,65 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"testing"
+	"time"
+)
+
+func TestLeaveRequestPayload_ToLeaveRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload LeaveRequestPayload
+		wantEnd time.Time
+		wantErr bool
+	}{
+		{
+			name: "Should take end date",
+			payload: LeaveRequestPayload{
+				LeaveKindID: uint8(models.LeaveKindPaid),
+				LeaveUnitID: uint8(models.LeaveUnitDay),
+				StartDate:   "2020-05-18",
+				EndDate:     "2020-05-19",
+			},
+			wantEnd: time.Date(2020, 5, 19, 0, 0, 0, 0, timezone.JSTLocation()),
+		},
+		{
+			name: "Should default end date to start date",
+			payload: LeaveRequestPayload{
+				LeaveKindID: uint8(models.LeaveKindPaid),
+				LeaveUnitID: uint8(models.LeaveUnitHalfDay),
+				StartDate:   "2020-05-18",
+			},
+			wantEnd: time.Date(2020, 5, 18, 0, 0, 0, 0, timezone.JSTLocation()),
+		},
+		{
+			name: "Should not validate unknown kind",
+			payload: LeaveRequestPayload{
+				LeaveKindID: 9,
+				LeaveUnitID: uint8(models.LeaveUnitDay),
+				StartDate:   "2020-05-18",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.payload.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := tt.payload.ToLeaveRequest("user")
+			if err != nil {
+				t.Errorf("ToLeaveRequest() error = %v", err)
+				return
+			}
+			if !got.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("ToLeaveRequest() end date = %v, want %v", got.EndDate, tt.wantEnd)
+			}
+		})
+	}
+}
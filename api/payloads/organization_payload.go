@@ -0,0 +1,105 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+// DepartmentPayload is a department. Without a parent it is at the top of the organisation.
+type DepartmentPayload struct {
+	ParentID int64  `json:"parent_id"`
+	Name     string `json:"name"`
+}
+
+func (i *DepartmentPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.ParentID, validation.Min(int64(0))),
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+func (i *DepartmentPayload) ToDepartment(id int64) *models.Department {
+	d := &models.Department{}
+	d.ID = id
+	d.ParentID = i.ParentID
+	d.Name = i.Name
+	return d
+}
+
+type TeamPayload struct {
+	DepartmentID int64  `json:"department_id"`
+	Name         string `json:"name"`
+}
+
+func (i *TeamPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.DepartmentID, validation.Required),
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+func (i *TeamPayload) ToTeam(id int64) *models.Team {
+	t := &models.Team{}
+	t.ID = id
+	t.DepartmentID = i.DepartmentID
+	t.Name = i.Name
+	return t
+}
+
+// AssignmentPayload puts the user in the team and under the manager from the start date.
+// Without a team or a manager the user has none from then.
+type AssignmentPayload struct {
+	UserID    string `json:"user_id"`
+	TeamID    int64  `json:"team_id"`
+	ManagerID string `json:"manager_id"`
+	StartDate string `json:"start_date"`
+}
+
+func (i *AssignmentPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.UserID, validation.Required, validation.Length(1, 100)),
+		validation.Field(&i.TeamID, validation.Min(int64(0))),
+		validation.Field(&i.ManagerID, validation.Length(0, 100)),
+		validation.Field(&i.StartDate, validation.Required, validation.Date(dateLayout)),
+	)
+}
+
+func (i *AssignmentPayload) ToAssignment() (*models.Assignment, error) {
+	start, err := time.ParseInLocation(dateLayout, i.StartDate, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	a := &models.Assignment{}
+	a.UserID = i.UserID
+	a.TeamID = i.TeamID
+	a.ManagerID = i.ManagerID
+	a.StartedAt = start
+	return a, nil
+}
+
+// OrganizationQueryParam selects the day the organisation is looked at, today by default.
+// Indirect includes the reports of the reports.
+type OrganizationQueryParam struct {
+	Date     string `form:"date"`
+	Indirect bool   `form:"indirect"`
+}
+
+func (i *OrganizationQueryParam) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Date, validation.Date(dateLayout)),
+	)
+}
+
+func (i *OrganizationQueryParam) At() (time.Time, error) {
+	if i.Date == "" {
+		return flextime.Now(), nil
+	}
+	return time.ParseInLocation(dateLayout, i.Date, timezone.JSTLocation())
+}
+
+type TeamsQueryParam struct {
+	DepartmentID int64 `form:"department_id"`
+}
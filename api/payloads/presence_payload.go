@@ -0,0 +1,26 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+// TeamAttendancesQueryParam selects the day the attendances of a team are looked at, today by default.
+type TeamAttendancesQueryParam struct {
+	Date string `form:"date"`
+}
+
+func (i *TeamAttendancesQueryParam) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Date, validation.Date(dateLayout)),
+	)
+}
+
+func (i *TeamAttendancesQueryParam) At() (time.Time, error) {
+	if i.Date == "" {
+		return flextime.Now(), nil
+	}
+	return time.ParseInLocation(dateLayout, i.Date, timezone.JSTLocation())
+}
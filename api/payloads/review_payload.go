@@ -0,0 +1,16 @@
+package payloads
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+// ReviewPayload is the body of an approve or reject request.
+type ReviewPayload struct {
+	Comment string `json:"comment"`
+}
+
+func (i *ReviewPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Comment, validation.Length(0, 250)),
+	)
+}
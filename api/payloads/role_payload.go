@@ -0,0 +1,36 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"golang.org/x/xerrors"
+)
+
+// UserRolesPayload replaces the roles of a user. The employee role is always given.
+type UserRolesPayload struct {
+	RoleIDs []int `json:"role_ids"`
+}
+
+func (i *UserRolesPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.RoleIDs, validation.Length(0, 10), validation.By(areRoles)),
+	)
+}
+
+func (i *UserRolesPayload) ToRoles() []models.Role {
+	roles := make([]models.Role, 0)
+	for _, id := range i.RoleIDs {
+		roles = append(roles, models.Role(id))
+	}
+	return roles
+}
+
+func areRoles(value interface{}) error {
+	ids, _ := value.([]int)
+	for _, id := range ids {
+		if id < 0 || id > 255 || !models.Role(id).IsValid() {
+			return xerrors.Errorf("role %d is invalid", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+type RoundingRulePayload struct {
+	AttendanceKindID    uint8 `json:"attendance_kind_id"`
+	UnitMinutes         int   `json:"unit_minutes"`
+	RoundingDirectionID uint8 `json:"rounding_direction_id"`
+}
+
+func (i RoundingRulePayload) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.AttendanceKindID, validation.Required, validation.In(
+			uint8(models.AttendanceKindClockIn),
+			uint8(models.AttendanceKindClockOut),
+			uint8(models.AttendanceKindBreakStart),
+			uint8(models.AttendanceKindBreakEnd),
+		)),
+		validation.Field(&i.UnitMinutes, validation.Required, validation.Min(1), validation.Max(60)),
+		validation.Field(&i.RoundingDirectionID, validation.Required, validation.In(
+			uint8(models.RoundingDirectionUp),
+			uint8(models.RoundingDirectionDown),
+			uint8(models.RoundingDirectionNearest),
+		)),
+	)
+}
+
+// RoundingRulesPayload replaces all the rounding rules. Punch kinds without a rule are not rounded.
+type RoundingRulesPayload struct {
+	Rules []RoundingRulePayload `json:"rules"`
+}
+
+func (i *RoundingRulesPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Rules),
+	)
+}
+
+func (i *RoundingRulesPayload) ToRoundingRules() models.RoundingRules {
+	rules := make(models.RoundingRules, 0, len(i.Rules))
+	for _, r := range i.Rules {
+		rules = append(rules, &models.RoundingRule{
+			AttendanceKindID:    r.AttendanceKindID,
+			UnitMinutes:         r.UnitMinutes,
+			RoundingDirectionID: r.RoundingDirectionID,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,47 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"testing"
+)
+
+func TestRoundingRulesPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload RoundingRulesPayload
+		wantErr bool
+	}{
+		{
+			name: "Should validate",
+			payload: RoundingRulesPayload{Rules: []RoundingRulePayload{
+				{AttendanceKindID: uint8(models.AttendanceKindClockIn), UnitMinutes: 15, RoundingDirectionID: uint8(models.RoundingDirectionUp)},
+				{AttendanceKindID: uint8(models.AttendanceKindClockOut), UnitMinutes: 15, RoundingDirectionID: uint8(models.RoundingDirectionDown)},
+			}},
+		},
+		{
+			name:    "Should validate no rules",
+			payload: RoundingRulesPayload{},
+		},
+		{
+			name: "Should not validate unknown direction",
+			payload: RoundingRulesPayload{Rules: []RoundingRulePayload{
+				{AttendanceKindID: uint8(models.AttendanceKindClockIn), UnitMinutes: 15, RoundingDirectionID: 9},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Should not validate unit over an hour",
+			payload: RoundingRulesPayload{Rules: []RoundingRulePayload{
+				{AttendanceKindID: uint8(models.AttendanceKindClockIn), UnitMinutes: 90, RoundingDirectionID: uint8(models.RoundingDirectionUp)},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.payload.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"time"
+)
+
+// ShiftTemplatePayload is a shift pattern. The times are given as "HH:MM"
+// and an end time at or before the start time ends on the next day.
+type ShiftTemplatePayload struct {
+	Name         string `json:"name"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	BreakMinutes int    `json:"break_minutes"`
+}
+
+func (i *ShiftTemplatePayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&i.StartTime, validation.Required, validation.Date(clockLayout)),
+		validation.Field(&i.EndTime, validation.Required, validation.Date(clockLayout)),
+		validation.Field(&i.BreakMinutes, validation.Min(0)),
+	)
+}
+
+func (i *ShiftTemplatePayload) ToShiftTemplate() (*models.ShiftTemplate, error) {
+	start, err := clockMinutes(i.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := clockMinutes(i.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	t := &models.ShiftTemplate{}
+	t.Name = i.Name
+	t.StartMinutes = start
+	t.EndMinutes = end
+	t.BreakMinutes = i.BreakMinutes
+	return t, nil
+}
+
+type ShiftAssignmentPayload struct {
+	UserID          string `json:"user_id"`
+	Date            string `json:"date"`
+	ShiftTemplateID int64  `json:"shift_template_id"`
+}
+
+func (i ShiftAssignmentPayload) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.UserID, validation.Required),
+		validation.Field(&i.Date, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.ShiftTemplateID, validation.Required),
+	)
+}
+
+// ShiftRosterPayload publishes the shifts of everyone from the start date to the end date,
+// replacing the shifts published before for those days.
+type ShiftRosterPayload struct {
+	StartDate string                   `json:"start_date"`
+	EndDate   string                   `json:"end_date"`
+	Shifts    []ShiftAssignmentPayload `json:"shifts"`
+}
+
+func (i *ShiftRosterPayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.StartDate, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.EndDate, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&i.Shifts),
+	)
+}
+
+func (i *ShiftRosterPayload) ToShiftRoster() (*models.ShiftRoster, error) {
+	start, err := time.ParseInLocation(dateLayout, i.StartDate, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.ParseInLocation(dateLayout, i.EndDate, timezone.JSTLocation())
+	if err != nil {
+		return nil, err
+	}
+	r := &models.ShiftRoster{}
+	r.Start = start
+	r.End = end
+	r.Shifts = make([]*models.ShiftAssignment, 0, len(i.Shifts))
+	for _, s := range i.Shifts {
+		date, err := time.ParseInLocation(dateLayout, s.Date, timezone.JSTLocation())
+		if err != nil {
+			return nil, err
+		}
+		r.Shifts = append(r.Shifts, &models.ShiftAssignment{
+			UserID:          s.UserID,
+			ShiftTemplateID: s.ShiftTemplateID,
+			WorkDate:        date,
+		})
+	}
+	return r, nil
+}
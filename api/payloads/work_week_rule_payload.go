@@ -0,0 +1,26 @@
+package payloads
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+)
+
+// WorkWeekRulePayload is the statutory holiday of the company: 0 is Sunday and 6 is Saturday.
+// The weekday is a pointer so that Sunday is not taken for a missing value.
+type WorkWeekRulePayload struct {
+	StatutoryHolidayWeekday *uint8 `json:"statutory_holiday_weekday"`
+}
+
+func (i *WorkWeekRulePayload) Validate() error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.StatutoryHolidayWeekday, validation.NotNil, validation.Max(uint8(6))),
+	)
+}
+
+func (i *WorkWeekRulePayload) ToWorkWeekRule() *models.WorkWeekRule {
+	r := &models.WorkWeekRule{}
+	if i.StatutoryHolidayWeekday != nil {
+		r.StatutoryHolidayWeekday = *i.StatutoryHolidayWeekday
+	}
+	return r
+}
@@ -0,0 +1,59 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type AllowedNetworkResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	CIDR string `json:"cidr"`
+}
+
+type AllowedNetworkResult struct {
+	CommonResponse
+	Network *AllowedNetworkResponse `json:"network"`
+}
+
+type AllowedNetworksResponses struct {
+	CommonResponse
+	Networks []*AllowedNetworkResponse `json:"networks"`
+}
+
+func toAllowedNetworkResponse(n *models.AllowedNetwork) *AllowedNetworkResponse {
+	return &AllowedNetworkResponse{
+		ID:   n.ID,
+		Name: n.Name,
+		CIDR: n.CIDR,
+	}
+}
+
+func ToAllowedNetworkResult(n *models.AllowedNetwork) *AllowedNetworkResult {
+	res := &AllowedNetworkResult{}
+	res.IsSuccessful = true
+	res.Network = toAllowedNetworkResponse(n)
+	return res
+}
+
+func ToAllowedNetworksResponses(networks models.AllowedNetworks) *AllowedNetworksResponses {
+	res := &AllowedNetworksResponses{}
+	responses := make([]*AllowedNetworkResponse, 0)
+	for _, n := range networks {
+		responses = append(responses, toAllowedNetworkResponse(n))
+	}
+	res.IsSuccessful = true
+	res.Networks = responses
+	return res
+}
+
+type NetworkRestrictionResult struct {
+	CommonResponse
+	NetworkRestrictionModeID uint8 `json:"network_restriction_mode_id"`
+}
+
+func ToNetworkRestrictionResult(r *models.NetworkRestriction) *NetworkRestrictionResult {
+	res := &NetworkRestrictionResult{}
+	res.IsSuccessful = true
+	res.NetworkRestrictionModeID = r.NetworkRestrictionModeID
+	return res
+}
@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type AnomalyResponse struct {
+	ID               int64  `json:"id"`
+	UserID           string `json:"user_id"`
+	AttendanceID     int64  `json:"attendance_id"`
+	AttendanceTimeID int64  `json:"attendance_time_id"`
+	AnomalyKindID    uint8  `json:"anomaly_kind_id"`
+	AnomalyKind      string `json:"anomaly_kind"`
+	PushedAt         string `json:"pushed_at"`
+	IsResolved       bool   `json:"is_resolved"`
+	ResolvedAt       string `json:"resolved_at"`
+	CreatedAt        string `json:"created_at"`
+}
+
+type AnomaliesResponses struct {
+	CommonResponse
+	Anomalies []*AnomalyResponse `json:"anomalies"`
+}
+
+func ToAnomaliesResponses(anomalies []*models.AttendanceAnomaly) *AnomaliesResponses {
+	res := &AnomaliesResponses{}
+	responses := make([]*AnomalyResponse, 0)
+	for _, a := range anomalies {
+		resp := &AnomalyResponse{
+			ID:               a.ID,
+			UserID:           a.UserID,
+			AttendanceID:     a.AttendanceID,
+			AttendanceTimeID: a.AttendanceTimeID,
+			AnomalyKindID:    a.AnomalyKindID,
+			AnomalyKind:      a.Kind().String(),
+			PushedAt:         a.PushedAt.Format(time.RFC3339),
+			IsResolved:       a.IsResolved(),
+			CreatedAt:        a.CreatedAt.Format(time.RFC3339),
+		}
+		if a.IsResolved() {
+			resp.ResolvedAt = a.ResolvedAt.Format(time.RFC3339)
+		}
+		responses = append(responses, resp)
+	}
+	res.IsSuccessful = true
+	res.Anomalies = responses
+	return res
+}
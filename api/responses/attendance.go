@@ -6,23 +6,54 @@ import (
 )
 
 type AttendanceTimeResponse struct {
-	ID               int64  `json:"id"`
-	AttendanceID     int64  `json:"attendance_id"`
-	AttendanceKindID uint8  `json:"attendance_kind_id"`
-	IsModified       bool   `json:"is_modified"`
-	PushedAt         string `json:"pushed_at"`
-	Remark           string `json:"remark"`
-	CreatedAt        string `json:"created_at"`
-	UpdatedAt        string `json:"updated_at"`
+	ID                  int64             `json:"id"`
+	AttendanceID        int64             `json:"attendance_id"`
+	AttendanceKindID    uint8             `json:"attendance_kind_id"`
+	IsModified          bool              `json:"is_modified"`
+	IsAutomatic         bool              `json:"is_automatic"`
+	WorkLocationID      uint8             `json:"work_location_id"`
+	Location            *LocationResponse `json:"location"`
+	GeofenceID          int64             `json:"geofence_id"`
+	GeofenceStatusID    uint8             `json:"geofence_status_id"`
+	IPAddress           string            `json:"ip_address"`
+	NetworkStatusID     uint8             `json:"network_status_id"`
+	KioskID             int64             `json:"kiosk_id"`
+	PushedAt            string            `json:"pushed_at"`
+	RoundedAt           string            `json:"rounded_at"`
+	Remark              string            `json:"remark"`
+	ApprovedBy          string            `json:"approved_by"`
+	CorrectionRequestID int64             `json:"correction_request_id"`
+	CreatedAt           string            `json:"created_at"`
+	UpdatedAt           string            `json:"updated_at"`
 }
 
-type AttendanceResponse struct {
-	ID             int64                   `json:"id"`
-	UserID         string                  `json:"user_id"`
+// LocationResponse is the location of the device sent with a punch.
+type LocationResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float64 `json:"accuracy"`
+}
+
+type AttendanceSessionResponse struct {
 	ClockedInTime  *AttendanceTimeResponse `json:"clocked_in_time"`
 	ClockedOutTime *AttendanceTimeResponse `json:"clocked_out_time"`
-	CreatedAt      string                  `json:"created_at"`
-	UpdatedAt      string                  `json:"updated_at"`
+}
+
+type AttendanceBreakResponse struct {
+	StartedTime *AttendanceTimeResponse `json:"started_time"`
+	EndedTime   *AttendanceTimeResponse `json:"ended_time"`
+}
+
+type AttendanceResponse struct {
+	ID             int64                        `json:"id"`
+	UserID         string                       `json:"user_id"`
+	ClockedInTime  *AttendanceTimeResponse      `json:"clocked_in_time"`
+	ClockedOutTime *AttendanceTimeResponse      `json:"clocked_out_time"`
+	Sessions       []*AttendanceSessionResponse `json:"sessions"`
+	Breaks         []*AttendanceBreakResponse   `json:"breaks"`
+	Shift          *ShiftCheckResponse          `json:"shift"`
+	CreatedAt      string                       `json:"created_at"`
+	UpdatedAt      string                       `json:"updated_at"`
 }
 
 type AttendanceCreatedResponse struct {
@@ -31,16 +62,58 @@ type AttendanceCreatedResponse struct {
 	IsClockedOut bool                `json:"is_clocked_out"`
 }
 
+// AttendancesResponses lists the attendances with their planned shifts.
+// Shifts the user did not show up for have no attendance and are listed in NoShows.
 type AttendancesResponses struct {
 	CommonResponse
 	Attendances []*AttendanceResponse `json:"attendances"`
+	NoShows     []*ShiftCheckResponse `json:"no_shows"`
+}
+
+// AttendanceHistoryTimeResponse is a punch in the edit history.
+// ChangedBy is who recorded it and Remark holds why, e.g. the reason of the correction request.
+type AttendanceHistoryTimeResponse struct {
+	*AttendanceTimeResponse
+	ChangedBy string `json:"changed_by"`
+}
+
+type AttendanceHistoryResponse struct {
+	CommonResponse
+	Attendance *AttendanceResponse              `json:"attendance"`
+	Times      []*AttendanceHistoryTimeResponse `json:"times"`
 }
 
 type AttendanceSummaryResponse struct {
 	CommonResponse
-	LatestAttendance *models.Attendance `json:"latest_attendance"`
-	RequiredHours    float64            `json:"required_time"`
-	TotalHours       float64            `json:"total_time"`
+	LatestAttendance  *models.Attendance `json:"latest_attendance"`
+	RequiredHours     float64            `json:"required_time"`
+	TotalHours        float64            `json:"total_time"`
+	RoundedTotalHours float64            `json:"rounded_total_time"`
+	LeaveHours        float64            `json:"leave_time"`
+	OfficeDays        int                `json:"office_days"`
+	RemoteDays        int                `json:"remote_days"`
+	ClientSiteDays    int                `json:"client_site_days"`
+}
+
+// WorkBreakdownResponse is the working time in hours split into payroll buckets.
+// LateNight and NonStatutoryHoliday overlap the other buckets.
+type WorkBreakdownResponse struct {
+	Statutory           float64 `json:"statutory_time"`
+	LegalOvertime       float64 `json:"legal_overtime"`
+	LateNight           float64 `json:"late_night_time"`
+	StatutoryHoliday    float64 `json:"statutory_holiday_time"`
+	NonStatutoryHoliday float64 `json:"non_statutory_holiday_time"`
+}
+
+type DailyWorkBreakdownResponse struct {
+	Date string `json:"date"`
+	*WorkBreakdownResponse
+}
+
+type WorkBreakdownResult struct {
+	CommonResponse
+	Days  []*DailyWorkBreakdownResponse `json:"days"`
+	Total *WorkBreakdownResponse        `json:"total"`
 }
 
 func toAttendanceResponse(a *models.Attendance) *AttendanceResponse {
@@ -53,22 +126,68 @@ func toAttendanceResponse(a *models.Attendance) *AttendanceResponse {
 	if a.ClockedOut != nil {
 		resp.ClockedOutTime = toAttendanceTimeResponse(a.ClockedOut)
 	}
+	resp.Sessions = make([]*AttendanceSessionResponse, 0)
+	for _, session := range a.Sessions {
+		resp.Sessions = append(resp.Sessions, toAttendanceSessionResponse(session))
+	}
+	resp.Breaks = make([]*AttendanceBreakResponse, 0)
+	for _, b := range a.Breaks {
+		resp.Breaks = append(resp.Breaks, toAttendanceBreakResponse(b))
+	}
 	resp.CreatedAt = a.CreatedAt.Format(time.RFC3339)
 	resp.UpdatedAt = a.UpdatedAt.Format(time.RFC3339)
 	return resp
 }
 
+func toAttendanceSessionResponse(session *models.AttendanceSession) *AttendanceSessionResponse {
+	resp := &AttendanceSessionResponse{}
+	resp.ClockedInTime = toAttendanceTimeResponse(session.ClockedIn)
+	if session.ClockedOut != nil {
+		resp.ClockedOutTime = toAttendanceTimeResponse(session.ClockedOut)
+	}
+	return resp
+}
+
+func toAttendanceBreakResponse(b *models.AttendanceBreak) *AttendanceBreakResponse {
+	resp := &AttendanceBreakResponse{}
+	resp.StartedTime = toAttendanceTimeResponse(b.Start)
+	if b.End != nil {
+		resp.EndedTime = toAttendanceTimeResponse(b.End)
+	}
+	return resp
+}
+
 func toAttendanceTimeResponse(t *models.AttendanceTime) *AttendanceTimeResponse {
-	return &AttendanceTimeResponse{
-		ID:               t.ID,
-		AttendanceID:     t.AttendanceID,
-		AttendanceKindID: t.AttendanceKindID,
-		IsModified:       t.IsModified,
-		PushedAt:         t.PushedAt.Format(time.RFC3339),
-		Remark:           t.Remark,
-		CreatedAt:        t.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:        t.UpdatedAt.Format(time.RFC3339),
+	resp := &AttendanceTimeResponse{
+		ID:                  t.ID,
+		AttendanceID:        t.AttendanceID,
+		AttendanceKindID:    t.AttendanceKindID,
+		IsModified:          t.IsModified,
+		IsAutomatic:         t.IsAutomatic,
+		WorkLocationID:      uint8(t.WorkLocation()),
+		GeofenceID:          t.GeofenceID,
+		GeofenceStatusID:    t.GeofenceStatusID,
+		IPAddress:           t.IPAddress,
+		NetworkStatusID:     t.NetworkStatusID,
+		KioskID:             t.KioskID,
+		PushedAt:            t.PushedAt.Format(time.RFC3339),
+		Remark:              t.Remark,
+		ApprovedBy:          t.ApprovedBy,
+		CorrectionRequestID: t.CorrectionRequestID,
+		CreatedAt:           t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           t.UpdatedAt.Format(time.RFC3339),
 	}
+	if !t.RoundedAt.IsZero() {
+		resp.RoundedAt = t.RoundedAt.Format(time.RFC3339)
+	}
+	if t.HasLocation {
+		resp.Location = &LocationResponse{
+			Latitude:  t.Latitude,
+			Longitude: t.Longitude,
+			Accuracy:  t.LocationAccuracy,
+		}
+	}
+	return resp
 }
 
 func ToAttendanceCreatedResponse(attendance *models.Attendance) *AttendanceCreatedResponse {
@@ -80,23 +199,44 @@ func ToAttendanceCreatedResponse(attendance *models.Attendance) *AttendanceCreat
 	return res
 }
 
-func ToAttendancesResponses(attendances []*models.Attendance) *AttendancesResponses {
+func ToAttendancesResponses(results *models.GetAttendancesResults) *AttendancesResponses {
 	res := &AttendancesResponses{}
+	shifts := make(map[int64]*models.ShiftCheck)
+	noShows := make([]*ShiftCheckResponse, 0)
+	for _, c := range results.Shifts {
+		if c.Attendance == nil {
+			if c.IsNoShow {
+				noShows = append(noShows, toShiftCheckResponse(c))
+			}
+			continue
+		}
+		shifts[c.Attendance.ID] = c
+	}
+
 	responses := make([]*AttendanceResponse, 0)
-	for _, attendance := range attendances {
+	for _, attendance := range results.Attendances {
 		resp := toAttendanceResponse(attendance)
+		if c, ok := shifts[attendance.ID]; ok {
+			resp.Shift = toShiftCheckResponse(c)
+		}
 		responses = append(responses, resp)
 	}
 
 	res.IsSuccessful = true
 	res.Attendances = responses
+	res.NoShows = noShows
 	return res
 }
 
 func ToAttendanceSummaryResponse(results *models.GetAttendanceSummaryResults) *AttendanceSummaryResponse {
 	res := AttendanceSummaryResponse{
-		TotalHours:    results.TotalHours,
-		RequiredHours: results.RequiredHours,
+		TotalHours:        results.TotalHours,
+		RoundedTotalHours: results.RoundedTotalHours,
+		LeaveHours:        results.LeaveHours,
+		RequiredHours:     results.RequiredHours,
+		OfficeDays:        results.WorkLocationDays.Office,
+		RemoteDays:        results.WorkLocationDays.Remote,
+		ClientSiteDays:    results.WorkLocationDays.ClientSite,
 	}
 	if results.LatestAttendance.ID != 0 {
 		res.LatestAttendance = &results.LatestAttendance
@@ -104,3 +244,41 @@ func ToAttendanceSummaryResponse(results *models.GetAttendanceSummaryResults) *A
 	res.IsSuccessful = true
 	return &res
 }
+
+func ToAttendanceHistoryResponse(results *models.GetAttendanceHistoryResults) *AttendanceHistoryResponse {
+	res := &AttendanceHistoryResponse{}
+	res.Attendance = toAttendanceResponse(results.Attendance)
+	res.Times = make([]*AttendanceHistoryTimeResponse, 0)
+	for _, t := range results.Times {
+		res.Times = append(res.Times, &AttendanceHistoryTimeResponse{
+			AttendanceTimeResponse: toAttendanceTimeResponse(t),
+			ChangedBy:              t.ChangedBy(results.Attendance.UserID),
+		})
+	}
+	res.IsSuccessful = true
+	return res
+}
+
+func toWorkBreakdownResponse(b models.WorkBreakdown) *WorkBreakdownResponse {
+	return &WorkBreakdownResponse{
+		Statutory:           b.Statutory.Hours(),
+		LegalOvertime:       b.LegalOvertime.Hours(),
+		LateNight:           b.LateNight.Hours(),
+		StatutoryHoliday:    b.StatutoryHoliday.Hours(),
+		NonStatutoryHoliday: b.NonStatutoryHoliday.Hours(),
+	}
+}
+
+func ToWorkBreakdownResult(results *models.GetWorkBreakdownResults) *WorkBreakdownResult {
+	res := &WorkBreakdownResult{}
+	res.Days = make([]*DailyWorkBreakdownResponse, 0)
+	for _, d := range results.Days {
+		res.Days = append(res.Days, &DailyWorkBreakdownResponse{
+			Date:                  d.Date.Format("2006-01-02"),
+			WorkBreakdownResponse: toWorkBreakdownResponse(d.WorkBreakdown),
+		})
+	}
+	res.Total = toWorkBreakdownResponse(results.Total)
+	res.IsSuccessful = true
+	return res
+}
@@ -0,0 +1,25 @@
+package responses
+
+import "github.com/KouT127/attendance-management/domain/models"
+
+type AutoClockOutPolicyResponse struct {
+	IsEnabled  bool   `json:"is_enabled"`
+	CutoffTime string `json:"cutoff_time"`
+	Remark     string `json:"remark"`
+}
+
+type AutoClockOutPolicyResult struct {
+	CommonResponse
+	Policy *AutoClockOutPolicyResponse `json:"policy"`
+}
+
+func ToAutoClockOutPolicyResult(p *models.AutoClockOutPolicy) *AutoClockOutPolicyResult {
+	res := &AutoClockOutPolicyResult{}
+	res.IsSuccessful = true
+	res.Policy = &AutoClockOutPolicyResponse{
+		IsEnabled:  p.IsEnabled,
+		CutoffTime: formatMinutes(p.CutoffMinutes),
+		Remark:     p.Remark,
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"time"
+)
+
+type ContractResponse struct {
+	ID               int64   `json:"id"`
+	UserID           string  `json:"user_id"`
+	EmploymentTypeID uint8   `json:"employment_type_id"`
+	WeeklyHours      float64 `json:"weekly_hours"`
+	DailyHours       float64 `json:"daily_hours"`
+	Workdays         []int   `json:"workdays"`
+	StartDate        string  `json:"start_date"`
+	EndDate          string  `json:"end_date"`
+}
+
+type ContractResult struct {
+	CommonResponse
+	Contract *ContractResponse `json:"contract"`
+}
+
+type ContractsResponses struct {
+	CommonResponse
+	Contracts []*ContractResponse `json:"contracts"`
+}
+
+func toContractResponse(c *models.EmploymentContract) *ContractResponse {
+	resp := &ContractResponse{
+		ID:               c.ID,
+		UserID:           c.UserID,
+		EmploymentTypeID: c.EmploymentTypeID,
+		WeeklyHours:      c.WeeklyHours,
+		DailyHours:       c.DailyHours(),
+		Workdays:         make([]int, 0),
+		StartDate:        c.StartedAt.In(timezone.JSTLocation()).Format("2006-01-02"),
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if models.Workdays(c.Workdays).Contains(d) {
+			resp.Workdays = append(resp.Workdays, int(d))
+		}
+	}
+	if !c.FinishedAt.IsZero() {
+		resp.EndDate = c.FinishedAt.In(timezone.JSTLocation()).AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return resp
+}
+
+func ToContractResult(c *models.EmploymentContract) *ContractResult {
+	res := &ContractResult{}
+	res.IsSuccessful = true
+	res.Contract = toContractResponse(c)
+	return res
+}
+
+func ToContractsResponses(contracts models.EmploymentContracts) *ContractsResponses {
+	res := &ContractsResponses{}
+	responses := make([]*ContractResponse, 0)
+	for _, c := range contracts {
+		responses = append(responses, toContractResponse(c))
+	}
+	res.IsSuccessful = true
+	res.Contracts = responses
+	return res
+}
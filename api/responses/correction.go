@@ -0,0 +1,74 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"time"
+)
+
+type CorrectionRequestResponse struct {
+	ID                 int64  `json:"id"`
+	UserID             string `json:"user_id"`
+	AttendanceID       int64  `json:"attendance_id"`
+	AttendanceTimeID   int64  `json:"attendance_time_id"`
+	AttendanceKindID   uint8  `json:"attendance_kind_id"`
+	Date               string `json:"date"`
+	PushedAt           string `json:"pushed_at"`
+	Reason             string `json:"reason"`
+	CorrectionStatusID uint8  `json:"correction_status_id"`
+	ReviewerID         string `json:"reviewer_id"`
+	ReviewComment      string `json:"review_comment"`
+	ReviewedAt         string `json:"reviewed_at"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+type CorrectionRequestResult struct {
+	CommonResponse
+	CorrectionRequest *CorrectionRequestResponse `json:"correction_request"`
+}
+
+type CorrectionRequestsResponses struct {
+	CommonResponse
+	CorrectionRequests []*CorrectionRequestResponse `json:"correction_requests"`
+}
+
+func toCorrectionRequestResponse(r *models.CorrectionRequest) *CorrectionRequestResponse {
+	resp := &CorrectionRequestResponse{
+		ID:                 r.ID,
+		UserID:             r.UserID,
+		AttendanceID:       r.AttendanceID,
+		AttendanceTimeID:   r.AttendanceTimeID,
+		AttendanceKindID:   r.AttendanceKindID,
+		Date:               r.TargetDate.In(timezone.JSTLocation()).Format("2006-01-02"),
+		PushedAt:           r.PushedAt.Format(time.RFC3339),
+		Reason:             r.Reason,
+		CorrectionStatusID: r.CorrectionStatusID,
+		ReviewerID:         r.ReviewerID,
+		ReviewComment:      r.ReviewComment,
+		CreatedAt:          r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          r.UpdatedAt.Format(time.RFC3339),
+	}
+	if !r.ReviewedAt.IsZero() {
+		resp.ReviewedAt = r.ReviewedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToCorrectionRequestResult(r *models.CorrectionRequest) *CorrectionRequestResult {
+	res := &CorrectionRequestResult{}
+	res.IsSuccessful = true
+	res.CorrectionRequest = toCorrectionRequestResponse(r)
+	return res
+}
+
+func ToCorrectionRequestsResponses(requests []*models.CorrectionRequest) *CorrectionRequestsResponses {
+	res := &CorrectionRequestsResponses{}
+	responses := make([]*CorrectionRequestResponse, 0)
+	for _, r := range requests {
+		responses = append(responses, toCorrectionRequestResponse(r))
+	}
+	res.IsSuccessful = true
+	res.CorrectionRequests = responses
+	return res
+}
@@ -28,4 +28,11 @@ func NewValidationError(key string, err error) ValidationError {
 const (
 	InvalidValueError = "指定した値が正しくありません"
 	BadAccessError    = "不正な値です"
+	NotFoundError     = "対象が見つかりません"
+	ForbiddenError    = "権限がありません"
+	ConflictError     = "現在の状態では実行できません"
+	LeaveBalanceError = "有給休暇の残日数が足りません"
+	GeofenceError     = "許可された場所の外では打刻できません"
+	NetworkError      = "許可されたネットワークの外では打刻できません"
+	PunchCodeError    = "打刻コードが正しくないか有効期限が切れています"
 )
@@ -0,0 +1,95 @@
+package responses
+
+import (
+	"fmt"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+)
+
+type FlextimeRuleResponse struct {
+	PeriodMonths     int    `json:"period_months"`
+	StartMonth       int    `json:"start_month"`
+	CoreStart        string `json:"core_start"`
+	CoreEnd          string `json:"core_end"`
+	CarryOverSurplus bool   `json:"carry_over_surplus"`
+}
+
+type FlextimeRuleResult struct {
+	CommonResponse
+	Rule *FlextimeRuleResponse `json:"rule"`
+}
+
+type FlextimeDayResponse struct {
+	Date         string  `json:"date"`
+	WorkedHours  float64 `json:"worked_time"`
+	IsLate       bool    `json:"is_late"`
+	IsEarlyLeave bool    `json:"is_early_leave"`
+	IsAway       bool    `json:"is_away"`
+	IsAbsent     bool    `json:"is_absent"`
+}
+
+// FlextimeSummaryResult is the settlement of the flextime period in hours.
+// A negative balance is the deficit of the period.
+type FlextimeSummaryResult struct {
+	CommonResponse
+	PeriodStart    string                 `json:"period_start"`
+	PeriodEnd      string                 `json:"period_end"`
+	RequiredHours  float64                `json:"required_time"`
+	WorkedHours    float64                `json:"worked_time"`
+	LeaveHours     float64                `json:"leave_time"`
+	CarryOverHours float64                `json:"carry_over_time"`
+	Balance        float64                `json:"balance"`
+	Rule           *FlextimeRuleResponse  `json:"rule"`
+	Days           []*FlextimeDayResponse `json:"days"`
+}
+
+func toFlextimeRuleResponse(r *models.FlextimeRule) *FlextimeRuleResponse {
+	resp := &FlextimeRuleResponse{
+		PeriodMonths:     r.PeriodMonths,
+		StartMonth:       r.StartMonth,
+		CarryOverSurplus: r.CarryOverSurplus,
+	}
+	if r.HasCoreTime() {
+		resp.CoreStart = formatMinutes(r.CoreStartMinutes)
+		resp.CoreEnd = formatMinutes(r.CoreEndMinutes)
+	}
+	return resp
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func ToFlextimeRuleResult(r *models.FlextimeRule) *FlextimeRuleResult {
+	res := &FlextimeRuleResult{}
+	res.IsSuccessful = true
+	res.Rule = toFlextimeRuleResponse(r)
+	return res
+}
+
+func ToFlextimeSummaryResult(results *models.GetFlextimeSummaryResults) *FlextimeSummaryResult {
+	s := results.Settlement
+	res := &FlextimeSummaryResult{
+		PeriodStart:    s.Start.In(timezone.JSTLocation()).Format("2006-01-02"),
+		PeriodEnd:      s.End.In(timezone.JSTLocation()).Format("2006-01-02"),
+		RequiredHours:  s.RequiredHours,
+		WorkedHours:    s.WorkedHours,
+		LeaveHours:     s.LeaveHours,
+		CarryOverHours: s.CarryOverHours,
+		Balance:        s.Balance(),
+		Rule:           toFlextimeRuleResponse(results.Rule),
+		Days:           make([]*FlextimeDayResponse, 0),
+	}
+	for _, d := range results.Days {
+		res.Days = append(res.Days, &FlextimeDayResponse{
+			Date:         d.Date.Format("2006-01-02"),
+			WorkedHours:  d.WorkedHours,
+			IsLate:       d.IsLate,
+			IsEarlyLeave: d.IsEarlyLeave,
+			IsAway:       d.IsAway,
+			IsAbsent:     d.IsAbsent,
+		})
+	}
+	res.IsSuccessful = true
+	return res
+}
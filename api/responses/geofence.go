@@ -0,0 +1,51 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type GeofenceResponse struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters float64 `json:"radius"`
+}
+
+type GeofenceResult struct {
+	CommonResponse
+	Geofence *GeofenceResponse `json:"geofence"`
+}
+
+type GeofencesResponses struct {
+	CommonResponse
+	Geofences []*GeofenceResponse `json:"geofences"`
+}
+
+func toGeofenceResponse(g *models.Geofence) *GeofenceResponse {
+	return &GeofenceResponse{
+		ID:           g.ID,
+		Name:         g.Name,
+		Latitude:     g.Latitude,
+		Longitude:    g.Longitude,
+		RadiusMeters: g.RadiusMeters,
+	}
+}
+
+func ToGeofenceResult(g *models.Geofence) *GeofenceResult {
+	res := &GeofenceResult{}
+	res.IsSuccessful = true
+	res.Geofence = toGeofenceResponse(g)
+	return res
+}
+
+func ToGeofencesResponses(geofences models.Geofences) *GeofencesResponses {
+	res := &GeofencesResponses{}
+	responses := make([]*GeofenceResponse, 0)
+	for _, g := range geofences {
+		responses = append(responses, toGeofenceResponse(g))
+	}
+	res.IsSuccessful = true
+	res.Geofences = responses
+	return res
+}
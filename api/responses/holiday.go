@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+)
+
+type HolidayResponse struct {
+	ID         int64  `json:"id"`
+	Date       string `json:"date"`
+	Name       string `json:"name"`
+	IsNational bool   `json:"is_national"`
+}
+
+type HolidayResult struct {
+	CommonResponse
+	Holiday *HolidayResponse `json:"holiday"`
+}
+
+type HolidaysResponses struct {
+	CommonResponse
+	Holidays []*HolidayResponse `json:"holidays"`
+}
+
+func toHolidayResponse(h *models.Holiday) *HolidayResponse {
+	return &HolidayResponse{
+		ID:         h.ID,
+		Date:       h.Date.In(timezone.JSTLocation()).Format("2006-01-02"),
+		Name:       h.Name,
+		IsNational: h.IsNational,
+	}
+}
+
+func ToHolidayResult(h *models.Holiday) *HolidayResult {
+	res := &HolidayResult{}
+	res.IsSuccessful = true
+	res.Holiday = toHolidayResponse(h)
+	return res
+}
+
+func ToHolidaysResponses(holidays []*models.Holiday) *HolidaysResponses {
+	res := &HolidaysResponses{}
+	responses := make([]*HolidayResponse, 0)
+	for _, h := range holidays {
+		responses = append(responses, toHolidayResponse(h))
+	}
+	res.IsSuccessful = true
+	res.Holidays = responses
+	return res
+}
@@ -0,0 +1,82 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type KioskResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	LastUsedAt string `json:"last_used_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// KioskCreatedResult returns the token of the new kiosk. It is the only time the token is shown.
+type KioskCreatedResult struct {
+	CommonResponse
+	Kiosk *KioskResponse `json:"kiosk"`
+	Token string         `json:"token"`
+}
+
+type KiosksResponses struct {
+	CommonResponse
+	Kiosks []*KioskResponse `json:"kiosks"`
+}
+
+type PunchCodeResponse struct {
+	UserID    string `json:"user_id"`
+	Code      string `json:"code"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type PunchCodesResponses struct {
+	CommonResponse
+	Codes []*PunchCodeResponse `json:"codes"`
+}
+
+func toKioskResponse(k *models.Kiosk) *KioskResponse {
+	resp := &KioskResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+	}
+	if !k.LastUsedAt.IsZero() {
+		resp.LastUsedAt = k.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToKioskCreatedResult(k *models.Kiosk, token string) *KioskCreatedResult {
+	res := &KioskCreatedResult{}
+	res.IsSuccessful = true
+	res.Kiosk = toKioskResponse(k)
+	res.Token = token
+	return res
+}
+
+func ToKiosksResponses(kiosks []*models.Kiosk) *KiosksResponses {
+	res := &KiosksResponses{}
+	responses := make([]*KioskResponse, 0)
+	for _, k := range kiosks {
+		responses = append(responses, toKioskResponse(k))
+	}
+	res.IsSuccessful = true
+	res.Kiosks = responses
+	return res
+}
+
+func ToPunchCodesResponses(codes []*models.PunchCode) *PunchCodesResponses {
+	res := &PunchCodesResponses{}
+	responses := make([]*PunchCodeResponse, 0)
+	for _, c := range codes {
+		responses = append(responses, &PunchCodeResponse{
+			UserID:    c.UserID,
+			Code:      c.Code,
+			ExpiresAt: c.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	res.IsSuccessful = true
+	res.Codes = responses
+	return res
+}
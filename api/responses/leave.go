@@ -0,0 +1,107 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"time"
+)
+
+type LeaveRequestResponse struct {
+	ID            int64   `json:"id"`
+	UserID        string  `json:"user_id"`
+	LeaveKindID   uint8   `json:"leave_kind_id"`
+	LeaveUnitID   uint8   `json:"leave_unit_id"`
+	StartDate     string  `json:"start_date"`
+	EndDate       string  `json:"end_date"`
+	Hours         float64 `json:"hours"`
+	Days          float64 `json:"days"`
+	Reason        string  `json:"reason"`
+	LeaveStatusID uint8   `json:"leave_status_id"`
+	ReviewerID    string  `json:"reviewer_id"`
+	ReviewComment string  `json:"review_comment"`
+	ReviewedAt    string  `json:"reviewed_at"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type LeaveRequestResult struct {
+	CommonResponse
+	LeaveRequest *LeaveRequestResponse `json:"leave_request"`
+}
+
+type LeaveRequestsResponses struct {
+	CommonResponse
+	LeaveRequests []*LeaveRequestResponse `json:"leave_requests"`
+}
+
+type LeaveGrantResponse struct {
+	ID        int64   `json:"id"`
+	GrantedAt string  `json:"granted_at"`
+	ExpiresAt string  `json:"expires_at"`
+	Days      float64 `json:"days"`
+	UsedDays  float64 `json:"used_days"`
+}
+
+type LeaveBalanceResponse struct {
+	CommonResponse
+	Balance float64               `json:"balance"`
+	Grants  []*LeaveGrantResponse `json:"grants"`
+}
+
+func toLeaveRequestResponse(r *models.LeaveRequest) *LeaveRequestResponse {
+	resp := &LeaveRequestResponse{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		LeaveKindID:   r.LeaveKindID,
+		LeaveUnitID:   r.LeaveUnitID,
+		StartDate:     r.StartDate.In(timezone.JSTLocation()).Format("2006-01-02"),
+		EndDate:       r.EndDate.In(timezone.JSTLocation()).Format("2006-01-02"),
+		Hours:         r.Hours,
+		Days:          r.Days,
+		Reason:        r.Reason,
+		LeaveStatusID: r.LeaveStatusID,
+		ReviewerID:    r.ReviewerID,
+		ReviewComment: r.ReviewComment,
+		CreatedAt:     r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     r.UpdatedAt.Format(time.RFC3339),
+	}
+	if !r.ReviewedAt.IsZero() {
+		resp.ReviewedAt = r.ReviewedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToLeaveRequestResult(r *models.LeaveRequest) *LeaveRequestResult {
+	res := &LeaveRequestResult{}
+	res.IsSuccessful = true
+	res.LeaveRequest = toLeaveRequestResponse(r)
+	return res
+}
+
+func ToLeaveRequestsResponses(requests []*models.LeaveRequest) *LeaveRequestsResponses {
+	res := &LeaveRequestsResponses{}
+	responses := make([]*LeaveRequestResponse, 0)
+	for _, r := range requests {
+		responses = append(responses, toLeaveRequestResponse(r))
+	}
+	res.IsSuccessful = true
+	res.LeaveRequests = responses
+	return res
+}
+
+func ToLeaveBalanceResponse(results *models.GetLeaveBalanceResults) *LeaveBalanceResponse {
+	res := &LeaveBalanceResponse{}
+	res.Balance = results.Balance
+	res.Grants = make([]*LeaveGrantResponse, 0)
+	for _, g := range results.Grants {
+		res.Grants = append(res.Grants, &LeaveGrantResponse{
+			ID:        g.ID,
+			GrantedAt: g.GrantedAt.In(timezone.JSTLocation()).Format("2006-01-02"),
+			ExpiresAt: g.ExpiresAt.In(timezone.JSTLocation()).Format("2006-01-02"),
+			Days:      g.Days,
+			UsedDays:  g.UsedDays,
+		})
+	}
+	res.IsSuccessful = true
+	return res
+}
@@ -0,0 +1,157 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+)
+
+type DepartmentResponse struct {
+	ID       int64  `json:"id"`
+	ParentID int64  `json:"parent_id"`
+	Name     string `json:"name"`
+}
+
+type DepartmentResult struct {
+	CommonResponse
+	Department *DepartmentResponse `json:"department"`
+}
+
+type DepartmentsResponses struct {
+	CommonResponse
+	Departments []*DepartmentResponse `json:"departments"`
+}
+
+type TeamResponse struct {
+	ID           int64  `json:"id"`
+	DepartmentID int64  `json:"department_id"`
+	Name         string `json:"name"`
+}
+
+type TeamResult struct {
+	CommonResponse
+	Team *TeamResponse `json:"team"`
+}
+
+type TeamsResponses struct {
+	CommonResponse
+	Teams []*TeamResponse `json:"teams"`
+}
+
+type AssignmentResponse struct {
+	ID        int64  `json:"id"`
+	UserID    string `json:"user_id"`
+	TeamID    int64  `json:"team_id"`
+	ManagerID string `json:"manager_id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type AssignmentResult struct {
+	CommonResponse
+	Assignment *AssignmentResponse `json:"assignment"`
+}
+
+type AssignmentsResponses struct {
+	CommonResponse
+	Assignments []*AssignmentResponse `json:"assignments"`
+}
+
+type ManagersResponses struct {
+	CommonResponse
+	Managers []UserResp `json:"managers"`
+}
+
+func toDepartmentResponse(d *models.Department) *DepartmentResponse {
+	return &DepartmentResponse{
+		ID:       d.ID,
+		ParentID: d.ParentID,
+		Name:     d.Name,
+	}
+}
+
+func ToDepartmentResult(d *models.Department) *DepartmentResult {
+	res := &DepartmentResult{}
+	res.IsSuccessful = true
+	res.Department = toDepartmentResponse(d)
+	return res
+}
+
+func ToDepartmentsResponses(departments models.Departments) *DepartmentsResponses {
+	res := &DepartmentsResponses{}
+	responses := make([]*DepartmentResponse, 0)
+	for _, d := range departments {
+		responses = append(responses, toDepartmentResponse(d))
+	}
+	res.IsSuccessful = true
+	res.Departments = responses
+	return res
+}
+
+func toTeamResponse(t *models.Team) *TeamResponse {
+	return &TeamResponse{
+		ID:           t.ID,
+		DepartmentID: t.DepartmentID,
+		Name:         t.Name,
+	}
+}
+
+func ToTeamResult(t *models.Team) *TeamResult {
+	res := &TeamResult{}
+	res.IsSuccessful = true
+	res.Team = toTeamResponse(t)
+	return res
+}
+
+func ToTeamsResponses(teams []*models.Team) *TeamsResponses {
+	res := &TeamsResponses{}
+	responses := make([]*TeamResponse, 0)
+	for _, t := range teams {
+		responses = append(responses, toTeamResponse(t))
+	}
+	res.IsSuccessful = true
+	res.Teams = responses
+	return res
+}
+
+func toAssignmentResponse(a *models.Assignment) *AssignmentResponse {
+	resp := &AssignmentResponse{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		TeamID:    a.TeamID,
+		ManagerID: a.ManagerID,
+		StartDate: a.StartedAt.In(timezone.JSTLocation()).Format("2006-01-02"),
+	}
+	if !a.FinishedAt.IsZero() {
+		resp.EndDate = a.FinishedAt.In(timezone.JSTLocation()).AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return resp
+}
+
+func ToAssignmentResult(a *models.Assignment) *AssignmentResult {
+	res := &AssignmentResult{}
+	res.IsSuccessful = true
+	res.Assignment = toAssignmentResponse(a)
+	return res
+}
+
+func ToAssignmentsResponses(assignments models.Assignments) *AssignmentsResponses {
+	res := &AssignmentsResponses{}
+	responses := make([]*AssignmentResponse, 0)
+	for _, a := range assignments {
+		responses = append(responses, toAssignmentResponse(a))
+	}
+	res.IsSuccessful = true
+	res.Assignments = responses
+	return res
+}
+
+func ToManagersResponses(managers []*models.User) *ManagersResponses {
+	res := &ManagersResponses{}
+	responses := make([]UserResp, 0)
+	for _, u := range managers {
+		responses = append(responses, toUserResp(u))
+	}
+	res.IsSuccessful = true
+	res.Managers = responses
+	return res
+}
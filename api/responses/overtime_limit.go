@@ -0,0 +1,53 @@
+package responses
+
+import "github.com/KouT127/attendance-management/domain/models"
+
+// OvertimeAlertResponse is a limit of the overtime agreement approached or exceeded.
+// Value and Threshold are in hours, except for the number of months over the monthly limit.
+type OvertimeAlertResponse struct {
+	Month                int     `json:"month"`
+	OvertimeLimitKindID  uint8   `json:"overtime_limit_kind_id"`
+	OvertimeLimitKind    string  `json:"overtime_limit_kind"`
+	OvertimeAlertLevelID uint8   `json:"overtime_alert_level_id"`
+	Value                float64 `json:"value"`
+	Threshold            float64 `json:"threshold"`
+}
+
+type OvertimeRiskResponse struct {
+	User   UserResp                 `json:"user"`
+	Alerts []*OvertimeAlertResponse `json:"alerts"`
+}
+
+type OvertimeRisksResponses struct {
+	CommonResponse
+	Risks []*OvertimeRiskResponse `json:"risks"`
+}
+
+func toOvertimeAlertResponse(a *models.OvertimeAlert) *OvertimeAlertResponse {
+	return &OvertimeAlertResponse{
+		Month:                a.Month,
+		OvertimeLimitKindID:  a.OvertimeLimitKindID,
+		OvertimeLimitKind:    a.Kind().String(),
+		OvertimeAlertLevelID: a.OvertimeAlertLevelID,
+		Value:                a.Value,
+		Threshold:            a.Threshold,
+	}
+}
+
+func ToOvertimeRisksResponses(risks []*models.OvertimeRisk) *OvertimeRisksResponses {
+	res := &OvertimeRisksResponses{}
+	responses := make([]*OvertimeRiskResponse, 0)
+	for _, r := range risks {
+		resp := &OvertimeRiskResponse{
+			User:   toUserResp(r.User),
+			Alerts: make([]*OvertimeAlertResponse, 0),
+		}
+		for _, a := range r.Alerts {
+			resp.Alerts = append(resp.Alerts, toOvertimeAlertResponse(a))
+		}
+		responses = append(responses, resp)
+	}
+	res.IsSuccessful = true
+	res.Risks = responses
+	return res
+}
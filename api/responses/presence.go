@@ -0,0 +1,68 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"time"
+)
+
+type MemberPresenceResponse struct {
+	User       UserResp                `json:"user"`
+	StatusID   uint8                   `json:"status_id"`
+	Status     string                  `json:"status"`
+	Attendance *AttendanceResponse     `json:"attendance"`
+	Leaves     []*LeaveRequestResponse `json:"leaves"`
+}
+
+type TeamAttendancesResponses struct {
+	CommonResponse
+	Date    string                    `json:"date"`
+	Members []*MemberPresenceResponse `json:"members"`
+}
+
+// PresenceEventResponse is a status change pushed to a live board.
+type PresenceEventResponse struct {
+	UserID           string `json:"user_id"`
+	StatusID         uint8  `json:"status_id"`
+	Status           string `json:"status"`
+	AttendanceKindID uint8  `json:"attendance_kind_id"`
+	PushedAt         string `json:"pushed_at"`
+}
+
+func toMemberPresenceResponse(p *models.MemberPresence) *MemberPresenceResponse {
+	resp := &MemberPresenceResponse{
+		User:     toUserResp(p.User),
+		StatusID: uint8(p.Status),
+		Status:   p.Status.String(),
+		Leaves:   make([]*LeaveRequestResponse, 0),
+	}
+	if p.Attendance != nil {
+		resp.Attendance = toAttendanceResponse(p.Attendance)
+	}
+	for _, l := range p.Leaves {
+		resp.Leaves = append(resp.Leaves, toLeaveRequestResponse(l))
+	}
+	return resp
+}
+
+func ToTeamAttendancesResponses(presences []*models.MemberPresence, at time.Time) *TeamAttendancesResponses {
+	res := &TeamAttendancesResponses{}
+	responses := make([]*MemberPresenceResponse, 0)
+	for _, p := range presences {
+		responses = append(responses, toMemberPresenceResponse(p))
+	}
+	res.IsSuccessful = true
+	res.Date = at.In(timezone.JSTLocation()).Format("2006-01-02")
+	res.Members = responses
+	return res
+}
+
+func ToPresenceEventResponse(e *models.PresenceEvent) *PresenceEventResponse {
+	return &PresenceEventResponse{
+		UserID:           e.UserID,
+		StatusID:         uint8(e.Status),
+		Status:           e.Status.String(),
+		AttendanceKindID: uint8(e.Kind),
+		PushedAt:         e.PushedAt.Format(time.RFC3339),
+	}
+}
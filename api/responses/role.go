@@ -0,0 +1,26 @@
+package responses
+
+import "github.com/KouT127/attendance-management/domain/models"
+
+type PrincipalResponse struct {
+	UserID  string `json:"user_id"`
+	RoleIDs []int  `json:"role_ids"`
+}
+
+type PrincipalResult struct {
+	CommonResponse
+	Principal *PrincipalResponse `json:"principal"`
+}
+
+func ToPrincipalResult(p *models.Principal) *PrincipalResult {
+	res := &PrincipalResult{}
+	res.IsSuccessful = true
+	res.Principal = &PrincipalResponse{
+		UserID:  p.UserID,
+		RoleIDs: make([]int, 0),
+	}
+	for _, r := range p.Roles {
+		res.Principal.RoleIDs = append(res.Principal.RoleIDs, int(r))
+	}
+	return res
+}
@@ -0,0 +1,29 @@
+package responses
+
+import "github.com/KouT127/attendance-management/domain/models"
+
+type RoundingRuleResponse struct {
+	AttendanceKindID    uint8 `json:"attendance_kind_id"`
+	UnitMinutes         int   `json:"unit_minutes"`
+	RoundingDirectionID uint8 `json:"rounding_direction_id"`
+}
+
+type RoundingRulesResponses struct {
+	CommonResponse
+	Rules []*RoundingRuleResponse `json:"rules"`
+}
+
+func ToRoundingRulesResponses(rules models.RoundingRules) *RoundingRulesResponses {
+	res := &RoundingRulesResponses{}
+	responses := make([]*RoundingRuleResponse, 0)
+	for _, r := range rules {
+		responses = append(responses, &RoundingRuleResponse{
+			AttendanceKindID:    r.AttendanceKindID,
+			UnitMinutes:         r.UnitMinutes,
+			RoundingDirectionID: r.RoundingDirectionID,
+		})
+	}
+	res.IsSuccessful = true
+	res.Rules = responses
+	return res
+}
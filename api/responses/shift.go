@@ -0,0 +1,133 @@
+package responses
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"time"
+)
+
+type ShiftTemplateResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	BreakMinutes int    `json:"break_minutes"`
+}
+
+type ShiftTemplateResult struct {
+	CommonResponse
+	Template *ShiftTemplateResponse `json:"template"`
+}
+
+type ShiftTemplatesResponses struct {
+	CommonResponse
+	Templates []*ShiftTemplateResponse `json:"templates"`
+}
+
+type ShiftResponse struct {
+	ID              int64  `json:"id"`
+	UserID          string `json:"user_id"`
+	ShiftTemplateID int64  `json:"shift_template_id"`
+	Date            string `json:"date"`
+	StartedAt       string `json:"started_at"`
+	FinishedAt      string `json:"finished_at"`
+	BreakMinutes    int    `json:"break_minutes"`
+}
+
+// ShiftCheckResponse is a planned shift compared with the attendance of its day.
+type ShiftCheckResponse struct {
+	*ShiftResponse
+	AttendanceID int64 `json:"attendance_id"`
+	IsLate       bool  `json:"is_late"`
+	IsEarlyLeave bool  `json:"is_early_leave"`
+	IsNoShow     bool  `json:"is_no_show"`
+}
+
+type ShiftRosterResult struct {
+	CommonResponse
+	StartDate string           `json:"start_date"`
+	EndDate   string           `json:"end_date"`
+	Shifts    []*ShiftResponse `json:"shifts"`
+}
+
+type ShiftChecksResponses struct {
+	CommonResponse
+	Shifts []*ShiftCheckResponse `json:"shifts"`
+}
+
+func toShiftTemplateResponse(t *models.ShiftTemplate) *ShiftTemplateResponse {
+	return &ShiftTemplateResponse{
+		ID:           t.ID,
+		Name:         t.Name,
+		StartTime:    formatMinutes(t.StartMinutes),
+		EndTime:      formatMinutes(t.EndMinutes),
+		BreakMinutes: t.BreakMinutes,
+	}
+}
+
+func toShiftResponse(s *models.ShiftAssignment) *ShiftResponse {
+	return &ShiftResponse{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		ShiftTemplateID: s.ShiftTemplateID,
+		Date:            s.WorkDate.In(timezone.JSTLocation()).Format("2006-01-02"),
+		StartedAt:       s.StartedAt.Format(time.RFC3339),
+		FinishedAt:      s.FinishedAt.Format(time.RFC3339),
+		BreakMinutes:    s.BreakMinutes,
+	}
+}
+
+func toShiftCheckResponse(c *models.ShiftCheck) *ShiftCheckResponse {
+	resp := &ShiftCheckResponse{
+		ShiftResponse: toShiftResponse(c.Shift),
+		IsLate:        c.IsLate,
+		IsEarlyLeave:  c.IsEarlyLeave,
+		IsNoShow:      c.IsNoShow,
+	}
+	if c.Attendance != nil {
+		resp.AttendanceID = c.Attendance.ID
+	}
+	return resp
+}
+
+func ToShiftTemplateResult(t *models.ShiftTemplate) *ShiftTemplateResult {
+	res := &ShiftTemplateResult{}
+	res.IsSuccessful = true
+	res.Template = toShiftTemplateResponse(t)
+	return res
+}
+
+func ToShiftTemplatesResponses(templates []*models.ShiftTemplate) *ShiftTemplatesResponses {
+	res := &ShiftTemplatesResponses{}
+	responses := make([]*ShiftTemplateResponse, 0)
+	for _, t := range templates {
+		responses = append(responses, toShiftTemplateResponse(t))
+	}
+	res.IsSuccessful = true
+	res.Templates = responses
+	return res
+}
+
+func ToShiftRosterResult(r *models.ShiftRoster) *ShiftRosterResult {
+	res := &ShiftRosterResult{
+		StartDate: r.Start.In(timezone.JSTLocation()).Format("2006-01-02"),
+		EndDate:   r.End.In(timezone.JSTLocation()).Format("2006-01-02"),
+		Shifts:    make([]*ShiftResponse, 0),
+	}
+	for _, s := range r.Shifts {
+		res.Shifts = append(res.Shifts, toShiftResponse(s))
+	}
+	res.IsSuccessful = true
+	return res
+}
+
+func ToShiftChecksResponses(checks []*models.ShiftCheck) *ShiftChecksResponses {
+	res := &ShiftChecksResponses{}
+	responses := make([]*ShiftCheckResponse, 0)
+	for _, c := range checks {
+		responses = append(responses, toShiftCheckResponse(c))
+	}
+	res.IsSuccessful = true
+	res.Shifts = responses
+	return res
+}
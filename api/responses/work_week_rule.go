@@ -0,0 +1,15 @@
+package responses
+
+import "github.com/KouT127/attendance-management/domain/models"
+
+type WorkWeekRuleResult struct {
+	CommonResponse
+	StatutoryHolidayWeekday uint8 `json:"statutory_holiday_weekday"`
+}
+
+func ToWorkWeekRuleResult(r *models.WorkWeekRule) *WorkWeekRuleResult {
+	res := &WorkWeekRuleResult{}
+	res.IsSuccessful = true
+	res.StatutoryHolidayWeekday = r.StatutoryHolidayWeekday
+	return res
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"golang.org/x/xerrors"
+	"net"
+)
+
+type AllowedNetworkService interface {
+	GetAllowedNetworks(ctx context.Context) (models.AllowedNetworks, error)
+	CreateAllowedNetwork(ctx context.Context, network *models.AllowedNetwork) (*models.AllowedNetwork, error)
+	DeleteAllowedNetwork(ctx context.Context, id int64) error
+	CheckNetwork(ctx context.Context, ip net.IP) (models.NetworkStatus, error)
+	GetNetworkRestriction(ctx context.Context) (*models.NetworkRestriction, error)
+	UpdateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) (*models.NetworkRestriction, error)
+}
+
+type allowedNetworkService struct {
+	store sqlstore.SQLStore
+}
+
+func NewAllowedNetworkService(ss sqlstore.SQLStore) AllowedNetworkService {
+	return &allowedNetworkService{
+		store: ss,
+	}
+}
+
+func (s *allowedNetworkService) GetAllowedNetworks(ctx context.Context) (models.AllowedNetworks, error) {
+	return s.store.GetAllowedNetworks(ctx)
+}
+
+func (s *allowedNetworkService) CreateAllowedNetwork(ctx context.Context, network *models.AllowedNetwork) (*models.AllowedNetwork, error) {
+	if network == nil {
+		return nil, xerrors.New("allowed network is empty")
+	}
+	if err := network.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateAllowedNetwork(ctx, network); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+func (s *allowedNetworkService) DeleteAllowedNetwork(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		network, err := s.store.GetAllowedNetwork(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if network == nil {
+			return nil, models.ErrAllowedNetworkNotFound
+		}
+		return nil, s.store.DeleteAllowedNetwork(ctx, id)
+	})
+	return err
+}
+
+// CheckNetwork returns the network status of a punch from the ip.
+// Whether the punch is refused depends on the network restriction of the company, which checkNetwork applies.
+func (s *allowedNetworkService) CheckNetwork(ctx context.Context, ip net.IP) (models.NetworkStatus, error) {
+	networks, err := s.store.GetAllowedNetworks(ctx)
+	if err != nil {
+		return models.NetworkStatusNone, err
+	}
+	return networks.Check(ip), nil
+}
+
+// GetNetworkRestriction returns the network restriction of the company, flagging punches from outside when not set.
+func (s *allowedNetworkService) GetNetworkRestriction(ctx context.Context) (*models.NetworkRestriction, error) {
+	return loadNetworkRestriction(ctx, s.store)
+}
+
+// UpdateNetworkRestriction sets the network restriction of the company, creating it on first use.
+func (s *allowedNetworkService) UpdateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) (*models.NetworkRestriction, error) {
+	if restriction == nil {
+		return nil, xerrors.New("network restriction is empty")
+	}
+	if err := restriction.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		current, err := s.store.GetNetworkRestriction(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, s.store.CreateNetworkRestriction(ctx, restriction)
+		}
+		restriction.ID = current.ID
+		restriction.CreatedAt = current.CreatedAt
+		return nil, s.store.UpdateNetworkRestriction(ctx, restriction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restriction, nil
+}
+
+func loadNetworkRestriction(ctx context.Context, store sqlstore.SQLStore) (*models.NetworkRestriction, error) {
+	restriction, err := store.GetNetworkRestriction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if restriction == nil {
+		return models.DefaultNetworkRestriction(), nil
+	}
+	return restriction, nil
+}
+
+// checkNetwork refuses a punch from outside the allowed networks when the network restriction of the company rejects it.
+// Every punch is checked whatever work location it declares, so a punch from outside is at least flagged.
+func checkNetwork(ctx context.Context, store sqlstore.SQLStore, t *models.AttendanceTime) error {
+	restriction, err := loadNetworkRestriction(ctx, store)
+	if err != nil {
+		return err
+	}
+	return restriction.Check(t)
+}
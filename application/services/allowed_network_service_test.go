@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_checkNetwork(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	// A tenant of its own, so that rejecting punches does not affect the punches of other tests.
+	tenant := &models.Tenant{Name: "network", AuthTenantID: uuid.NewV4().String()}
+	if err := store.CreateTenant(context.Background(), tenant); err != nil {
+		t.Errorf("CreateTenant() failed %s", err)
+		return
+	}
+	ctx := sqlstore.WithTenant(context.Background(), tenant.ID)
+	s := NewAllowedNetworkService(store)
+
+	at := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	punch := func(location models.WorkLocation, status models.NetworkStatus) *models.AttendanceTime {
+		return &models.AttendanceTime{
+			AttendanceKindID: uint8(models.AttendanceKindClockIn),
+			WorkLocationID:   uint8(location),
+			NetworkStatusID:  uint8(status),
+			PushedAt:         at,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mode       models.NetworkRestrictionMode
+		punch      *models.AttendanceTime
+		wantErr    error
+		wantStatus models.NetworkStatus
+	}{
+		{
+			name:       "Should flag a punch from outside by default",
+			punch:      punch(models.WorkLocationOffice, models.NetworkStatusOutside),
+			wantStatus: models.NetworkStatusOutside,
+		},
+		{
+			name:       "Should flag a remote punch from outside",
+			mode:       models.NetworkRestrictionModeFlag,
+			punch:      punch(models.WorkLocationRemote, models.NetworkStatusOutside),
+			wantStatus: models.NetworkStatusOutside,
+		},
+		{
+			name:    "Should reject a punch at the office from outside",
+			mode:    models.NetworkRestrictionModeReject,
+			punch:   punch(models.WorkLocationOffice, models.NetworkStatusOutside),
+			wantErr: models.ErrPunchOutsideNetwork,
+		},
+		{
+			name:    "Should reject a remote punch from outside",
+			mode:    models.NetworkRestrictionModeReject,
+			punch:   punch(models.WorkLocationRemote, models.NetworkStatusOutside),
+			wantErr: models.ErrPunchOutsideNetwork,
+		},
+		{
+			name:       "Should allow a punch from an allowed network",
+			mode:       models.NetworkRestrictionModeReject,
+			punch:      punch(models.WorkLocationOffice, models.NetworkStatusAllowed),
+			wantStatus: models.NetworkStatusAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mode != models.NetworkRestrictionModeNone {
+				if _, err := s.UpdateNetworkRestriction(ctx, &models.NetworkRestriction{NetworkRestrictionModeID: uint8(tt.mode)}); err != nil {
+					t.Errorf("UpdateNetworkRestriction() error = %v", err)
+					return
+				}
+			}
+			err := checkNetwork(ctx, store, tt.punch)
+			if !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("checkNetwork() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && tt.punch.NetworkStatus() != tt.wantStatus {
+				t.Errorf("checkNetwork() status = %v, want %v", tt.punch.NetworkStatus(), tt.wantStatus)
+			}
+		})
+	}
+}
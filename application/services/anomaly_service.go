@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type AnomalyService interface {
+	GetAnomalies(ctx context.Context, params models.GetAnomaliesParameters) ([]*models.AttendanceAnomaly, error)
+	ScanAnomalies(ctx context.Context, userID string, start, end time.Time) error
+	ScanAllAnomalies(ctx context.Context) error
+}
+
+type anomalyService struct {
+	store sqlstore.SQLStore
+	rules models.AnomalyRules
+}
+
+type AnomalyServiceOption func(s *anomalyService)
+
+// WithAnomalyRules replaces the default thresholds of the anomaly detection.
+func WithAnomalyRules(rules models.AnomalyRules) AnomalyServiceOption {
+	return func(s *anomalyService) {
+		s.rules = rules
+	}
+}
+
+func NewAnomalyService(ss sqlstore.SQLStore, opts ...AnomalyServiceOption) AnomalyService {
+	s := &anomalyService{
+		store: ss,
+		rules: models.DefaultAnomalyRules(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetAnomalies returns the anomalies of the user in the month recorded by the scans, including resolved ones.
+func (s *anomalyService) GetAnomalies(ctx context.Context, params models.GetAnomaliesParameters) ([]*models.AttendanceAnomaly, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	start, end, err := timeutil.GetMonthRange(params.Month)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetAttendanceAnomalies(ctx, params.UserID, start, end)
+}
+
+// ScanAllAnomalies scans the attendances of every user from the start of the previous month,
+// so that a month is still checked for a while after it ends. A user failing does not stop the others.
+func (s *anomalyService) ScanAllAnomalies(ctx context.Context) error {
+	users, err := s.store.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+	now := flextime.Now().In(timezone.JSTLocation())
+	start := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	for _, u := range users {
+		if err := s.ScanAnomalies(ctx, u.ID, start, now); err != nil {
+			logger.NewWarn(logrus.Fields{"user_id": u.ID}, err.Error())
+		}
+	}
+	return nil
+}
+
+// ScanAnomalies records the anomalies of the attendances of the user between start and end.
+// Anomalies found before that the attendances no longer have are resolved, and reopened when found again.
+func (s *anomalyService) ScanAnomalies(ctx context.Context, userID string, start, end time.Time) error {
+	if userID == "" {
+		return xerrors.New("user id is empty")
+	}
+	cal, err := loadCalendar(ctx, s.store, start, end)
+	if err != nil {
+		return err
+	}
+	attendances, err := s.store.GetAttendancesBetween(ctx, userID, start, end)
+	if err != nil {
+		return err
+	}
+	shifts, err := s.store.GetShiftAssignments(ctx, userID, start, end)
+	if err != nil {
+		return err
+	}
+	now := flextime.Now()
+	detected := s.rules.Detect(cal, attendances, shifts, now)
+
+	_, err = s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		recorded, err := s.store.GetAttendanceAnomalies(ctx, userID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[string]bool)
+		for _, a := range detected {
+			found[a.Key()] = true
+		}
+		known := make(map[string]bool)
+		for _, a := range recorded {
+			known[a.Key()] = true
+			switch {
+			case found[a.Key()] && a.IsResolved():
+				a.ResolvedAt = time.Time{}
+			case !found[a.Key()] && !a.IsResolved():
+				a.ResolvedAt = now
+			default:
+				continue
+			}
+			if err = s.store.UpdateAttendanceAnomalyResolved(ctx, a); err != nil {
+				return nil, err
+			}
+		}
+		for _, a := range detected {
+			if known[a.Key()] {
+				continue
+			}
+			if err = s.store.CreateAttendanceAnomaly(ctx, a); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
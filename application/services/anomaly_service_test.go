@@ -0,0 +1,69 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func Test_anomalyService_ScanAllAnomalies(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	defer flextime.Restore()
+	s := NewAnomalyService(store)
+	attendances := NewAttendanceService(store)
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	flextime.Fix(time.Date(2020, 6, 1, 9, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := attendances.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, userID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+
+	params := models.GetAnomaliesParameters{UserID: userID, Month: 202006}
+	flextime.Fix(time.Date(2020, 6, 1, 18, 0, 0, 0, timezone.JSTLocation()))
+	if err := s.ScanAllAnomalies(sqlstore.NewTestContext()); err != nil {
+		t.Errorf("ScanAllAnomalies() error = %v", err)
+	}
+	anomalies, err := s.GetAnomalies(sqlstore.NewTestContext(), params)
+	if err != nil {
+		t.Errorf("GetAnomalies() error = %v", err)
+		return
+	}
+	if len(anomalies) != 0 {
+		t.Errorf("GetAnomalies() got %d anomalies during the shift, want none", len(anomalies))
+	}
+
+	flextime.Fix(time.Date(2020, 6, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+	anomalies, err = s.GetAnomalies(sqlstore.NewTestContext(), params)
+	if err != nil {
+		t.Errorf("GetAnomalies() error = %v", err)
+		return
+	}
+	if len(anomalies) != 0 {
+		t.Errorf("GetAnomalies() got %d anomalies before the scan, want none", len(anomalies))
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.ScanAllAnomalies(sqlstore.NewTestContext()); err != nil {
+			t.Errorf("ScanAllAnomalies() error = %v", err)
+		}
+		anomalies, err = s.GetAnomalies(sqlstore.NewTestContext(), params)
+		if err != nil {
+			t.Errorf("GetAnomalies() error = %v", err)
+			return
+		}
+		if len(anomalies) != 1 || anomalies[0].Kind() != models.AnomalyKindMissingClockOut || anomalies[0].IsResolved() {
+			t.Errorf("GetAnomalies() = %v, want a missing clock-out recorded once", anomalies)
+		}
+	}
+}
@@ -4,27 +4,81 @@ import (
 	"context"
 	"github.com/KouT127/attendance-management/domain/models"
 	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
 	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/KouT127/attendance-management/utilities/timezone"
 	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
+	"time"
 )
 
+// DefaultMaxShiftLength is how long an attendance stays open for a clock-out when no option is given.
+const DefaultMaxShiftLength = 16 * time.Hour
+
 type AttendanceService interface {
 	GetAttendances(ctx context.Context, params models.GetAttendancesParameters) (*models.GetAttendancesResults, error)
 	CreateOrUpdateAttendance(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+	ClockIn(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+	ClockOut(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+	StartBreak(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
+	EndBreak(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error)
 	GetAttendanceSummary(ctx context.Context, params models.GetAttendanceSummaryParameters) (*models.GetAttendanceSummaryResults, error)
+	GetAttendanceHistory(ctx context.Context, params models.GetAttendanceHistoryParameters) (*models.GetAttendanceHistoryResults, error)
+	GetWorkBreakdown(ctx context.Context, params models.GetWorkBreakdownParameters) (*models.GetWorkBreakdownResults, error)
 }
 
 type attendanceService struct {
-	store sqlstore.SQLStore
+	store          sqlstore.SQLStore
+	maxShiftLength time.Duration
+	limits         OvertimeLimitService
+	geofenceMode   models.GeofenceMode
+	broker         PresenceBroker
+}
+
+type AttendanceServiceOption func(s *attendanceService)
+
+// WithMaxShiftLength sets how long after clock-in a shift can still be clocked out,
+// even when it started on a previous day.
+func WithMaxShiftLength(d time.Duration) AttendanceServiceOption {
+	return func(s *attendanceService) {
+		s.maxShiftLength = d
+	}
+}
+
+// WithOvertimeLimitService checks the overtime limits of the user after each clock-out.
+func WithOvertimeLimitService(limits OvertimeLimitService) AttendanceServiceOption {
+	return func(s *attendanceService) {
+		s.limits = limits
+	}
 }
 
-func NewAttendanceService(ss sqlstore.SQLStore) AttendanceService {
-	return &attendanceService{
-		store: ss,
+// WithGeofenceMode sets what is done with a punch at the office outside every geofence.
+func WithGeofenceMode(mode models.GeofenceMode) AttendanceServiceOption {
+	return func(s *attendanceService) {
+		s.geofenceMode = mode
 	}
 }
 
+// WithPresenceBroker pushes the status of the user to the live boards after each punch.
+func WithPresenceBroker(broker PresenceBroker) AttendanceServiceOption {
+	return func(s *attendanceService) {
+		s.broker = broker
+	}
+}
+
+func NewAttendanceService(ss sqlstore.SQLStore, opts ...AttendanceServiceOption) AttendanceService {
+	s := &attendanceService{
+		store:          ss,
+		maxShiftLength: DefaultMaxShiftLength,
+		geofenceMode:   models.GeofenceModeFlag,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
 func (s *attendanceService) GetAttendances(ctx context.Context, params models.GetAttendancesParameters) (*models.GetAttendancesResults, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
@@ -37,14 +91,29 @@ func (s *attendanceService) GetAttendances(ctx context.Context, params models.Ge
 	if err != nil {
 		return nil, err
 	}
+	if params.WorkLocation != models.WorkLocationNone {
+		attendances = attendances.FilterByWorkLocation(params.WorkLocation)
+		maxCnt = int64(len(attendances))
+	}
+	start, end, err := timeutil.GetMonthRange(params.Month)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := checkShifts(ctx, s.store, params.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
 
 	res := models.GetAttendancesResults{
 		MaxCnt:      maxCnt,
 		Attendances: attendances,
+		Shifts:      shifts,
 	}
 	return &res, nil
 }
 
+// CreateOrUpdateAttendance toggles the punch of the user: a clock-out while a session is in progress,
+// otherwise a clock-in that opens a new session, as ClockIn does.
 func (s *attendanceService) CreateOrUpdateAttendance(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error) {
 	if userID == "" {
 		return nil, xerrors.New("userID is empty")
@@ -53,35 +122,86 @@ func (s *attendanceService) CreateOrUpdateAttendance(ctx context.Context, attend
 		return nil, xerrors.New("attendance time is empty")
 	}
 
+	attendance, err := s.getCurrentAttendance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session := attendance.LatestSession(); session != nil && !session.IsClosed() {
+		return s.ClockOut(ctx, attendanceTime, userID)
+	}
+	return s.ClockIn(ctx, attendanceTime, userID)
+}
+
+func (s *attendanceService) ClockIn(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error) {
+	return s.pushAttendanceTime(ctx, attendanceTime, userID, models.AttendanceKindClockIn)
+}
+
+func (s *attendanceService) ClockOut(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error) {
+	attendance, err := s.pushAttendanceTime(ctx, attendanceTime, userID, models.AttendanceKindClockOut)
+	if err != nil {
+		return nil, err
+	}
+	s.checkOvertimeLimits(ctx, userID)
+	return attendance, nil
+}
+
+func (s *attendanceService) StartBreak(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error) {
+	return s.pushAttendanceTime(ctx, attendanceTime, userID, models.AttendanceKindBreakStart)
+}
+
+func (s *attendanceService) EndBreak(ctx context.Context, attendanceTime *models.AttendanceTime, userID string) (*models.Attendance, error) {
+	return s.pushAttendanceTime(ctx, attendanceTime, userID, models.AttendanceKindBreakEnd)
+}
+
+// pushAttendanceTime records a punch of the kind after validating it against the current status.
+// It returns models.AttendanceConflictError when the transition is invalid,
+// and models.ErrPunchOutsideGeofence or models.ErrPunchOutsideNetwork when the place of the punch is refused.
+func (s *attendanceService) pushAttendanceTime(ctx context.Context, attendanceTime *models.AttendanceTime, userID string, kind models.AttendanceKind) (*models.Attendance, error) {
+	if userID == "" {
+		return nil, xerrors.New("userID is empty")
+	}
+	if attendanceTime == nil {
+		return nil, xerrors.New("attendance time is empty")
+	}
+
 	ctx, err := s.store.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer s.store.Close(ctx)
 
-	attendance, err := s.store.GetLatestAttendance(ctx, userID)
+	attendance, err := s.getCurrentAttendance(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	if err = attendance.ValidatePush(kind); err != nil {
+		return nil, err
+	}
 
 	if attendance == nil {
 		attendance = &models.Attendance{}
 		attendance.UserID = userID
-		attendance.ClockedIn = attendanceTime
 		attendance.AttendedAt = flextime.Now()
 		if err = s.store.CreateAttendance(ctx, attendance); err != nil {
 			return nil, err
 		}
-		attendanceTime.AttendanceKindID = uint8(models.AttendanceKindClockIn)
-	} else {
-		if err = s.store.UpdateOldAttendanceTime(ctx, attendance.ID, uint8(models.AttendanceKindClockOut)); err != nil {
-			return nil, err
-		}
-		attendance.ClockedOut = attendanceTime
-		attendanceTime.AttendanceKindID = uint8(models.AttendanceKindClockOut)
+	}
+
+	attendanceTime.AttendanceKindID = uint8(kind)
+	if kind != models.AttendanceKindClockIn {
+		attendanceTime.WorkLocationID = uint8(models.WorkLocationNone)
 	}
 	attendanceTime.PushedAt = flextime.Now()
 	attendanceTime.AttendanceID = attendance.ID
+	if err = roundAttendanceTime(ctx, s.store, attendanceTime); err != nil {
+		return nil, err
+	}
+	if err = checkGeofence(ctx, s.store, s.geofenceMode, attendance, attendanceTime); err != nil {
+		return nil, err
+	}
+	if err = checkNetwork(ctx, s.store, attendanceTime); err != nil {
+		return nil, err
+	}
 
 	if err = s.store.CreateAttendanceTime(ctx, attendanceTime); err != nil {
 		return nil, err
@@ -90,8 +210,39 @@ func (s *attendanceService) CreateOrUpdateAttendance(ctx context.Context, attend
 	if err = s.store.Commit(ctx); err != nil {
 		return nil, err
 	}
+
+	attendance.AddTime(attendanceTime)
+	publishPresence(ctx, s.broker, attendance, attendanceTime)
 	return attendance, nil
 }
+
+// checkOvertimeLimits raises the overtime alerts of the user. A failure is logged and does not fail the punch.
+func (s *attendanceService) checkOvertimeLimits(ctx context.Context, userID string) {
+	if s.limits == nil {
+		return
+	}
+	if _, err := s.limits.CheckOvertimeLimits(ctx, userID); err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": userID}, err.Error())
+	}
+}
+
+// getCurrentAttendance returns the attendance a punch applies to.
+// A shift still open within the max shift length takes priority over today's attendance,
+// so that a clock-out after midnight is attached to the day the shift started.
+func (s *attendanceService) getCurrentAttendance(ctx context.Context, userID string) (*models.Attendance, error) {
+	attendance, err := s.store.GetOpenAttendance(ctx, userID, flextime.Now().Add(-s.maxShiftLength))
+	if err != nil {
+		return nil, err
+	}
+	if attendance != nil {
+		return attendance, nil
+	}
+	return s.store.GetLatestAttendance(ctx, userID)
+}
+
+// GetAttendanceSummary returns the hours worked this month against the required hours.
+// The required hours come from the contracts of the user. A user without a contract falls back to
+// the working_hours table when set for the month, otherwise to the calendar.
 func (s *attendanceService) GetAttendanceSummary(ctx context.Context, params models.GetAttendanceSummaryParameters) (*models.GetAttendanceSummaryResults, error) {
 	var (
 		res models.GetAttendanceSummaryResults
@@ -101,17 +252,33 @@ func (s *attendanceService) GetAttendanceSummary(ctx context.Context, params mod
 	if err != nil {
 		return nil, err
 	}
+	start, end, err := timeutil.GetMonthRange(month)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := loadCalendar(ctx, s.store, start, end)
+	if err != nil {
+		return nil, err
+	}
 
+	contracts, err := s.store.GetEmploymentContracts(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
 	hour, err := s.store.GetWorkingHours(ctx, flextime.Now())
 	if err != nil {
 		return nil, err
 	}
-	if hour == nil || hour.WorkingHours == 0 {
-		return nil, xerrors.New("No working hours set for this month")
+	switch {
+	case len(contracts) != 0:
+		res.RequiredHours = contracts.RequiredHours(cal, start, end)
+	case hour != nil && hour.WorkingHours != 0:
+		res.RequiredHours = hour.WorkingHours
+	default:
+		res.RequiredHours = models.RequiredHours(cal, start, end, models.StandardWorkingHoursPerDay)
 	}
-	res.RequiredHours = hour.WorkingHours
 
-	attendance, err := s.store.GetLatestAttendance(ctx, params.UserID)
+	attendance, err := s.getCurrentAttendance(ctx, params.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -121,10 +288,87 @@ func (s *attendanceService) GetAttendanceSummary(ctx context.Context, params mod
 		return nil, err
 	}
 
-	res.TotalHours = attendances.ManipulateTotalWorkHours()
+	leaves, err := s.store.GetLeaveRequests(ctx, &models.GetLeaveRequestsParameters{
+		UserID: params.UserID,
+		Status: models.LeaveStatusApproved,
+		From:   start,
+		To:     end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range leaves {
+		if l.Kind().CountsAsWorked() {
+			res.LeaveHours += l.HoursBetween(cal, contracts, start, end)
+		}
+	}
+
+	res.TotalHours = attendances.ManipulateTotalWorkHours() + res.LeaveHours
+	res.RoundedTotalHours = attendances.ManipulateTotalRoundedWorkHours() + res.LeaveHours
+	res.WorkLocationDays = attendances.CountWorkLocationDays(timezone.JSTLocation())
 
 	if attendance != nil {
 		res.LatestAttendance = *attendance
 	}
 	return &res, nil
 }
+
+// GetAttendanceHistory returns the attendance with every punch ever recorded for it, including superseded ones.
+// Attendances of other users are reported as not found.
+func (s *attendanceService) GetAttendanceHistory(ctx context.Context, params models.GetAttendanceHistoryParameters) (*models.GetAttendanceHistoryResults, error) {
+	if params.UserID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+
+	attendance, err := s.store.GetAttendance(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil || attendance.UserID != params.UserID {
+		return nil, models.ErrAttendanceNotFound
+	}
+
+	times, err := s.store.GetAttendanceTimes(ctx, attendance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := models.GetAttendanceHistoryResults{
+		Attendance: attendance,
+		Times:      times,
+	}
+	return &res, nil
+}
+
+// GetWorkBreakdown returns the statutory work, overtime, late-night and holiday work of the month for each day and in total.
+// It is counted from the rounded punches paid by payroll, with the weeks of the work week rule of the company.
+// Attendances from the start of the first week are loaded so that the weekly limit is applied across the month boundary.
+func (s *attendanceService) GetWorkBreakdown(ctx context.Context, params models.GetWorkBreakdownParameters) (*models.GetWorkBreakdownResults, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	start, end, err := timeutil.GetMonthRange(params.Month)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := loadCalendar(ctx, s.store, start, end)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := loadWorkWeekRule(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := s.store.GetAttendancesBetween(ctx, params.UserID, models.WeekStartOf(cal, rule, start), end)
+	if err != nil {
+		return nil, err
+	}
+
+	days := models.BreakdownWork(cal, rule, attendances, start, end)
+	res := models.GetWorkBreakdownResults{
+		Days:  days,
+		Total: days.Total(),
+	}
+	return &res, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
 	"testing"
 	"time"
 )
@@ -17,10 +18,23 @@ import (
 var IgnoreGlobalOptions = cmp.Options{
 	cmpopts.IgnoreFields(models.Attendance{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.Attendance{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.Attendance{}, "TenantID"),
 	cmpopts.IgnoreFields(models.AttendanceTime{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.AttendanceTime{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.AttendanceTime{}, "TenantID"),
 	cmpopts.IgnoreFields(models.User{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.User{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.User{}, "TenantID"),
+}
+
+// withSessions sets the single session implied by ClockedIn and ClockedOut of the expected attendance.
+func withSessions(a *models.Attendance) *models.Attendance {
+	if a.ClockedIn != nil {
+		a.Sessions = []*models.AttendanceSession{
+			{ClockedIn: a.ClockedIn, ClockedOut: a.ClockedOut},
+		}
+	}
+	return a
 }
 
 func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
@@ -33,7 +47,7 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 	}
 
 	userID := uuid.NewV4().String()
-	if err := store.CreateUser(context.Background(), &models.User{
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
 		ID:        userID,
 		Name:      "insert user",
 		Email:     "insert",
@@ -44,6 +58,23 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 		t.Errorf("CreateUser() %s", err)
 	}
 
+	first := &models.AttendanceTime{
+		Remark:           "test",
+		IsModified:       false,
+		AttendanceKindID: uint8(models.AttendanceKindClockIn),
+		PushedAt:         flextime.Now(),
+		CreatedAt:        flextime.Now(),
+		UpdatedAt:        flextime.Now(),
+	}
+	firstOut := &models.AttendanceTime{
+		Remark:           "test1",
+		IsModified:       false,
+		AttendanceKindID: uint8(models.AttendanceKindClockOut),
+		PushedAt:         flextime.Now(),
+		CreatedAt:        flextime.Now(),
+		UpdatedAt:        flextime.Now(),
+	}
+
 	type fields struct {
 		store sqlstore.SQLStore
 	}
@@ -66,14 +97,14 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:     "test",
 					IsModified: false,
 				},
 				userID: userID,
 			},
-			want: &models.Attendance{
+			want: withSessions(&models.Attendance{
 				UserID:     userID,
 				AttendedAt: flextime.Now(),
 				CreatedAt:  flextime.Now(),
@@ -87,7 +118,7 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 					UpdatedAt:        flextime.Now(),
 				},
 				ClockedOut: nil,
-			},
+			}),
 			wantErr: false,
 		},
 		{
@@ -96,14 +127,14 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:     "test1",
 					IsModified: false,
 				},
 				userID: userID,
 			},
-			want: &models.Attendance{
+			want: withSessions(&models.Attendance{
 				UserID:     userID,
 				AttendedAt: flextime.Now(),
 				CreatedAt:  flextime.Now(),
@@ -124,16 +155,16 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 					CreatedAt:        flextime.Now(),
 					UpdatedAt:        flextime.Now(),
 				},
-			},
+			}),
 			wantErr: false,
 		},
 		{
-			name: "Should check out when second time",
+			name: "Should clock in again when the session is closed",
 			fields: fields{
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:     "test2",
 					IsModified: false,
@@ -145,21 +176,18 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				AttendedAt: flextime.Now(),
 				CreatedAt:  flextime.Now(),
 				UpdatedAt:  flextime.Now(),
-				ClockedIn: &models.AttendanceTime{
-					Remark:           "test",
-					IsModified:       false,
-					AttendanceKindID: uint8(models.AttendanceKindClockIn),
-					PushedAt:         flextime.Now(),
-					CreatedAt:        flextime.Now(),
-					UpdatedAt:        flextime.Now(),
-				},
-				ClockedOut: &models.AttendanceTime{
-					Remark:           "test2",
-					IsModified:       false,
-					AttendanceKindID: uint8(models.AttendanceKindClockOut),
-					PushedAt:         flextime.Now(),
-					CreatedAt:        flextime.Now(),
-					UpdatedAt:        flextime.Now(),
+				ClockedIn:  first,
+				ClockedOut: nil,
+				Sessions: []*models.AttendanceSession{
+					{ClockedIn: first, ClockedOut: firstOut},
+					{ClockedIn: &models.AttendanceTime{
+						Remark:           "test2",
+						IsModified:       false,
+						AttendanceKindID: uint8(models.AttendanceKindClockIn),
+						PushedAt:         flextime.Now(),
+						CreatedAt:        flextime.Now(),
+						UpdatedAt:        flextime.Now(),
+					}},
 				},
 			},
 			wantErr: false,
@@ -170,14 +198,14 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:     "test",
 					IsModified: false,
 				},
 				userID: userID,
 			},
-			want: &models.Attendance{
+			want: withSessions(&models.Attendance{
 				UserID:     userID,
 				AttendedAt: time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
 				CreatedAt:  time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
@@ -191,7 +219,7 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 					UpdatedAt:        time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
 				},
 				ClockedOut: nil,
-			},
+			}),
 			shouldChangeDate: true,
 			wantErr:          false,
 		},
@@ -201,7 +229,7 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:     "test",
 					IsModified: false,
@@ -217,7 +245,7 @@ func Test_attendanceService_CreateOrUpdateAttendance(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx:            context.Background(),
+				ctx:            sqlstore.NewTestContext(),
 				attendanceTime: nil,
 				userID:         userID,
 			},
@@ -255,7 +283,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(sqlstore.NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -266,7 +294,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 		UpdatedAt:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Second),
 	}
 
-	if err := store.CreateAttendance(context.Background(), attendance); err != nil {
+	if err := store.CreateAttendance(sqlstore.NewTestContext(), attendance); err != nil {
 		t.Errorf("CreateAttendance() failed%s", err)
 	}
 
@@ -280,11 +308,12 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 		UpdatedAt:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Second),
 	}
 
-	if err := store.CreateAttendanceTime(context.Background(), time); err != nil {
+	if err := store.CreateAttendanceTime(sqlstore.NewTestContext(), time); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
 	attendance.ClockedIn = time
+	attendance.Sessions = []*models.AttendanceSession{{ClockedIn: time}}
 	type fields struct {
 		store sqlstore.SQLStore
 	}
@@ -305,7 +334,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				params: models.GetAttendancesParameters{
 					UserID: userID,
 					Month:  202001,
@@ -316,6 +345,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 				Attendances: models.Attendances{
 					attendance,
 				},
+				Shifts: []*models.ShiftCheck{},
 			},
 			wantErr: false,
 		},
@@ -325,7 +355,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				params: models.GetAttendancesParameters{
 					UserID: userID,
 					Month:  202002,
@@ -334,6 +364,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 			want: &models.GetAttendancesResults{
 				MaxCnt:      0,
 				Attendances: models.Attendances{},
+				Shifts:      []*models.ShiftCheck{},
 			},
 			wantErr: false,
 		},
@@ -343,7 +374,7 @@ func Test_attendanceService_GetAttendances(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				params: models.GetAttendancesParameters{
 					UserID: "",
 					Month:  0,
@@ -381,7 +412,7 @@ func Test_attendanceService_GetAttendanceSummary(t *testing.T) {
 	}
 
 	userID := uuid.NewV4().String()
-	if err := store.CreateUser(context.Background(), &models.User{
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
 		ID:        userID,
 		Name:      "insert user",
 		Email:     "insert",
@@ -398,7 +429,7 @@ func Test_attendanceService_GetAttendanceSummary(t *testing.T) {
 		PushedAt:         time.Date(2020, 1, 2, 10, 0, 0, 0, timezone.JSTLocation()),
 	}
 
-	if _, err := s.CreateOrUpdateAttendance(context.Background(), at, userID); err != nil {
+	if _, err := s.CreateOrUpdateAttendance(sqlstore.NewTestContext(), at, userID); err != nil {
 		t.Errorf("CreateOrUpdateAttendace() failed %s", err)
 	}
 
@@ -409,12 +440,12 @@ func Test_attendanceService_GetAttendanceSummary(t *testing.T) {
 		PushedAt:         time.Date(2020, 1, 2, 19, 0, 0, 0, timezone.JSTLocation()),
 	}
 
-	attendance, err := s.CreateOrUpdateAttendance(context.Background(), at, userID)
+	attendance, err := s.CreateOrUpdateAttendance(sqlstore.NewTestContext(), at, userID)
 	if err != nil {
 		t.Errorf("CreateOrUpdateAttendace() failed %s", err)
 	}
 
-	err = store.CreateWorkingHour(context.Background(), &models.WorkingHour{
+	err = store.CreateWorkingHour(sqlstore.NewTestContext(), &models.WorkingHour{
 		StartedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 		FinishedAt:   time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC),
 		WorkingHours: 180,
@@ -443,7 +474,7 @@ func Test_attendanceService_GetAttendanceSummary(t *testing.T) {
 				store: store,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: sqlstore.NewTestContext(),
 				params: models.GetAttendanceSummaryParameters{
 					UserID: userID,
 				},
@@ -472,3 +503,399 @@ func Test_attendanceService_GetAttendanceSummary(t *testing.T) {
 		})
 	}
 }
+
+func Test_attendanceService_PushBreakTime(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	s := NewAttendanceService(store)
+	timezone.Set("Asia/Tokyo")
+	flextime.Fix(time.Date(2020, 1, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	type args struct {
+		kind models.AttendanceKind
+		now  time.Time
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantBreaks int
+		wantOpen   bool
+		wantErr    bool
+	}{
+		{
+			name: "Should not end break before started",
+			args: args{
+				kind: models.AttendanceKindBreakEnd,
+				now:  time.Date(2020, 1, 2, 11, 0, 0, 0, timezone.JSTLocation()),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should start break",
+			args: args{
+				kind: models.AttendanceKindBreakStart,
+				now:  time.Date(2020, 1, 2, 12, 0, 0, 0, timezone.JSTLocation()),
+			},
+			wantBreaks: 1,
+			wantOpen:   true,
+			wantErr:    false,
+		},
+		{
+			name: "Should not start break twice",
+			args: args{
+				kind: models.AttendanceKindBreakStart,
+				now:  time.Date(2020, 1, 2, 12, 10, 0, 0, timezone.JSTLocation()),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should end break",
+			args: args{
+				kind: models.AttendanceKindBreakEnd,
+				now:  time.Date(2020, 1, 2, 13, 0, 0, 0, timezone.JSTLocation()),
+			},
+			wantBreaks: 1,
+			wantOpen:   false,
+			wantErr:    false,
+		},
+		{
+			name: "Should start second break",
+			args: args{
+				kind: models.AttendanceKindBreakStart,
+				now:  time.Date(2020, 1, 2, 15, 0, 0, 0, timezone.JSTLocation()),
+			},
+			wantBreaks: 2,
+			wantOpen:   true,
+			wantErr:    false,
+		},
+	}
+
+	if _, err := s.StartBreak(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "test"}, userID); err == nil {
+		t.Errorf("StartBreak() should fail before clock in")
+	}
+	if _, err := s.CreateOrUpdateAttendance(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "test"}, userID); err != nil {
+		t.Errorf("CreateOrUpdateAttendance() failed %s", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flextime.Fix(tt.args.now)
+			at := &models.AttendanceTime{Remark: "test"}
+			var (
+				got *models.Attendance
+				err error
+			)
+			if tt.args.kind == models.AttendanceKindBreakStart {
+				got, err = s.StartBreak(sqlstore.NewTestContext(), at, userID)
+			} else {
+				got, err = s.EndBreak(sqlstore.NewTestContext(), at, userID)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pushBreakTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Breaks) != tt.wantBreaks {
+				t.Errorf("pushBreakTime() breaks = %v, want %v", len(got.Breaks), tt.wantBreaks)
+			}
+			if got.HasOpenBreak() != tt.wantOpen {
+				t.Errorf("pushBreakTime() open = %v, want %v", got.HasOpenBreak(), tt.wantOpen)
+			}
+		})
+	}
+
+	flextime.Fix(time.Date(2020, 1, 2, 19, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := s.CreateOrUpdateAttendance(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "test"}, userID); err != nil {
+		t.Errorf("CreateOrUpdateAttendance() failed %s", err)
+	}
+	if _, err := s.EndBreak(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "test"}, userID); err == nil {
+		t.Errorf("EndBreak() should fail after clock out")
+	}
+
+	attendances, err := store.GetAttendances(sqlstore.NewTestContext(), userID, 202001)
+	if err != nil {
+		t.Errorf("GetAttendances() failed %s", err)
+	}
+	// Worked from 9:00 to 19:00 with an hour lunch and a break left open from 15:00 until clock out.
+	if got := attendances.ManipulateTotalWorkHours(); got != 5 {
+		t.Errorf("ManipulateTotalWorkHours() = %v, want %v", got, 5)
+	}
+}
+
+func Test_attendanceService_ClockInAndClockOut(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	s := NewAttendanceService(store)
+	timezone.Set("Asia/Tokyo")
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	tests := []struct {
+		name         string
+		kind         models.AttendanceKind
+		now          time.Time
+		wantStatus   models.AttendanceStatus
+		wantConflict bool
+	}{
+		{
+			name:         "Should not clock out without clock in",
+			kind:         models.AttendanceKindClockOut,
+			now:          time.Date(2020, 1, 2, 8, 0, 0, 0, timezone.JSTLocation()),
+			wantConflict: true,
+		},
+		{
+			name:       "Should clock in",
+			kind:       models.AttendanceKindClockIn,
+			now:        time.Date(2020, 1, 2, 9, 0, 0, 0, timezone.JSTLocation()),
+			wantStatus: models.AttendanceStatusWorking,
+		},
+		{
+			name:         "Should not clock in twice",
+			kind:         models.AttendanceKindClockIn,
+			now:          time.Date(2020, 1, 2, 9, 1, 0, 0, timezone.JSTLocation()),
+			wantConflict: true,
+		},
+		{
+			name:       "Should clock out",
+			kind:       models.AttendanceKindClockOut,
+			now:        time.Date(2020, 1, 2, 18, 0, 0, 0, timezone.JSTLocation()),
+			wantStatus: models.AttendanceStatusFinished,
+		},
+		{
+			name:         "Should not clock out twice",
+			kind:         models.AttendanceKindClockOut,
+			now:          time.Date(2020, 1, 2, 18, 1, 0, 0, timezone.JSTLocation()),
+			wantConflict: true,
+		},
+		{
+			name:       "Should clock in again for another session",
+			kind:       models.AttendanceKindClockIn,
+			now:        time.Date(2020, 1, 2, 20, 0, 0, 0, timezone.JSTLocation()),
+			wantStatus: models.AttendanceStatusWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flextime.Fix(tt.now)
+			at := &models.AttendanceTime{Remark: "test"}
+			var (
+				got *models.Attendance
+				err error
+			)
+			if tt.kind == models.AttendanceKindClockIn {
+				got, err = s.ClockIn(sqlstore.NewTestContext(), at, userID)
+			} else {
+				got, err = s.ClockOut(sqlstore.NewTestContext(), at, userID)
+			}
+			var conflictErr *models.AttendanceConflictError
+			if xerrors.As(err, &conflictErr) != tt.wantConflict {
+				t.Errorf("pushAttendanceTime() error = %v, wantConflict %v", err, tt.wantConflict)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Status() != tt.wantStatus {
+				t.Errorf("pushAttendanceTime() status = %v, want %v", got.Status(), tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_attendanceService_OvernightShift(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewAttendanceService(store, WithMaxShiftLength(12*time.Hour))
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	flextime.Fix(time.Date(2020, 1, 31, 22, 0, 0, 0, timezone.JSTLocation()))
+	in, err := s.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "night"}, userID)
+	if err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+		return
+	}
+
+	flextime.Fix(time.Date(2020, 2, 1, 6, 0, 0, 0, timezone.JSTLocation()))
+	out, err := s.ClockOut(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "night"}, userID)
+	if err != nil {
+		t.Errorf("ClockOut() failed %s", err)
+		return
+	}
+	if out.ID != in.ID {
+		t.Errorf("ClockOut() attendance id = %v, want %v", out.ID, in.ID)
+	}
+
+	jan, err := store.GetAttendances(sqlstore.NewTestContext(), userID, 202001)
+	if err != nil {
+		t.Errorf("GetAttendances() failed %s", err)
+	}
+	if got := jan.ManipulateTotalWorkHours(); got != 8 {
+		t.Errorf("ManipulateTotalWorkHours() january = %v, want %v", got, 8)
+	}
+	feb, err := store.GetAttendances(sqlstore.NewTestContext(), userID, 202002)
+	if err != nil {
+		t.Errorf("GetAttendances() failed %s", err)
+	}
+	if len(feb) != 0 {
+		t.Errorf("GetAttendances() february = %v, want %v", len(feb), 0)
+	}
+
+	flextime.Fix(time.Date(2020, 2, 1, 22, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := s.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "night"}, userID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+
+	// The shift has been open longer than the max shift length, so it can no longer be clocked out.
+	flextime.Fix(time.Date(2020, 2, 2, 12, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := s.ClockOut(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "night"}, userID); err == nil {
+		t.Errorf("ClockOut() should fail after max shift length")
+	}
+}
+
+func Test_attendanceService_GetAttendanceHistory(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	s := NewAttendanceService(store)
+	timezone.Set("Asia/Tokyo")
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	var (
+		attendance *models.Attendance
+		err        error
+	)
+	flextime.Fix(time.Date(2020, 1, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+	if attendance, err = s.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, userID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+		return
+	}
+	flextime.Fix(time.Date(2020, 1, 2, 18, 0, 0, 0, timezone.JSTLocation()))
+	if _, err = s.ClockOut(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "out"}, userID); err != nil {
+		t.Errorf("ClockOut() failed %s", err)
+		return
+	}
+	flextime.Fix(time.Date(2020, 1, 2, 19, 0, 0, 0, timezone.JSTLocation()))
+	if _, err = s.CreateOrUpdateAttendance(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "overtime"}, userID); err != nil {
+		t.Errorf("CreateOrUpdateAttendance() failed %s", err)
+		return
+	}
+
+	tests := []struct {
+		name         string
+		params       models.GetAttendanceHistoryParameters
+		wantRemarks  []string
+		wantModified []bool
+		wantErr      error
+	}{
+		{
+			name:         "Should get superseded punches in recorded order",
+			params:       models.GetAttendanceHistoryParameters{ID: attendance.ID, UserID: userID},
+			wantRemarks:  []string{"in", "out", "overtime"},
+			wantModified: []bool{false, true, false},
+		},
+		{
+			name:    "Should not get attendance of other user",
+			params:  models.GetAttendanceHistoryParameters{ID: attendance.ID, UserID: uuid.NewV4().String()},
+			wantErr: models.ErrAttendanceNotFound,
+		},
+		{
+			name:    "Should not get unknown attendance",
+			params:  models.GetAttendanceHistoryParameters{ID: attendance.ID + 1000, UserID: userID},
+			wantErr: models.ErrAttendanceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetAttendanceHistory(sqlstore.NewTestContext(), tt.params)
+			if !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("GetAttendanceHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			remarks := make([]string, 0)
+			modified := make([]bool, 0)
+			for _, at := range got.Times {
+				remarks = append(remarks, at.Remark)
+				modified = append(modified, at.IsModified)
+			}
+			if diff := cmp.Diff(remarks, tt.wantRemarks); diff != "" {
+				t.Errorf("GetAttendanceHistory() remarks diff %s", diff)
+			}
+			if diff := cmp.Diff(modified, tt.wantModified); diff != "" {
+				t.Errorf("GetAttendanceHistory() modified diff %s", diff)
+			}
+		})
+	}
+}
+
+func Test_attendanceService_GetAttendanceSummaryWithoutWorkingHours(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	s := NewAttendanceService(store)
+	timezone.Set("Asia/Tokyo")
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want float64
+	}{
+		{
+			name: "Should compute required hours excluding holidays",
+			now:  time.Date(2020, 2, 3, 10, 0, 0, 0, timezone.JSTLocation()),
+			want: 144,
+		},
+		{
+			name: "Should compute required hours excluding golden week",
+			now:  time.Date(2020, 5, 7, 10, 0, 0, 0, timezone.JSTLocation()),
+			want: 144,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flextime.Fix(tt.now)
+			got, err := s.GetAttendanceSummary(sqlstore.NewTestContext(), models.GetAttendanceSummaryParameters{UserID: userID})
+			if err != nil {
+				t.Errorf("GetAttendanceSummary() error = %v", err)
+				return
+			}
+			if got.RequiredHours != tt.want {
+				t.Errorf("GetAttendanceSummary() required hours = %v, want %v", got.RequiredHours, tt.want)
+			}
+		})
+	}
+}
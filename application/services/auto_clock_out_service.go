@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// autoClockOutLookbackDays is how far back open attendances are closed.
+// Older ones are left to the anomaly detection as missing clock-outs.
+const autoClockOutLookbackDays = 7
+
+type AutoClockOutService interface {
+	GetAutoClockOutPolicy(ctx context.Context) (*models.AutoClockOutPolicy, error)
+	UpdateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) (*models.AutoClockOutPolicy, error)
+	CloseOpenAttendances(ctx context.Context) ([]*models.AttendanceTime, error)
+}
+
+type autoClockOutService struct {
+	store  sqlstore.SQLStore
+	broker PresenceBroker
+}
+
+type AutoClockOutServiceOption func(s *autoClockOutService)
+
+// WithAutoClockOutPresenceBroker pushes the automatic clock-outs to the live boards.
+func WithAutoClockOutPresenceBroker(broker PresenceBroker) AutoClockOutServiceOption {
+	return func(s *autoClockOutService) {
+		s.broker = broker
+	}
+}
+
+func NewAutoClockOutService(ss sqlstore.SQLStore, opts ...AutoClockOutServiceOption) AutoClockOutService {
+	s := &autoClockOutService{
+		store: ss,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *autoClockOutService) GetAutoClockOutPolicy(ctx context.Context) (*models.AutoClockOutPolicy, error) {
+	policy, err := s.store.GetAutoClockOutPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, models.ErrAutoClockOutPolicyNotFound
+	}
+	return policy, nil
+}
+
+// UpdateAutoClockOutPolicy sets the auto clock-out policy of the company, creating it on first use.
+func (s *autoClockOutService) UpdateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) (*models.AutoClockOutPolicy, error) {
+	if policy == nil {
+		return nil, xerrors.New("auto clock-out policy is empty")
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		current, err := s.store.GetAutoClockOutPolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, s.store.CreateAutoClockOutPolicy(ctx, policy)
+		}
+		policy.ID = current.ID
+		policy.CreatedAt = current.CreatedAt
+		return nil, s.store.UpdateAutoClockOutPolicy(ctx, policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// CloseOpenAttendances clocks out the attendances still open past the cutoff of the policy
+// and returns the automatic clock-outs written. Nothing is closed while the policy is disabled.
+func (s *autoClockOutService) CloseOpenAttendances(ctx context.Context) ([]*models.AttendanceTime, error) {
+	closed := make([]*models.AttendanceTime, 0)
+	policy, err := s.store.GetAutoClockOutPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil || !policy.IsEnabled {
+		return closed, nil
+	}
+
+	now := flextime.Now()
+	attendances, err := s.store.GetUnclosedAttendances(ctx, now.AddDate(0, 0, -autoClockOutLookbackDays))
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range attendances {
+		t, err := s.closeAttendance(ctx, policy, a.ID)
+		if err != nil {
+			return closed, err
+		}
+		if t != nil {
+			closed = append(closed, t)
+		}
+	}
+	return closed, nil
+}
+
+// closeAttendance writes the automatic clock-out of the attendance unless the user clocked out in the meantime.
+func (s *autoClockOutService) closeAttendance(ctx context.Context, policy *models.AutoClockOutPolicy, attendanceID int64) (*models.AttendanceTime, error) {
+	var attendance *models.Attendance
+	v, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		a, err := s.store.GetAttendance(ctx, attendanceID)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return nil, nil
+		}
+		attendance = a
+		a.AttendedAt = a.AttendedAt.In(timezone.JSTLocation())
+		t := policy.ClockOut(a, flextime.Now())
+		if t == nil {
+			return nil, nil
+		}
+		if err = roundAttendanceTime(ctx, s.store, t); err != nil {
+			return nil, err
+		}
+		if err = s.store.CreateAttendanceTime(ctx, t); err != nil {
+			return nil, err
+		}
+		logger.NewWarn(logrus.Fields{
+			"user_id":       a.UserID,
+			"attendance_id": a.ID,
+			"pushed_at":     t.PushedAt,
+		}, "attendance clocked out automatically")
+		return t, nil
+	})
+	if err != nil || v == nil {
+		return nil, err
+	}
+	t := v.(*models.AttendanceTime)
+	attendance.AddTime(t)
+	publishPresence(ctx, s.broker, attendance, t)
+	return t, nil
+}
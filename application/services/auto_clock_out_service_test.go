@@ -0,0 +1,62 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func Test_autoClockOutService_CloseOpenAttendances(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewAutoClockOutService(store)
+	attendances := NewAttendanceService(store)
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	flextime.Fix(time.Date(2020, 6, 1, 9, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := attendances.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, userID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+
+	flextime.Fix(time.Date(2020, 6, 2, 6, 0, 0, 0, timezone.JSTLocation()))
+	closed, err := s.CloseOpenAttendances(sqlstore.NewTestContext())
+	if err != nil || len(closed) != 0 {
+		t.Errorf("CloseOpenAttendances() = %v, %v, want nothing closed without a policy", closed, err)
+	}
+
+	if _, err = s.UpdateAutoClockOutPolicy(sqlstore.NewTestContext(), &models.AutoClockOutPolicy{IsEnabled: true, CutoffMinutes: 5 * 60}); err != nil {
+		t.Errorf("UpdateAutoClockOutPolicy() error = %v", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		closed, err = s.CloseOpenAttendances(sqlstore.NewTestContext())
+		if err != nil {
+			t.Errorf("CloseOpenAttendances() error = %v", err)
+			return
+		}
+		if want := 1 - i; len(closed) != want {
+			t.Errorf("CloseOpenAttendances() closed %d attendances, want %d", len(closed), want)
+		}
+	}
+
+	latest, err := store.GetLatestAttendance(sqlstore.NewTestContext(), userID)
+	if err != nil {
+		t.Errorf("GetLatestAttendance() error = %v", err)
+		return
+	}
+	cutoff := time.Date(2020, 6, 2, 5, 0, 0, 0, timezone.JSTLocation())
+	if latest.ClockedOut == nil || !latest.ClockedOut.IsAutomatic || !latest.ClockedOut.PushedAt.Equal(cutoff) {
+		t.Errorf("CloseOpenAttendances() clocked out %v, want automatically at %v", latest.ClockedOut, cutoff)
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"golang.org/x/xerrors"
+)
+
+type ContractService interface {
+	GetEmploymentContracts(ctx context.Context, userID string) (models.EmploymentContracts, error)
+	CreateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) (*models.EmploymentContract, error)
+}
+
+type contractService struct {
+	store sqlstore.SQLStore
+}
+
+func NewContractService(ss sqlstore.SQLStore) ContractService {
+	return &contractService{
+		store: ss,
+	}
+}
+
+func (s *contractService) GetEmploymentContracts(ctx context.Context, userID string) (models.EmploymentContracts, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	return s.store.GetEmploymentContracts(ctx, userID)
+}
+
+// CreateEmploymentContract starts a new contract of the user.
+// The current contract finishes when the new one starts, so that past months keep their own contract.
+func (s *contractService) CreateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) (*models.EmploymentContract, error) {
+	if contract == nil {
+		return nil, xerrors.New("contract is empty")
+	}
+	if err := contract.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		contracts, err := s.store.GetEmploymentContracts(ctx, contract.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(contracts) != 0 {
+			latest := contracts[len(contracts)-1]
+			if !contract.StartedAt.After(latest.StartedAt) {
+				return nil, models.ErrContractOverlapped
+			}
+			if latest.FinishedAt.IsZero() || latest.FinishedAt.After(contract.StartedAt) {
+				latest.FinishedAt = contract.StartedAt
+				if err = s.store.UpdateEmploymentContract(ctx, latest); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, s.store.CreateEmploymentContract(ctx, contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_contractService_CreateEmploymentContract(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewContractService(store)
+
+	newContract := func(weeklyHours float64, startedAt time.Time) *models.EmploymentContract {
+		return &models.EmploymentContract{
+			UserID:           "asdiekawei42lasedi356ladfkjfity",
+			EmploymentTypeID: uint8(models.EmploymentTypeFullTime),
+			WeeklyHours:      weeklyHours,
+			Workdays:         uint8(models.DefaultWorkdays),
+			StartedAt:        startedAt,
+		}
+	}
+	tests := []struct {
+		name     string
+		contract *models.EmploymentContract
+		wantErr  error
+	}{
+		{
+			name:     "Should create first contract",
+			contract: newContract(40, time.Date(2019, 4, 1, 0, 0, 0, 0, timezone.JSTLocation())),
+		},
+		{
+			name:     "Should create next contract",
+			contract: newContract(30, time.Date(2020, 6, 15, 0, 0, 0, 0, timezone.JSTLocation())),
+		},
+		{
+			name:     "Should not create contract before the current one",
+			contract: newContract(20, time.Date(2020, 6, 1, 0, 0, 0, 0, timezone.JSTLocation())),
+			wantErr:  models.ErrContractOverlapped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.CreateEmploymentContract(sqlstore.NewTestContext(), tt.contract); !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("CreateEmploymentContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	contracts, err := s.GetEmploymentContracts(sqlstore.NewTestContext(), "asdiekawei42lasedi356ladfkjfity")
+	if err != nil {
+		t.Errorf("GetEmploymentContracts() error = %v", err)
+		return
+	}
+	if len(contracts) != 2 {
+		t.Errorf("GetEmploymentContracts() got %d contracts, want %d", len(contracts), 2)
+		return
+	}
+	if !contracts[0].FinishedAt.Equal(contracts[1].StartedAt) {
+		t.Errorf("GetEmploymentContracts() first finished at %v, want %v", contracts[0].FinishedAt, contracts[1].StartedAt)
+	}
+}
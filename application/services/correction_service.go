@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/Songmu/flextime"
+	"golang.org/x/xerrors"
+)
+
+type CorrectionService interface {
+	GetCorrectionRequests(ctx context.Context, params models.GetCorrectionRequestsParameters) ([]*models.CorrectionRequest, error)
+	CreateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) (*models.CorrectionRequest, error)
+	ApproveCorrectionRequest(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error)
+	RejectCorrectionRequest(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error)
+}
+
+type correctionService struct {
+	store sqlstore.SQLStore
+}
+
+func NewCorrectionService(ss sqlstore.SQLStore) CorrectionService {
+	return &correctionService{
+		store: ss,
+	}
+}
+
+func (s *correctionService) GetCorrectionRequests(ctx context.Context, params models.GetCorrectionRequestsParameters) ([]*models.CorrectionRequest, error) {
+	if params.ReviewerID != "" {
+		userIDs, err := reviewableUserIDs(ctx, s.store, params.ReviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if userIDs != nil && len(userIDs) == 0 {
+			return []*models.CorrectionRequest{}, nil
+		}
+		params.UserIDs = userIDs
+		params.ExcludeUserID = params.ReviewerID
+	}
+	if params.UserID == "" && params.ExcludeUserID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	return s.store.GetCorrectionRequests(ctx, &params)
+}
+
+func (s *correctionService) CreateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) (*models.CorrectionRequest, error) {
+	if request == nil {
+		return nil, xerrors.New("correction request is empty")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	request.CorrectionStatusID = uint8(models.CorrectionStatusPending)
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, s.store.CreateCorrectionRequest(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveCorrectionRequest approves the request and writes the requested punch.
+// The punch it replaces is kept with is_modified set so that the history is not lost,
+// and a replaced clock-in passes its work location on to the new one.
+func (s *correctionService) ApproveCorrectionRequest(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error) {
+	ctx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.store.Close(ctx)
+
+	request, err := s.getCorrectionRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err = request.Review(params.ReviewerID, models.CorrectionStatusApproved, params.Comment, flextime.Now()); err != nil {
+		return nil, err
+	}
+
+	attendance, err := s.store.GetAttendanceByDate(ctx, request.UserID, request.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := request.ReplacedTime(attendance)
+	if request.AttendanceTimeID != 0 && (replaced == nil || replaced.AttendanceKindID != request.AttendanceKindID) {
+		return nil, models.ErrCorrectionRequestInvalidTime
+	}
+
+	attendanceTime := &models.AttendanceTime{
+		Remark:              request.Reason,
+		AttendanceKindID:    request.AttendanceKindID,
+		PushedAt:            request.PushedAt,
+		ApprovedBy:          params.ReviewerID,
+		CorrectionRequestID: request.ID,
+	}
+	if replaced != nil && models.AttendanceKind(request.AttendanceKindID) == models.AttendanceKindClockIn {
+		attendanceTime.WorkLocationID = replaced.WorkLocationID
+	}
+
+	times := make([]*models.AttendanceTime, 0)
+	for _, t := range attendance.Times() {
+		if replaced != nil && t.ID == replaced.ID {
+			continue
+		}
+		times = append(times, t)
+	}
+	if err = models.ValidateSessionTimes(append(times, attendanceTime)); err != nil {
+		return nil, models.ErrCorrectionRequestInvalidTime
+	}
+
+	if attendance == nil {
+		attendance = &models.Attendance{
+			UserID:     request.UserID,
+			AttendedAt: request.PushedAt,
+		}
+		if err = s.store.CreateAttendance(ctx, attendance); err != nil {
+			return nil, err
+		}
+	}
+	if replaced != nil {
+		if err = s.store.UpdateAttendanceTimeModified(ctx, replaced.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	attendanceTime.AttendanceID = attendance.ID
+	if err = roundAttendanceTime(ctx, s.store, attendanceTime); err != nil {
+		return nil, err
+	}
+	if err = s.store.CreateAttendanceTime(ctx, attendanceTime); err != nil {
+		return nil, err
+	}
+
+	request.AttendanceID = attendance.ID
+	if err = s.store.UpdateCorrectionRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *correctionService) RejectCorrectionRequest(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error) {
+	ctx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.store.Close(ctx)
+
+	request, err := s.getCorrectionRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err = request.Review(params.ReviewerID, models.CorrectionStatusRejected, params.Comment, flextime.Now()); err != nil {
+		return nil, err
+	}
+	if err = s.store.UpdateCorrectionRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *correctionService) getCorrectionRequest(ctx context.Context, params models.ReviewCorrectionRequestParameters) (*models.CorrectionRequest, error) {
+	if params.ReviewerID == "" {
+		return nil, xerrors.New("reviewer id is empty")
+	}
+	request, err := s.store.GetCorrectionRequest(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, models.ErrCorrectionRequestNotFound
+	}
+	// A request of the reviewer is refused by the review itself.
+	if request.UserID != params.ReviewerID {
+		reviewer, err := loadPrincipal(ctx, s.store, params.ReviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if err = authorize(ctx, s.store, reviewer, request.UserID, models.AccessReview); err != nil {
+			return nil, err
+		}
+	}
+	return request, nil
+}
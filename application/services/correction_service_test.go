@@ -0,0 +1,194 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_correctionService_ApproveCorrectionRequest(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	attendanceService := NewAttendanceService(store)
+	s := NewCorrectionService(store)
+
+	userID := uuid.NewV4().String()
+	managerID := uuid.NewV4().String()
+	otherManagerID := uuid.NewV4().String()
+	for _, id := range []string{userID, managerID, otherManagerID} {
+		if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: id, Name: "insert user"}); err != nil {
+			t.Errorf("CreateUser() %s", err)
+		}
+		if err := store.UpdateUserRoles(sqlstore.NewTestContext(), id, []*models.UserRole{{UserID: id, RoleID: uint8(models.RoleManager)}}); err != nil {
+			t.Errorf("UpdateUserRoles() %s", err)
+		}
+	}
+	if err := store.CreateAssignment(sqlstore.NewTestContext(), &models.Assignment{UserID: userID, ManagerID: managerID, StartedAt: time.Date(2019, 1, 1, 0, 0, 0, 0, timezone.JSTLocation())}); err != nil {
+		t.Errorf("CreateAssignment() %s", err)
+	}
+
+	flextime.Fix(time.Date(2020, 1, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := attendanceService.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "test", WorkLocationID: uint8(models.WorkLocationRemote)}, userID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+	flextime.Fix(time.Date(2020, 1, 3, 12, 0, 0, 0, timezone.JSTLocation()))
+
+	newRequest := func(hour int) *models.CorrectionRequest {
+		request, err := s.CreateCorrectionRequest(sqlstore.NewTestContext(), &models.CorrectionRequest{
+			UserID:           userID,
+			AttendanceKindID: uint8(models.AttendanceKindClockOut),
+			TargetDate:       time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
+			PushedAt:         time.Date(2020, 1, 2, hour, 0, 0, 0, timezone.JSTLocation()),
+			Reason:           "forgot to clock out",
+		})
+		if err != nil {
+			t.Errorf("CreateCorrectionRequest() failed %s", err)
+		}
+		return request
+	}
+
+	invalid := newRequest(8)
+	first := newRequest(18)
+	second := newRequest(19)
+
+	tests := []struct {
+		name       string
+		params     models.ReviewCorrectionRequestParameters
+		wantErr    error
+		wantOutput time.Time
+	}{
+		{
+			name:    "Should not approve own request",
+			params:  models.ReviewCorrectionRequestParameters{ID: first.ID, ReviewerID: userID},
+			wantErr: models.ErrCorrectionRequestSelfReview,
+		},
+		{
+			name:    "Should not approve request of other manager's report",
+			params:  models.ReviewCorrectionRequestParameters{ID: first.ID, ReviewerID: otherManagerID},
+			wantErr: models.ErrForbidden,
+		},
+		{
+			name:    "Should not approve clock out before clock in",
+			params:  models.ReviewCorrectionRequestParameters{ID: invalid.ID, ReviewerID: managerID},
+			wantErr: models.ErrCorrectionRequestInvalidTime,
+		},
+		{
+			name:       "Should add forgotten clock out",
+			params:     models.ReviewCorrectionRequestParameters{ID: first.ID, ReviewerID: managerID},
+			wantOutput: time.Date(2020, 1, 2, 18, 0, 0, 0, timezone.JSTLocation()),
+		},
+		{
+			name:    "Should not approve twice",
+			params:  models.ReviewCorrectionRequestParameters{ID: first.ID, ReviewerID: managerID},
+			wantErr: models.ErrCorrectionRequestNotPending,
+		},
+		{
+			name:       "Should replace clock out",
+			params:     models.ReviewCorrectionRequestParameters{ID: second.ID, ReviewerID: managerID},
+			wantOutput: time.Date(2020, 1, 2, 19, 0, 0, 0, timezone.JSTLocation()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ApproveCorrectionRequest(sqlstore.NewTestContext(), tt.params)
+			if !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("ApproveCorrectionRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Status() != models.CorrectionStatusApproved || got.ReviewerID != managerID {
+				t.Errorf("ApproveCorrectionRequest() got = %v", got)
+			}
+
+			attendance, err := store.GetAttendanceByDate(sqlstore.NewTestContext(), userID, time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()))
+			if err != nil {
+				t.Errorf("GetAttendanceByDate() failed %s", err)
+				return
+			}
+			if attendance.ClockedOut == nil || !attendance.ClockedOut.PushedAt.Equal(tt.wantOutput) {
+				t.Errorf("ApproveCorrectionRequest() clocked out = %v, want %v", attendance.ClockedOut, tt.wantOutput)
+				return
+			}
+			if attendance.ClockedOut.ApprovedBy != managerID || attendance.ClockedOut.CorrectionRequestID != tt.params.ID {
+				t.Errorf("ApproveCorrectionRequest() approver = %v", attendance.ClockedOut)
+			}
+		})
+	}
+
+	t.Run("Should keep the work location of the replaced clock in", func(t *testing.T) {
+		request, err := s.CreateCorrectionRequest(sqlstore.NewTestContext(), &models.CorrectionRequest{
+			UserID:           userID,
+			AttendanceKindID: uint8(models.AttendanceKindClockIn),
+			TargetDate:       time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
+			PushedAt:         time.Date(2020, 1, 2, 8, 0, 0, 0, timezone.JSTLocation()),
+			Reason:           "clocked in late",
+		})
+		if err != nil {
+			t.Errorf("CreateCorrectionRequest() failed %s", err)
+			return
+		}
+		if _, err := s.ApproveCorrectionRequest(sqlstore.NewTestContext(), models.ReviewCorrectionRequestParameters{ID: request.ID, ReviewerID: managerID}); err != nil {
+			t.Errorf("ApproveCorrectionRequest() failed %s", err)
+			return
+		}
+		attendance, err := store.GetAttendanceByDate(sqlstore.NewTestContext(), userID, request.TargetDate)
+		if err != nil {
+			t.Errorf("GetAttendanceByDate() failed %s", err)
+			return
+		}
+		if attendance.ClockedIn.CorrectionRequestID != request.ID || attendance.ClockedIn.WorkLocation() != models.WorkLocationRemote {
+			t.Errorf("ApproveCorrectionRequest() clocked in = %v, want remote", attendance.ClockedIn)
+		}
+	})
+}
+
+func Test_correctionService_RejectCorrectionRequest(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewCorrectionService(store)
+
+	userID := uuid.NewV4().String()
+	adminID := uuid.NewV4().String()
+	if err := store.UpdateUserRoles(sqlstore.NewTestContext(), adminID, []*models.UserRole{{UserID: adminID, RoleID: uint8(models.RoleHRAdmin)}}); err != nil {
+		t.Errorf("UpdateUserRoles() %s", err)
+	}
+	request, err := s.CreateCorrectionRequest(sqlstore.NewTestContext(), &models.CorrectionRequest{
+		UserID:           userID,
+		AttendanceKindID: uint8(models.AttendanceKindClockIn),
+		TargetDate:       time.Date(2020, 1, 2, 0, 0, 0, 0, timezone.JSTLocation()),
+		PushedAt:         time.Date(2020, 1, 2, 9, 0, 0, 0, timezone.JSTLocation()),
+		Reason:           "forgot to clock in",
+	})
+	if err != nil {
+		t.Errorf("CreateCorrectionRequest() failed %s", err)
+		return
+	}
+
+	got, err := s.RejectCorrectionRequest(sqlstore.NewTestContext(), models.ReviewCorrectionRequestParameters{
+		ID:         request.ID,
+		ReviewerID: adminID,
+		Comment:    "no record",
+	})
+	if err != nil {
+		t.Errorf("RejectCorrectionRequest() failed %s", err)
+		return
+	}
+	if got.Status() != models.CorrectionStatusRejected {
+		t.Errorf("RejectCorrectionRequest() status = %v", got.Status())
+	}
+
+	attendance, err := store.GetAttendanceByDate(sqlstore.NewTestContext(), userID, request.TargetDate)
+	if err != nil {
+		t.Errorf("GetAttendanceByDate() failed %s", err)
+	}
+	if attendance != nil {
+		t.Errorf("RejectCorrectionRequest() should not create attendance")
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/Songmu/flextime"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type FlextimeService interface {
+	GetFlextimeRule(ctx context.Context) (*models.FlextimeRule, error)
+	UpdateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) (*models.FlextimeRule, error)
+	GetFlextimeSummary(ctx context.Context, params models.GetFlextimeSummaryParameters) (*models.GetFlextimeSummaryResults, error)
+}
+
+type flextimeService struct {
+	store sqlstore.SQLStore
+}
+
+func NewFlextimeService(ss sqlstore.SQLStore) FlextimeService {
+	return &flextimeService{
+		store: ss,
+	}
+}
+
+func (s *flextimeService) GetFlextimeRule(ctx context.Context) (*models.FlextimeRule, error) {
+	rule, err := s.store.GetFlextimeRule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, models.ErrFlextimeRuleNotFound
+	}
+	return rule, nil
+}
+
+// UpdateFlextimeRule sets the flextime rule of the company, creating it on first use.
+func (s *flextimeService) UpdateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) (*models.FlextimeRule, error) {
+	if rule == nil {
+		return nil, xerrors.New("flextime rule is empty")
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		current, err := s.store.GetFlextimeRule(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, s.store.CreateFlextimeRule(ctx, rule)
+		}
+		rule.ID = current.ID
+		rule.CreatedAt = current.CreatedAt
+		return nil, s.store.UpdateFlextimeRule(ctx, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetFlextimeSummary returns the settlement of the period containing the month and the core time check of its days.
+// The balance is carried over period by period from the first period of the rule.
+func (s *flextimeService) GetFlextimeSummary(ctx context.Context, params models.GetFlextimeSummaryParameters) (*models.GetFlextimeSummaryResults, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	month, _, err := timeutil.GetMonthRange(params.Month)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.GetFlextimeRule(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := rule.PeriodOf(month)
+	first := start
+	if !rule.CreatedAt.IsZero() {
+		if ruleStart, _ := rule.PeriodOf(rule.CreatedAt.In(month.Location())); ruleStart.Before(start) {
+			first = ruleStart
+		}
+	}
+	cal, err := loadCalendar(ctx, s.store, first, end)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := s.store.GetEmploymentContracts(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		settlements = make([]models.FlextimeSettlement, 0)
+		attendances models.Attendances
+		leaves      []*models.LeaveRequest
+	)
+	for period := first; !period.After(start); period = period.AddDate(0, rule.PeriodMonths, 0) {
+		periodStart, periodEnd := rule.PeriodOf(period)
+		var settlement models.FlextimeSettlement
+		settlement, attendances, leaves, err = s.getSettlement(ctx, params.UserID, cal, contracts, periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	res := models.GetFlextimeSummaryResults{
+		Rule:       rule,
+		Settlement: rule.Settle(settlements),
+		Days:       rule.FlextimeDays(cal, attendances, leaves, start, end, flextime.Now()),
+	}
+	return &res, nil
+}
+
+// getSettlement totals the hours of the user between start and end without the carry-over.
+// The attendances and approved leave it was totalled from are returned with it.
+func (s *flextimeService) getSettlement(ctx context.Context, userID string, cal *models.Calendar, contracts models.EmploymentContracts, start, end time.Time) (models.FlextimeSettlement, models.Attendances, []*models.LeaveRequest, error) {
+	settlement := models.FlextimeSettlement{
+		Start: start,
+		End:   end,
+	}
+	required, err := s.getRequiredHours(ctx, cal, contracts, start, end)
+	if err != nil {
+		return settlement, nil, nil, err
+	}
+	settlement.RequiredHours = required
+
+	attendances, err := s.store.GetAttendancesBetween(ctx, userID, start, end)
+	if err != nil {
+		return settlement, nil, nil, err
+	}
+	settlement.WorkedHours = attendances.ManipulateTotalWorkHours()
+
+	leaves, err := s.store.GetLeaveRequests(ctx, &models.GetLeaveRequestsParameters{
+		UserID: userID,
+		Status: models.LeaveStatusApproved,
+		From:   start,
+		To:     end,
+	})
+	if err != nil {
+		return settlement, nil, nil, err
+	}
+	for _, l := range leaves {
+		if l.Kind().CountsAsWorked() {
+			settlement.LeaveHours += l.HoursBetween(cal, contracts, start, end)
+		}
+	}
+	return settlement, attendances, leaves, nil
+}
+
+// getRequiredHours totals the required hours of the months between start and end.
+// As in the attendance summary, the contracts of the user come first; a user without a contract falls back to
+// the working hours set for the month, taken in the middle of the month so that either convention of its bounds matches.
+func (s *flextimeService) getRequiredHours(ctx context.Context, cal *models.Calendar, contracts models.EmploymentContracts, start, end time.Time) (float64, error) {
+	var required float64
+	if len(contracts) != 0 {
+		return contracts.RequiredHours(cal, start, end), nil
+	}
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		monthEnd := month.AddDate(0, 1, 0).Add(-time.Second)
+		hour, err := s.store.GetWorkingHours(ctx, month.AddDate(0, 0, 14).Add(12*time.Hour))
+		if err != nil {
+			return 0, err
+		}
+		if hour != nil && hour.WorkingHours != 0 {
+			required += hour.WorkingHours
+		} else {
+			required += models.RequiredHours(cal, month, monthEnd, models.StandardWorkingHoursPerDay)
+		}
+	}
+	return required, nil
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"golang.org/x/xerrors"
+	"testing"
+)
+
+func Test_flextimeService_UpdateFlextimeRule(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewFlextimeService(store)
+
+	if _, err := s.GetFlextimeRule(sqlstore.NewTestContext()); !xerrors.Is(err, models.ErrFlextimeRuleNotFound) {
+		t.Errorf("GetFlextimeRule() error = %v, wantErr %v", err, models.ErrFlextimeRuleNotFound)
+	}
+
+	tests := []struct {
+		name    string
+		rule    *models.FlextimeRule
+		wantErr bool
+	}{
+		{
+			name: "Should create rule",
+			rule: &models.FlextimeRule{PeriodMonths: 1, StartMonth: 4, CoreStartMinutes: 11 * 60, CoreEndMinutes: 15 * 60},
+		},
+		{
+			name: "Should update rule",
+			rule: &models.FlextimeRule{PeriodMonths: 3, StartMonth: 4, CarryOverSurplus: true},
+		},
+		{
+			name:    "Should not update rule with reversed core time",
+			rule:    &models.FlextimeRule{PeriodMonths: 1, StartMonth: 4, CoreStartMinutes: 15 * 60, CoreEndMinutes: 11 * 60},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.UpdateFlextimeRule(sqlstore.NewTestContext(), tt.rule); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateFlextimeRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	rule, err := s.GetFlextimeRule(sqlstore.NewTestContext())
+	if err != nil {
+		t.Errorf("GetFlextimeRule() error = %v", err)
+		return
+	}
+	if rule.PeriodMonths != 3 || rule.HasCoreTime() || !rule.CarryOverSurplus {
+		t.Errorf("GetFlextimeRule() = %+v, want the updated rule", rule)
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"golang.org/x/xerrors"
+)
+
+type GeofenceService interface {
+	GetGeofences(ctx context.Context) (models.Geofences, error)
+	CreateGeofence(ctx context.Context, geofence *models.Geofence) (*models.Geofence, error)
+	DeleteGeofence(ctx context.Context, id int64) error
+}
+
+type geofenceService struct {
+	store sqlstore.SQLStore
+}
+
+func NewGeofenceService(ss sqlstore.SQLStore) GeofenceService {
+	return &geofenceService{
+		store: ss,
+	}
+}
+
+func (s *geofenceService) GetGeofences(ctx context.Context) (models.Geofences, error) {
+	return s.store.GetGeofences(ctx)
+}
+
+func (s *geofenceService) CreateGeofence(ctx context.Context, geofence *models.Geofence) (*models.Geofence, error) {
+	if geofence == nil {
+		return nil, xerrors.New("geofence is empty")
+	}
+	if err := geofence.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateGeofence(ctx, geofence); err != nil {
+		return nil, err
+	}
+	return geofence, nil
+}
+
+// DeleteGeofence removes the geofence. Punches already recorded inside it keep their status.
+func (s *geofenceService) DeleteGeofence(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		geofence, err := s.store.GetGeofence(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if geofence == nil {
+			return nil, models.ErrGeofenceNotFound
+		}
+		return nil, s.store.DeleteGeofence(ctx, id)
+	})
+	return err
+}
+
+// checkGeofence sets the geofence status of a punch of the attendance and refuses it when the mode does not allow it.
+// Only sessions worked at the office are checked; a clock-out or a break follows the clock-in of its session.
+// A punch at a kiosk is not checked, the kiosk being a terminal registered at the office.
+func checkGeofence(ctx context.Context, store sqlstore.SQLStore, mode models.GeofenceMode, attendance *models.Attendance, t *models.AttendanceTime) error {
+	t.GeofenceID = 0
+	t.GeofenceStatusID = uint8(models.GeofenceStatusNone)
+	if mode == models.GeofenceModeOff || t.KioskID != 0 {
+		return nil
+	}
+	if sessionWorkLocation(attendance, t) != models.WorkLocationOffice {
+		return nil
+	}
+
+	geofences, err := store.GetGeofences(ctx)
+	if err != nil {
+		return err
+	}
+	geofences.Check(t)
+	if !mode.Allows(t.GeofenceStatus()) {
+		return models.ErrPunchOutsideGeofence
+	}
+	return nil
+}
+
+// sessionWorkLocation returns where the session of the punch is worked: its own location for a clock-in,
+// otherwise the location of the clock-in of the session it belongs to.
+func sessionWorkLocation(attendance *models.Attendance, t *models.AttendanceTime) models.WorkLocation {
+	if models.AttendanceKind(t.AttendanceKindID) == models.AttendanceKindClockIn {
+		return t.WorkLocation()
+	}
+	session := attendance.LatestSession()
+	if session == nil {
+		return models.WorkLocationNone
+	}
+	return session.ClockedIn.WorkLocation()
+}
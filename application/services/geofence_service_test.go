@@ -0,0 +1,97 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_checkGeofence(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewGeofenceService(store)
+	attendances := NewAttendanceService(store, WithGeofenceMode(models.GeofenceModeReject))
+
+	office, err := s.CreateGeofence(sqlstore.NewTestContext(), &models.Geofence{
+		Name:         "東京オフィス",
+		Latitude:     35.681236,
+		Longitude:    139.767125,
+		RadiusMeters: 200,
+	})
+	if err != nil {
+		t.Errorf("CreateGeofence() error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name       string
+		in         *models.AttendanceTime
+		out        *models.AttendanceTime
+		wantErr    error
+		wantStatus models.GeofenceStatus
+	}{
+		{
+			name:       "Should record a punch inside the office",
+			in:         &models.AttendanceTime{Remark: "in", HasLocation: true, Latitude: 35.6815, Longitude: 139.7670},
+			out:        &models.AttendanceTime{Remark: "out", HasLocation: true, Latitude: 35.6810, Longitude: 139.7675},
+			wantStatus: models.GeofenceStatusInside,
+		},
+		{
+			name:    "Should reject a punch outside the office",
+			in:      &models.AttendanceTime{Remark: "in", HasLocation: true, Latitude: 35.690921, Longitude: 139.700258},
+			wantErr: models.ErrPunchOutsideGeofence,
+		},
+		{
+			name:    "Should reject a punch without location",
+			in:      &models.AttendanceTime{Remark: "in"},
+			wantErr: models.ErrPunchOutsideGeofence,
+		},
+		{
+			name:       "Should not check a session worked remotely",
+			in:         &models.AttendanceTime{Remark: "in", WorkLocationID: uint8(models.WorkLocationRemote)},
+			out:        &models.AttendanceTime{Remark: "out", HasLocation: true, Latitude: 35.690921, Longitude: 139.700258},
+			wantStatus: models.GeofenceStatusNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.NewV4().String()
+			if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+				ID:   userID,
+				Name: "insert user",
+			}); err != nil {
+				t.Errorf("CreateUser() %s", err)
+			}
+
+			flextime.Fix(time.Date(2020, 6, 1, 9, 0, 0, 0, timezone.JSTLocation()))
+			_, err := attendances.ClockIn(sqlstore.NewTestContext(), tt.in, userID)
+			if !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("ClockIn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			flextime.Fix(time.Date(2020, 6, 1, 18, 0, 0, 0, timezone.JSTLocation()))
+			attendance, err := attendances.ClockOut(sqlstore.NewTestContext(), tt.out, userID)
+			if err != nil {
+				t.Errorf("ClockOut() error = %v", err)
+				return
+			}
+			for _, p := range []*models.AttendanceTime{attendance.ClockedIn, attendance.ClockedOut} {
+				if p.GeofenceStatus() != tt.wantStatus {
+					t.Errorf("GeofenceStatus() = %v, want %v", p.GeofenceStatus(), tt.wantStatus)
+				}
+			}
+			if tt.wantStatus == models.GeofenceStatusInside && attendance.ClockedOut.GeofenceID != office.ID {
+				t.Errorf("GeofenceID = %v, want %v", attendance.ClockedOut.GeofenceID, office.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type HolidayService interface {
+	GetHolidays(ctx context.Context, year int) ([]*models.Holiday, error)
+	CreateHoliday(ctx context.Context, holiday *models.Holiday) (*models.Holiday, error)
+	DeleteHoliday(ctx context.Context, id int64) error
+}
+
+type holidayService struct {
+	store sqlstore.SQLStore
+}
+
+func NewHolidayService(ss sqlstore.SQLStore) HolidayService {
+	return &holidayService{
+		store: ss,
+	}
+}
+
+// GetHolidays returns the national holidays and company closure days of the year in date order.
+func (s *holidayService) GetHolidays(ctx context.Context, year int) ([]*models.Holiday, error) {
+	if year == 0 {
+		return nil, xerrors.New("year is empty")
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	end := start.AddDate(1, 0, 0).Add(-time.Second)
+
+	cal, err := loadCalendar(ctx, s.store, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return cal.Holidays(start, end), nil
+}
+
+// CreateHoliday adds a company closure day. A date that is already a holiday is rejected.
+func (s *holidayService) CreateHoliday(ctx context.Context, holiday *models.Holiday) (*models.Holiday, error) {
+	if holiday == nil {
+		return nil, xerrors.New("holiday is empty")
+	}
+	if err := holiday.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		cal, err := loadCalendar(ctx, s.store, holiday.Date, holiday.Date)
+		if err != nil {
+			return nil, err
+		}
+		if cal.IsHoliday(holiday.Date) {
+			return nil, models.ErrHolidayAlreadyExists
+		}
+		return nil, s.store.CreateHoliday(ctx, holiday)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *holidayService) DeleteHoliday(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		holiday, err := s.store.GetHoliday(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if holiday == nil {
+			return nil, models.ErrHolidayNotFound
+		}
+		return nil, s.store.DeleteHoliday(ctx, id)
+	})
+	return err
+}
+
+// loadCalendar returns the calendar in JST with the company closure days between the days of start and end.
+func loadCalendar(ctx context.Context, store sqlstore.SQLStore, start, end time.Time) (*models.Calendar, error) {
+	loc := timezone.JSTLocation()
+	s := start.In(loc)
+	e := end.In(loc)
+	closures, err := store.GetHolidays(ctx,
+		time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, loc),
+		time.Date(e.Year(), e.Month(), e.Day(), 23, 59, 59, 0, loc),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewCalendar(loc, closures), nil
+}
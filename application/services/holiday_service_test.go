@@ -0,0 +1,57 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_holidayService_CreateHoliday(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewHolidayService(store)
+
+	tests := []struct {
+		name    string
+		holiday *models.Holiday
+		wantErr error
+	}{
+		{
+			name:    "Should create company closure",
+			holiday: &models.Holiday{Date: time.Date(2020, 12, 29, 0, 0, 0, 0, timezone.JSTLocation()), Name: "年末休暇"},
+		},
+		{
+			name:    "Should not create closure twice",
+			holiday: &models.Holiday{Date: time.Date(2020, 12, 29, 0, 0, 0, 0, timezone.JSTLocation()), Name: "年末休暇"},
+			wantErr: models.ErrHolidayAlreadyExists,
+		},
+		{
+			name:    "Should not create closure on national holiday",
+			holiday: &models.Holiday{Date: time.Date(2020, 11, 3, 0, 0, 0, 0, timezone.JSTLocation()), Name: "創立記念日"},
+			wantErr: models.ErrHolidayAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.CreateHoliday(sqlstore.NewTestContext(), tt.holiday); !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("CreateHoliday() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	holidays, err := s.GetHolidays(sqlstore.NewTestContext(), 2020)
+	if err != nil {
+		t.Errorf("GetHolidays() error = %v", err)
+		return
+	}
+	if len(holidays) != 19 {
+		t.Errorf("GetHolidays() got %d holidays, want %d", len(holidays), 19)
+		return
+	}
+	if last := holidays[len(holidays)-1]; last.IsNational || last.Name != "年末休暇" {
+		t.Errorf("GetHolidays() last = %v", last)
+	}
+}
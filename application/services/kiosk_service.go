@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+// DefaultPunchCodeLifetime is how long a punch code can be used when no option is given.
+const DefaultPunchCodeLifetime = 5 * time.Minute
+
+type KioskService interface {
+	GetKiosks(ctx context.Context) ([]*models.Kiosk, error)
+	CreateKiosk(ctx context.Context, kiosk *models.Kiosk) (*models.Kiosk, string, error)
+	DeleteKiosk(ctx context.Context, id int64) error
+	AuthenticateKiosk(ctx context.Context, token string) (*models.Kiosk, error)
+	IssuePunchCodes(ctx context.Context, userIDs []string) ([]*models.PunchCode, error)
+	ResolvePunchCode(ctx context.Context, code string) (string, error)
+}
+
+type kioskService struct {
+	store  sqlstore.SQLStore
+	signer *models.PunchCodeSigner
+}
+
+type KioskServiceOption func(s *kioskService)
+
+// WithPunchCodeSigner sets the signer of the punch codes. The kiosks and the issuers of the codes must share it.
+func WithPunchCodeSigner(signer *models.PunchCodeSigner) KioskServiceOption {
+	return func(s *kioskService) {
+		s.signer = signer
+	}
+}
+
+func NewKioskService(ss sqlstore.SQLStore, opts ...KioskServiceOption) KioskService {
+	s := &kioskService{
+		store: ss,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *kioskService) GetKiosks(ctx context.Context) ([]*models.Kiosk, error) {
+	return s.store.GetKiosks(ctx)
+}
+
+// CreateKiosk registers the kiosk and returns it with its token.
+// The token is the credential of the terminal and can not be shown again.
+func (s *kioskService) CreateKiosk(ctx context.Context, kiosk *models.Kiosk) (*models.Kiosk, string, error) {
+	if kiosk == nil {
+		return nil, "", xerrors.New("kiosk is empty")
+	}
+	if err := kiosk.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, hash, err := models.NewKioskToken()
+	if err != nil {
+		return nil, "", err
+	}
+	kiosk.TokenHash = hash
+	if err := s.store.CreateKiosk(ctx, kiosk); err != nil {
+		return nil, "", err
+	}
+	return kiosk, token, nil
+}
+
+// DeleteKiosk revokes the kiosk. Punches already recorded from it keep its id.
+func (s *kioskService) DeleteKiosk(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		kiosk, err := s.store.GetKiosk(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if kiosk == nil {
+			return nil, models.ErrKioskNotFound
+		}
+		return nil, s.store.DeleteKiosk(ctx, id)
+	})
+	return err
+}
+
+// AuthenticateKiosk returns the kiosk of the token and records that it was used.
+// The token is looked up across the tenants, the kiosk telling which tenant it punches for.
+// It returns models.ErrKioskNotFound for an unknown or revoked token.
+func (s *kioskService) AuthenticateKiosk(ctx context.Context, token string) (*models.Kiosk, error) {
+	if token == "" {
+		return nil, models.ErrKioskNotFound
+	}
+	kiosk, err := s.store.GetKioskByTokenHash(ctx, models.HashKioskToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if kiosk == nil {
+		return nil, models.ErrKioskNotFound
+	}
+	kiosk.LastUsedAt = flextime.Now()
+	if err := s.store.UpdateKioskLastUsedAt(sqlstore.WithTenant(ctx, kiosk.TenantID), kiosk.ID, kiosk.LastUsedAt); err != nil {
+		logger.NewWarn(logrus.Fields{"kiosk_id": kiosk.ID}, err.Error())
+	}
+	return kiosk, nil
+}
+
+// IssuePunchCodes returns a new punch code for each user, e.g. to be shown as a QR code or printed on badges.
+func (s *kioskService) IssuePunchCodes(ctx context.Context, userIDs []string) ([]*models.PunchCode, error) {
+	if s.signer == nil {
+		return nil, xerrors.New("punch code signer is not set")
+	}
+	if len(userIDs) == 0 {
+		return nil, xerrors.New("user ids are empty")
+	}
+	codes := make([]*models.PunchCode, 0)
+	for _, userID := range userIDs {
+		user, err := s.store.GetUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == "" {
+			return nil, models.ErrPunchCodeUserNotFound
+		}
+		codes = append(codes, s.signer.Issue(userID, flextime.Now()))
+	}
+	return codes, nil
+}
+
+// ResolvePunchCode returns the user of a punch code presented at a kiosk.
+func (s *kioskService) ResolvePunchCode(ctx context.Context, code string) (string, error) {
+	if s.signer == nil {
+		return "", xerrors.New("punch code signer is not set")
+	}
+	userID, err := s.signer.Verify(code, flextime.Now())
+	if err != nil {
+		return "", err
+	}
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.ID == "" {
+		return "", models.ErrPunchCodeUserNotFound
+	}
+	return userID, nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_kioskService_ResolvePunchCode(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewKioskService(store, WithPunchCodeSigner(models.NewPunchCodeSigner([]byte("secret"), DefaultPunchCodeLifetime)))
+	attendances := NewAttendanceService(store)
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	kiosk, token, err := s.CreateKiosk(sqlstore.NewTestContext(), &models.Kiosk{Name: "工場1F"})
+	if err != nil {
+		t.Errorf("CreateKiosk() error = %v", err)
+		return
+	}
+	if authenticated, err := s.AuthenticateKiosk(sqlstore.NewTestContext(), token); err != nil || authenticated.ID != kiosk.ID {
+		t.Errorf("AuthenticateKiosk() = %v, %v, want kiosk %d", authenticated, err, kiosk.ID)
+	}
+	if _, err := s.AuthenticateKiosk(sqlstore.NewTestContext(), kiosk.TokenHash); !xerrors.Is(err, models.ErrKioskNotFound) {
+		t.Errorf("AuthenticateKiosk() error = %v, want %v", err, models.ErrKioskNotFound)
+	}
+
+	flextime.Fix(time.Date(2020, 6, 1, 9, 0, 0, 0, timezone.JSTLocation()))
+	codes, err := s.IssuePunchCodes(sqlstore.NewTestContext(), []string{userID})
+	if err != nil || len(codes) != 1 {
+		t.Errorf("IssuePunchCodes() = %v, %v", codes, err)
+		return
+	}
+	if _, err := s.IssuePunchCodes(sqlstore.NewTestContext(), []string{uuid.NewV4().String()}); !xerrors.Is(err, models.ErrPunchCodeUserNotFound) {
+		t.Errorf("IssuePunchCodes() error = %v, want %v", err, models.ErrPunchCodeUserNotFound)
+	}
+
+	resolved, err := s.ResolvePunchCode(sqlstore.NewTestContext(), codes[0].Code)
+	if err != nil || resolved != userID {
+		t.Errorf("ResolvePunchCode() = %v, %v, want %v", resolved, err, userID)
+		return
+	}
+	attendance, err := attendances.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{KioskID: kiosk.ID}, resolved)
+	if err != nil {
+		t.Errorf("ClockIn() error = %v", err)
+		return
+	}
+	if attendance.ClockedIn.KioskID != kiosk.ID {
+		t.Errorf("KioskID = %v, want %v", attendance.ClockedIn.KioskID, kiosk.ID)
+	}
+
+	flextime.Fix(time.Date(2020, 6, 1, 9, 5, 0, 0, timezone.JSTLocation()))
+	if _, err := s.ResolvePunchCode(sqlstore.NewTestContext(), codes[0].Code); !xerrors.Is(err, models.ErrPunchCodeExpired) {
+		t.Errorf("ResolvePunchCode() error = %v, want %v", err, models.ErrPunchCodeExpired)
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type LeaveService interface {
+	GetLeaveRequests(ctx context.Context, params models.GetLeaveRequestsParameters) ([]*models.LeaveRequest, error)
+	CreateLeaveRequest(ctx context.Context, request *models.LeaveRequest) (*models.LeaveRequest, error)
+	ApproveLeaveRequest(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error)
+	RejectLeaveRequest(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error)
+	GetLeaveBalance(ctx context.Context, userID string) (*models.GetLeaveBalanceResults, error)
+}
+
+type leaveService struct {
+	store sqlstore.SQLStore
+}
+
+func NewLeaveService(ss sqlstore.SQLStore) LeaveService {
+	return &leaveService{
+		store: ss,
+	}
+}
+
+func (s *leaveService) GetLeaveRequests(ctx context.Context, params models.GetLeaveRequestsParameters) ([]*models.LeaveRequest, error) {
+	if params.ReviewerID != "" {
+		userIDs, err := reviewableUserIDs(ctx, s.store, params.ReviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if userIDs != nil && len(userIDs) == 0 {
+			return []*models.LeaveRequest{}, nil
+		}
+		params.UserIDs = userIDs
+		params.ExcludeUserID = params.ReviewerID
+	}
+	if params.UserID == "" && params.ExcludeUserID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	return s.store.GetLeaveRequests(ctx, &params)
+}
+
+// CreateLeaveRequest submits a leave request.
+// Paid leave is rejected up front when it exceeds the current balance.
+func (s *leaveService) CreateLeaveRequest(ctx context.Context, request *models.LeaveRequest) (*models.LeaveRequest, error) {
+	if request == nil {
+		return nil, xerrors.New("leave request is empty")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	request.LeaveStatusID = uint8(models.LeaveStatusPending)
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		cal, err := loadCalendar(ctx, s.store, request.StartDate, request.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		contracts, err := s.store.GetEmploymentContracts(ctx, request.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err = request.ValidateHours(contracts); err != nil {
+			return nil, err
+		}
+		request.Days = request.CountDays(cal, contracts)
+		if request.Days == 0 {
+			return nil, xerrors.New("leave has no workdays")
+		}
+
+		if request.Kind().ConsumesBalance() {
+			grants, err := s.accrueLeaveGrants(ctx, request.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if grants.Balance(request.StartDate) < request.Days {
+				return nil, models.ErrLeaveBalanceInsufficient
+			}
+		}
+		return nil, s.store.CreateLeaveRequest(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveLeaveRequest approves the request and takes paid leave from the grants expiring first.
+func (s *leaveService) ApproveLeaveRequest(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error) {
+	ctx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.store.Close(ctx)
+
+	request, err := s.getLeaveRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err = request.Review(params.ReviewerID, models.LeaveStatusApproved, params.Comment, flextime.Now()); err != nil {
+		return nil, err
+	}
+
+	if request.Kind().ConsumesBalance() {
+		grants, err := s.accrueLeaveGrants(ctx, request.UserID)
+		if err != nil {
+			return nil, err
+		}
+		changed, err := grants.Consume(request.Days, request.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range changed {
+			if err = s.store.UpdateLeaveGrant(ctx, g); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = s.store.UpdateLeaveRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *leaveService) RejectLeaveRequest(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error) {
+	ctx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.store.Close(ctx)
+
+	request, err := s.getLeaveRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err = request.Review(params.ReviewerID, models.LeaveStatusRejected, params.Comment, flextime.Now()); err != nil {
+		return nil, err
+	}
+	if err = s.store.UpdateLeaveRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *leaveService) GetLeaveBalance(ctx context.Context, userID string) (*models.GetLeaveBalanceResults, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+
+	v, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		return s.accrueLeaveGrants(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	grants := v.(models.LeaveGrants)
+
+	res := models.GetLeaveBalanceResults{
+		Balance: grants.Balance(flextime.Now()),
+		Grants:  grants,
+	}
+	return &res, nil
+}
+
+func (s *leaveService) getLeaveRequest(ctx context.Context, params models.ReviewLeaveRequestParameters) (*models.LeaveRequest, error) {
+	if params.ReviewerID == "" {
+		return nil, xerrors.New("reviewer id is empty")
+	}
+	request, err := s.store.GetLeaveRequest(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, models.ErrLeaveRequestNotFound
+	}
+	// A request of the reviewer is refused by the review itself.
+	if request.UserID != params.ReviewerID {
+		reviewer, err := loadPrincipal(ctx, s.store, params.ReviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if err = authorize(ctx, s.store, reviewer, request.UserID, models.AccessReview); err != nil {
+			return nil, err
+		}
+	}
+	return request, nil
+}
+
+// accrueLeaveGrants grants the statutory paid leave due by now and returns all grants of the user.
+// A grant is given when the user attended at least 80% of the business days since the previous grant date.
+// Grant dates whose leave would already have expired are skipped.
+// The service is counted from the hire date of the user, or from the start of the first contract when it is not set.
+func (s *leaveService) accrueLeaveGrants(ctx context.Context, userID string) (models.LeaveGrants, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.store.GetLeaveGrants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := s.store.GetEmploymentContracts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := flextime.Now()
+	hiredAt := user.HiredAt
+	if hiredAt.IsZero() {
+		hiredAt = contracts.FirstStartedAt()
+	}
+	if hiredAt.IsZero() {
+		return grants, nil
+	}
+	hiredAt = hiredAt.In(timezone.JSTLocation())
+	from := hiredAt
+	for _, grantedAt := range models.LeaveGrantDates(hiredAt, now) {
+		periodStart := from
+		from = grantedAt
+		if grants.HasGrantedAt(grantedAt) || !grantedAt.AddDate(models.LeaveExpiryYears, 0, 0).After(now) {
+			continue
+		}
+
+		rate, err := s.getAttendanceRate(ctx, userID, periodStart, grantedAt.AddDate(0, 0, -1))
+		if err != nil {
+			return nil, err
+		}
+		if rate < models.MinLeaveAttendanceRate {
+			continue
+		}
+
+		grant := models.NewStatutoryLeaveGrant(userID, contracts, hiredAt, grantedAt)
+		if err = s.store.CreateLeaveGrant(ctx, grant); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// getAttendanceRate returns the ratio of attended days to the workdays of the user between the dates.
+// Approved paid leave counts as attended as required by the Labor Standards Act.
+func (s *leaveService) getAttendanceRate(ctx context.Context, userID string, start, end time.Time) (float64, error) {
+	cal, err := loadCalendar(ctx, s.store, start, end)
+	if err != nil {
+		return 0, err
+	}
+	contracts, err := s.store.GetEmploymentContracts(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	workdays := contracts.CountWorkdays(cal, start, end)
+	if workdays == 0 {
+		return 1, nil
+	}
+
+	attended, err := s.store.GetAttendedDaysCount(ctx, userID, start, end.AddDate(0, 0, 1).Add(-time.Second))
+	if err != nil {
+		return 0, err
+	}
+
+	leaves, err := s.store.GetLeaveRequests(ctx, &models.GetLeaveRequestsParameters{
+		UserID: userID,
+		Status: models.LeaveStatusApproved,
+		From:   start,
+		To:     end,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var leaveDays float64
+	for _, l := range leaves {
+		if l.Kind() == models.LeaveKindPaid {
+			leaveDays += l.DaysBetween(cal, contracts, start, end)
+		}
+	}
+	return (float64(attended) + leaveDays) / float64(workdays), nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_leaveService_LeaveBalance(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewLeaveService(store)
+
+	hiredAt := time.Date(2019, 4, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	attendedUserID := uuid.NewV4().String()
+	absentUserID := uuid.NewV4().String()
+	managerID := uuid.NewV4().String()
+	for _, id := range []string{attendedUserID, absentUserID, managerID} {
+		if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: id, Name: "insert user", HiredAt: hiredAt}); err != nil {
+			t.Errorf("CreateUser() %s", err)
+		}
+	}
+	if err := store.UpdateUserRoles(sqlstore.NewTestContext(), managerID, []*models.UserRole{{UserID: managerID, RoleID: uint8(models.RoleManager)}}); err != nil {
+		t.Errorf("UpdateUserRoles() %s", err)
+	}
+	if err := store.CreateAssignment(sqlstore.NewTestContext(), &models.Assignment{UserID: attendedUserID, ManagerID: managerID, StartedAt: time.Date(2019, 1, 1, 0, 0, 0, 0, timezone.JSTLocation())}); err != nil {
+		t.Errorf("CreateAssignment() %s", err)
+	}
+	for d := hiredAt; d.Before(hiredAt.AddDate(0, 6, 0)); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		if err := store.CreateAttendance(sqlstore.NewTestContext(), &models.Attendance{UserID: attendedUserID, AttendedAt: d.Add(9 * time.Hour)}); err != nil {
+			t.Errorf("CreateAttendance() %s", err)
+		}
+	}
+	flextime.Fix(time.Date(2019, 10, 2, 9, 0, 0, 0, timezone.JSTLocation()))
+
+	newRequest := func(userID string) *models.LeaveRequest {
+		return &models.LeaveRequest{
+			UserID:      userID,
+			LeaveKindID: uint8(models.LeaveKindPaid),
+			LeaveUnitID: uint8(models.LeaveUnitDay),
+			StartDate:   time.Date(2019, 10, 7, 0, 0, 0, 0, timezone.JSTLocation()),
+			EndDate:     time.Date(2019, 10, 8, 0, 0, 0, 0, timezone.JSTLocation()),
+		}
+	}
+
+	if _, err := s.CreateLeaveRequest(sqlstore.NewTestContext(), newRequest(absentUserID)); !xerrors.Is(err, models.ErrLeaveBalanceInsufficient) {
+		t.Errorf("CreateLeaveRequest() error = %v, want %v", err, models.ErrLeaveBalanceInsufficient)
+	}
+
+	request, err := s.CreateLeaveRequest(sqlstore.NewTestContext(), newRequest(attendedUserID))
+	if err != nil {
+		t.Errorf("CreateLeaveRequest() failed %s", err)
+		return
+	}
+	if _, err = s.ApproveLeaveRequest(sqlstore.NewTestContext(), models.ReviewLeaveRequestParameters{ID: request.ID, ReviewerID: managerID}); err != nil {
+		t.Errorf("ApproveLeaveRequest() failed %s", err)
+		return
+	}
+
+	tests := []struct {
+		name        string
+		userID      string
+		wantBalance float64
+		wantGrants  int
+	}{
+		{
+			name:        "Should grant 10 days and subtract approved leave",
+			userID:      attendedUserID,
+			wantBalance: 8,
+			wantGrants:  1,
+		},
+		{
+			name:        "Should not grant leave under 80% attendance",
+			userID:      absentUserID,
+			wantBalance: 0,
+			wantGrants:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetLeaveBalance(sqlstore.NewTestContext(), tt.userID)
+			if err != nil {
+				t.Errorf("GetLeaveBalance() error = %v", err)
+				return
+			}
+			if got.Balance != tt.wantBalance || len(got.Grants) != tt.wantGrants {
+				t.Errorf("GetLeaveBalance() balance = %v grants = %v, want %v %v", got.Balance, len(got.Grants), tt.wantBalance, tt.wantGrants)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/Songmu/flextime"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type OrganizationService interface {
+	GetDepartments(ctx context.Context) (models.Departments, error)
+	CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error)
+	UpdateDepartment(ctx context.Context, department *models.Department) (*models.Department, error)
+	DeleteDepartment(ctx context.Context, id int64) error
+	GetTeams(ctx context.Context, departmentID int64) ([]*models.Team, error)
+	CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
+	UpdateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
+	DeleteTeam(ctx context.Context, id int64) error
+	GetTeamMembers(ctx context.Context, teamID int64, at time.Time) (models.Assignments, error)
+	GetAssignments(ctx context.Context, userID string) (models.Assignments, error)
+	CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error)
+	GetReports(ctx context.Context, managerID string, at time.Time, indirect bool) (models.Assignments, error)
+	GetManagers(ctx context.Context, userID string, at time.Time) ([]*models.User, error)
+}
+
+type organizationService struct {
+	store sqlstore.SQLStore
+}
+
+func NewOrganizationService(ss sqlstore.SQLStore) OrganizationService {
+	return &organizationService{
+		store: ss,
+	}
+}
+
+func (s *organizationService) GetDepartments(ctx context.Context) (models.Departments, error) {
+	return s.store.GetDepartments(ctx)
+}
+
+func (s *organizationService) CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error) {
+	if department == nil {
+		return nil, xerrors.New("department is empty")
+	}
+	if err := department.Validate(); err != nil {
+		return nil, err
+	}
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.requireDepartment(ctx, department.ParentID); err != nil {
+			return nil, err
+		}
+		return nil, s.store.CreateDepartment(ctx, department)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+// UpdateDepartment renames the department or moves it under another one, but not under itself.
+func (s *organizationService) UpdateDepartment(ctx context.Context, department *models.Department) (*models.Department, error) {
+	if department == nil {
+		return nil, xerrors.New("department is empty")
+	}
+	if err := department.Validate(); err != nil {
+		return nil, err
+	}
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.requireDepartment(ctx, department.ID); err != nil {
+			return nil, err
+		}
+		if err := s.requireDepartment(ctx, department.ParentID); err != nil {
+			return nil, err
+		}
+		departments, err := s.store.GetDepartments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if departments.IsUnder(department.ParentID, department.ID) {
+			return nil, models.ErrDepartmentCycle
+		}
+		return nil, s.store.UpdateDepartment(ctx, department)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+// DeleteDepartment removes a department without departments or teams under it.
+func (s *organizationService) DeleteDepartment(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if id == 0 {
+			return nil, models.ErrDepartmentNotFound
+		}
+		if err := s.requireDepartment(ctx, id); err != nil {
+			return nil, err
+		}
+		departments, err := s.store.GetDepartments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range departments {
+			if d.ParentID == id {
+				return nil, models.ErrDepartmentNotEmpty
+			}
+		}
+		teams, err := s.store.GetTeams(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(teams) != 0 {
+			return nil, models.ErrDepartmentNotEmpty
+		}
+		return nil, s.store.DeleteDepartment(ctx, id)
+	})
+	return err
+}
+
+func (s *organizationService) GetTeams(ctx context.Context, departmentID int64) ([]*models.Team, error) {
+	return s.store.GetTeams(ctx, departmentID)
+}
+
+func (s *organizationService) CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
+	if team == nil {
+		return nil, xerrors.New("team is empty")
+	}
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.requireDepartment(ctx, team.DepartmentID); err != nil {
+			return nil, err
+		}
+		return nil, s.store.CreateTeam(ctx, team)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *organizationService) UpdateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
+	if team == nil {
+		return nil, xerrors.New("team is empty")
+	}
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.requireTeam(ctx, team.ID); err != nil {
+			return nil, err
+		}
+		if err := s.requireDepartment(ctx, team.DepartmentID); err != nil {
+			return nil, err
+		}
+		return nil, s.store.UpdateTeam(ctx, team)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// DeleteTeam removes a team nobody is or will be assigned to. Past assignments keep its id.
+func (s *organizationService) DeleteTeam(ctx context.Context, id int64) error {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.requireTeam(ctx, id); err != nil {
+			return nil, err
+		}
+		assignments, err := s.store.GetAssignments(ctx, &models.GetAssignmentsParameters{TeamID: id})
+		if err != nil {
+			return nil, err
+		}
+		now := flextime.Now()
+		for _, a := range assignments {
+			if a.FinishedAt.IsZero() || a.FinishedAt.After(now) {
+				return nil, models.ErrTeamNotEmpty
+			}
+		}
+		return nil, s.store.DeleteTeam(ctx, id)
+	})
+	return err
+}
+
+// GetTeamMembers returns the assignments of the members of the team at the time.
+func (s *organizationService) GetTeamMembers(ctx context.Context, teamID int64, at time.Time) (models.Assignments, error) {
+	if err := s.requireTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+	assignments, err := s.store.GetAssignments(ctx, &models.GetAssignmentsParameters{TeamID: teamID})
+	if err != nil {
+		return nil, err
+	}
+	return assignments.Effective(at), nil
+}
+
+func (s *organizationService) GetAssignments(ctx context.Context, userID string) (models.Assignments, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	return s.store.GetAssignments(ctx, &models.GetAssignmentsParameters{UserID: userID})
+}
+
+// CreateAssignment moves the user to a team or under a manager from the start of the assignment.
+// The current assignment finishes when the new one starts, so that the past keeps who reported to whom.
+func (s *organizationService) CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error) {
+	if assignment == nil {
+		return nil, xerrors.New("assignment is empty")
+	}
+	if err := assignment.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := requireUser(ctx, s.store, assignment.UserID); err != nil {
+			return nil, err
+		}
+		if assignment.TeamID != 0 {
+			if err := s.requireTeam(ctx, assignment.TeamID); err != nil {
+				return nil, err
+			}
+		}
+		if assignment.ManagerID != "" {
+			if err := requireUser(ctx, s.store, assignment.ManagerID); err != nil {
+				return nil, err
+			}
+			managers, err := managerChain(ctx, s.store, assignment.ManagerID, assignment.StartedAt)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range managers {
+				if id == assignment.UserID {
+					return nil, models.ErrReportingCycle
+				}
+			}
+		}
+
+		assignments, err := s.store.GetAssignments(ctx, &models.GetAssignmentsParameters{UserID: assignment.UserID})
+		if err != nil {
+			return nil, err
+		}
+		if len(assignments) != 0 {
+			latest := assignments[len(assignments)-1]
+			if !assignment.StartedAt.After(latest.StartedAt) {
+				return nil, models.ErrAssignmentOverlapped
+			}
+			if latest.FinishedAt.IsZero() || latest.FinishedAt.After(assignment.StartedAt) {
+				latest.FinishedAt = assignment.StartedAt
+				if err = s.store.UpdateAssignment(ctx, latest); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, s.store.CreateAssignment(ctx, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// GetReports returns the assignments of the users reporting to the manager at the time,
+// with indirect, down the whole reporting line.
+func (s *organizationService) GetReports(ctx context.Context, managerID string, at time.Time, indirect bool) (models.Assignments, error) {
+	if managerID == "" {
+		return nil, xerrors.New("manager id is empty")
+	}
+	return reportsOf(ctx, s.store, managerID, at, indirect)
+}
+
+// GetManagers returns the managers the user reports to at the time, up the reporting line from its own manager.
+func (s *organizationService) GetManagers(ctx context.Context, userID string, at time.Time) ([]*models.User, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	ids, err := managerChain(ctx, s.store, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	managers := make([]*models.User, 0, len(ids))
+	for _, id := range ids {
+		user, err := s.store.GetUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == "" {
+			user.ID = id
+		}
+		managers = append(managers, user)
+	}
+	return managers, nil
+}
+
+// requireDepartment returns models.ErrDepartmentNotFound unless the department exists. Zero is the top of the tree.
+func (s *organizationService) requireDepartment(ctx context.Context, id int64) error {
+	if id == 0 {
+		return nil
+	}
+	department, err := s.store.GetDepartment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if department == nil {
+		return models.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+func (s *organizationService) requireTeam(ctx context.Context, id int64) error {
+	team, err := s.store.GetTeam(ctx, id)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return models.ErrTeamNotFound
+	}
+	return nil
+}
+
+// managerChain returns the ids of the managers above the user at the time, its own manager first.
+func managerChain(ctx context.Context, store sqlstore.SQLStore, userID string, at time.Time) ([]string, error) {
+	chain := make([]string, 0)
+	seen := map[string]bool{userID: true}
+	for id := userID; ; {
+		assignments, err := store.GetAssignments(ctx, &models.GetAssignmentsParameters{UserID: id})
+		if err != nil {
+			return nil, err
+		}
+		assignment := assignments.At(at)
+		if assignment == nil || assignment.ManagerID == "" || seen[assignment.ManagerID] {
+			return chain, nil
+		}
+		seen[assignment.ManagerID] = true
+		chain = append(chain, assignment.ManagerID)
+		id = assignment.ManagerID
+	}
+}
+
+// reportsOf returns the assignments of the users reporting to the manager at the time,
+// and with indirect, of the users reporting to them in turn.
+func reportsOf(ctx context.Context, store sqlstore.SQLStore, managerID string, at time.Time, indirect bool) (models.Assignments, error) {
+	reports := make(models.Assignments, 0)
+	seen := map[string]bool{managerID: true}
+	queue := []string{managerID}
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+		assignments, err := store.GetAssignments(ctx, &models.GetAssignmentsParameters{ManagerID: id})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assignments.Effective(at) {
+			if seen[a.UserID] {
+				continue
+			}
+			seen[a.UserID] = true
+			reports = append(reports, a)
+			if indirect {
+				queue = append(queue, a.UserID)
+			}
+		}
+	}
+	return reports, nil
+}
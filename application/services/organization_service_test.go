@@ -0,0 +1,103 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_organizationService_CreateAssignment(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewOrganizationService(store)
+
+	headID := uuid.NewV4().String()
+	managerID := uuid.NewV4().String()
+	userID := uuid.NewV4().String()
+	for _, id := range []string{headID, managerID, userID} {
+		if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: id, Name: "insert user"}); err != nil {
+			t.Errorf("CreateUser() %s", err)
+		}
+	}
+	department, err := s.CreateDepartment(sqlstore.NewTestContext(), &models.Department{Name: "開発部"})
+	if err != nil {
+		t.Errorf("CreateDepartment() failed %s", err)
+		return
+	}
+	team, err := s.CreateTeam(sqlstore.NewTestContext(), &models.Team{DepartmentID: department.ID, Name: "勤怠チーム"})
+	if err != nil {
+		t.Errorf("CreateTeam() failed %s", err)
+		return
+	}
+
+	april := time.Date(2020, 4, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	june := time.Date(2020, 6, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	tests := []struct {
+		name       string
+		assignment *models.Assignment
+		wantErr    error
+	}{
+		{
+			name:       "Should put manager under head",
+			assignment: &models.Assignment{UserID: managerID, TeamID: team.ID, ManagerID: headID, StartedAt: april},
+		},
+		{
+			name:       "Should put user under manager",
+			assignment: &models.Assignment{UserID: userID, TeamID: team.ID, ManagerID: managerID, StartedAt: april},
+		},
+		{
+			name:       "Should not put head under its report",
+			assignment: &models.Assignment{UserID: headID, ManagerID: userID, StartedAt: april},
+			wantErr:    models.ErrReportingCycle,
+		},
+		{
+			name:       "Should not assign to unknown team",
+			assignment: &models.Assignment{UserID: userID, TeamID: team.ID + 1, StartedAt: june},
+			wantErr:    models.ErrTeamNotFound,
+		},
+		{
+			name:       "Should move user under head",
+			assignment: &models.Assignment{UserID: userID, TeamID: team.ID, ManagerID: headID, StartedAt: june},
+		},
+		{
+			name:       "Should not assign before the current assignment",
+			assignment: &models.Assignment{UserID: userID, ManagerID: managerID, StartedAt: april},
+			wantErr:    models.ErrAssignmentOverlapped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.CreateAssignment(sqlstore.NewTestContext(), tt.assignment); !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("CreateAssignment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	may := time.Date(2020, 5, 15, 0, 0, 0, 0, timezone.JSTLocation())
+	reports, err := s.GetReports(sqlstore.NewTestContext(), headID, may, true)
+	if err != nil {
+		t.Errorf("GetReports() failed %s", err)
+		return
+	}
+	if len(reports) != 2 {
+		t.Errorf("GetReports() got %d reports in May, want %d", len(reports), 2)
+	}
+	if reports, err = s.GetReports(sqlstore.NewTestContext(), managerID, june, false); err != nil || len(reports) != 0 {
+		t.Errorf("GetReports() got %v reports in June, want none: %v", reports, err)
+	}
+	managers, err := s.GetManagers(sqlstore.NewTestContext(), userID, may)
+	if err != nil {
+		t.Errorf("GetManagers() failed %s", err)
+		return
+	}
+	if len(managers) != 2 || managers[0].ID != managerID || managers[1].ID != headID {
+		t.Errorf("GetManagers() got %v, want manager then head", managers)
+	}
+	if err = s.DeleteTeam(sqlstore.NewTestContext(), team.ID); !xerrors.Is(err, models.ErrTeamNotEmpty) {
+		t.Errorf("DeleteTeam() error = %v, want %v", err, models.ErrTeamNotEmpty)
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+// OvertimeAlertHook is called with each alert newly raised for a user.
+type OvertimeAlertHook func(ctx context.Context, alert *models.OvertimeAlert)
+
+type OvertimeLimitService interface {
+	GetOvertimeRisks(ctx context.Context, month int) ([]*models.OvertimeRisk, error)
+	CheckOvertimeLimits(ctx context.Context, userID string) ([]*models.OvertimeAlert, error)
+}
+
+type overtimeLimitService struct {
+	store  sqlstore.SQLStore
+	limits models.OvertimeLimits
+	hooks  []OvertimeAlertHook
+}
+
+type OvertimeLimitServiceOption func(s *overtimeLimitService)
+
+// WithOvertimeLimits replaces the default limits of the overtime agreement.
+func WithOvertimeLimits(limits models.OvertimeLimits) OvertimeLimitServiceOption {
+	return func(s *overtimeLimitService) {
+		s.limits = limits
+	}
+}
+
+// WithOvertimeAlertHook adds a hook called when a limit is approached or exceeded.
+// Alerts are logged even without a hook.
+func WithOvertimeAlertHook(hook OvertimeAlertHook) OvertimeLimitServiceOption {
+	return func(s *overtimeLimitService) {
+		s.hooks = append(s.hooks, hook)
+	}
+}
+
+func NewOvertimeLimitService(ss sqlstore.SQLStore, opts ...OvertimeLimitServiceOption) OvertimeLimitService {
+	s := &overtimeLimitService{
+		store:  ss,
+		limits: models.DefaultOvertimeLimits(),
+		hooks:  []OvertimeAlertHook{logOvertimeAlert},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetOvertimeRisks returns the users whose overtime approaches or exceeds a limit in the month.
+func (s *overtimeLimitService) GetOvertimeRisks(ctx context.Context, month int) ([]*models.OvertimeRisk, error) {
+	start, _, err := timeutil.GetMonthRange(month)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.store.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	risks := make([]*models.OvertimeRisk, 0)
+	for _, user := range users {
+		alerts, err := s.checkLimits(ctx, user.ID, start)
+		if err != nil {
+			return nil, err
+		}
+		if len(alerts) != 0 {
+			risks = append(risks, &models.OvertimeRisk{User: user, Alerts: alerts})
+		}
+	}
+	return risks, nil
+}
+
+// CheckOvertimeLimits evaluates the limits of the user for the current month and records the alerts.
+// The hooks are called only for alerts that were not raised before for the same period at the same or a higher level,
+// so that each threshold fires once when it is crossed.
+func (s *overtimeLimitService) CheckOvertimeLimits(ctx context.Context, userID string) ([]*models.OvertimeAlert, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	now := flextime.Now().In(timezone.JSTLocation())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	alerts, err := s.checkLimits(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return alerts, nil
+	}
+
+	since := alerts[0].Month
+	for _, a := range alerts {
+		if a.Month < since {
+			since = a.Month
+		}
+	}
+	v, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		raised, err := s.store.GetOvertimeAlerts(ctx, userID, since)
+		if err != nil {
+			return nil, err
+		}
+		crossed := make([]*models.OvertimeAlert, 0)
+		for _, a := range alerts {
+			if a.IsCoveredBy(raised) {
+				continue
+			}
+			if err = s.store.CreateOvertimeAlert(ctx, a); err != nil {
+				return nil, err
+			}
+			crossed = append(crossed, a)
+		}
+		return crossed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	crossed := v.([]*models.OvertimeAlert)
+	for _, a := range crossed {
+		for _, hook := range s.hooks {
+			hook(ctx, a)
+		}
+	}
+	return crossed, nil
+}
+
+// checkLimits evaluates the limits of the user at the month, which starts at its first day.
+// The current month is also projected to its end to warn about users trending toward a breach.
+// The limits apply to the time actually worked, so the raw punches are counted rather than the rounded ones.
+func (s *overtimeLimitService) checkLimits(ctx context.Context, userID string, month time.Time) ([]*models.OvertimeAlert, error) {
+	from := s.limits.CheckedFrom(month)
+	end := month.AddDate(0, 1, 0).Add(-time.Second)
+
+	cal, err := loadCalendar(ctx, s.store, from, end)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := loadWorkWeekRule(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+	attendances, err := s.store.GetAttendancesBetween(ctx, userID, models.WeekStartOf(cal, rule, from), end)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]models.MonthlyOvertime, 0)
+	for m := from; !m.After(month); m = m.AddDate(0, 1, 0) {
+		days := models.BreakdownActualWork(cal, rule, attendances, m, m.AddDate(0, 1, 0).Add(-time.Second))
+		months = append(months, models.NewMonthlyOvertime(m, days))
+	}
+
+	current := months[len(months)-1]
+	projected := current
+	if now := flextime.Now(); !now.Before(month) && now.Before(end) {
+		projected = current.Project(cal.CountBusinessDays(month, now), cal.CountBusinessDays(month, end))
+	}
+	return s.limits.Check(userID, months, projected), nil
+}
+
+func logOvertimeAlert(ctx context.Context, alert *models.OvertimeAlert) {
+	logger.NewWarn(logrus.Fields{
+		"user_id": alert.UserID,
+		"month":   alert.Month,
+		"limit":   alert.Kind().String(),
+		"level":   alert.Level().String(),
+		"value":   alert.Value,
+	}, "overtime limit alert")
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func Test_overtimeLimitService_CheckOvertimeLimits(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	defer flextime.Restore()
+
+	raised := make([]*models.OvertimeAlert, 0)
+	limits := NewOvertimeLimitService(store, WithOvertimeAlertHook(func(ctx context.Context, alert *models.OvertimeAlert) {
+		raised = append(raised, alert)
+	}))
+	s := NewAttendanceService(store, WithOvertimeLimitService(limits))
+
+	userID := uuid.NewV4().String()
+	if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{
+		ID:   userID,
+		Name: "insert user",
+	}); err != nil {
+		t.Errorf("CreateUser() %s", err)
+	}
+
+	// Six hours of overtime a day trends far over the monthly limit from the first day.
+	work := func(day int) {
+		flextime.Fix(time.Date(2020, 6, day, 8, 0, 0, 0, timezone.JSTLocation()))
+		if _, err := s.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, userID); err != nil {
+			t.Errorf("ClockIn() failed %s", err)
+		}
+		flextime.Fix(time.Date(2020, 6, day, 22, 0, 0, 0, timezone.JSTLocation()))
+		if _, err := s.ClockOut(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "out"}, userID); err != nil {
+			t.Errorf("ClockOut() failed %s", err)
+		}
+	}
+
+	work(1)
+	if len(raised) == 0 {
+		t.Errorf("CheckOvertimeLimits() should raise an alert when trending over the limit")
+		return
+	}
+	for _, a := range raised {
+		if a.Level() != models.OvertimeAlertLevelWarning {
+			t.Errorf("CheckOvertimeLimits() level = %s, want %s", a.Level(), models.OvertimeAlertLevelWarning)
+		}
+	}
+
+	count := len(raised)
+	work(2)
+	if len(raised) != count {
+		t.Errorf("CheckOvertimeLimits() raised %d alerts again, want none", len(raised)-count)
+	}
+
+	risks, err := limits.GetOvertimeRisks(sqlstore.NewTestContext(), 202006)
+	if err != nil {
+		t.Errorf("GetOvertimeRisks() error = %v", err)
+		return
+	}
+	// Other tests share the database, so only the risks of this user are checked.
+	found := 0
+	for _, r := range risks {
+		if r.User.ID == userID {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("GetOvertimeRisks() = %v, want the user once", risks)
+	}
+}
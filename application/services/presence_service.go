@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+	"sync"
+	"time"
+)
+
+// presenceBufferSize is how many events a board can fall behind before it misses some.
+const presenceBufferSize = 16
+
+type PresenceService interface {
+	GetTeamAttendances(ctx context.Context, principal *models.Principal, teamID int64, at time.Time) ([]*models.MemberPresence, error)
+	SubscribeTeam(ctx context.Context, principal *models.Principal, teamID int64) ([]*models.MemberPresence, <-chan *models.PresenceEvent, func(), error)
+}
+
+type presenceService struct {
+	store          sqlstore.SQLStore
+	broker         PresenceBroker
+	maxShiftLength time.Duration
+}
+
+type PresenceServiceOption func(s *presenceService)
+
+// WithPresenceMaxShiftLength sets how long after clock-in a shift started on a previous day is still shown today.
+// It should be the max shift length of the attendance service.
+func WithPresenceMaxShiftLength(d time.Duration) PresenceServiceOption {
+	return func(s *presenceService) {
+		s.maxShiftLength = d
+	}
+}
+
+func NewPresenceService(ss sqlstore.SQLStore, broker PresenceBroker, opts ...PresenceServiceOption) PresenceService {
+	s := &presenceService{
+		store:          ss,
+		broker:         broker,
+		maxShiftLength: DefaultMaxShiftLength,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetTeamAttendances returns the punches and the status of the members of the team on the day of at.
+// Only the members whose attendances the principal can read are returned, e.g. the reports of a manager.
+func (s *presenceService) GetTeamAttendances(ctx context.Context, principal *models.Principal, teamID int64, at time.Time) ([]*models.MemberPresence, error) {
+	userIDs, err := s.readableMembers(ctx, principal, teamID, at)
+	if err != nil {
+		return nil, err
+	}
+	return s.presences(ctx, userIDs, at)
+}
+
+// SubscribeTeam returns the members of the team as they are now, and the events of their punches from now on
+// until the returned func is called.
+func (s *presenceService) SubscribeTeam(ctx context.Context, principal *models.Principal, teamID int64) ([]*models.MemberPresence, <-chan *models.PresenceEvent, func(), error) {
+	if s.broker == nil {
+		return nil, nil, nil, xerrors.New("presence broker is not set")
+	}
+	tenantID, err := sqlstore.TenantID(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := flextime.Now()
+	userIDs, err := s.readableMembers(ctx, principal, teamID, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Subscribed before the members are loaded, so that no punch is missed in between.
+	events, cancel := s.broker.Subscribe(tenantID, userIDs)
+	presences, err := s.presences(ctx, userIDs, now)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	return presences, events, cancel, nil
+}
+
+// readableMembers returns the ids of the members of the team at the time whose attendances the principal can read.
+func (s *presenceService) readableMembers(ctx context.Context, principal *models.Principal, teamID int64, at time.Time) ([]string, error) {
+	team, err := s.store.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, models.ErrTeamNotFound
+	}
+	assignments, err := s.store.GetAssignments(ctx, &models.GetAssignmentsParameters{TeamID: teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0)
+	for _, a := range assignments.Effective(at) {
+		err := authorize(ctx, s.store, principal, a.UserID, models.AccessRead)
+		if xerrors.Is(err, models.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, a.UserID)
+	}
+	return userIDs, nil
+}
+
+// presences returns the attendances of the users on the JST day of at.
+// On the current day, a shift started on the previous day and not clocked out yet is the attendance shown.
+func (s *presenceService) presences(ctx context.Context, userIDs []string, at time.Time) ([]*models.MemberPresence, error) {
+	presences := make([]*models.MemberPresence, 0, len(userIDs))
+	if len(userIDs) == 0 {
+		return presences, nil
+	}
+
+	d := at.In(timezone.JSTLocation())
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, timezone.JSTLocation())
+	now := flextime.Now()
+	today := now.In(timezone.JSTLocation())
+	isToday := d.Year() == today.Year() && d.YearDay() == today.YearDay()
+
+	leaves, err := s.store.GetLeaveRequests(ctx, &models.GetLeaveRequestsParameters{
+		UserIDs: userIDs,
+		Status:  models.LeaveStatusApproved,
+		From:    day,
+		To:      day,
+	})
+	if err != nil {
+		return nil, err
+	}
+	leavesOf := make(map[string][]*models.LeaveRequest)
+	for _, l := range leaves {
+		leavesOf[l.UserID] = append(leavesOf[l.UserID], l)
+	}
+
+	for _, userID := range userIDs {
+		user, err := s.store.GetUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == "" {
+			user.ID = userID
+		}
+
+		var attendance *models.Attendance
+		if isToday {
+			attendance, err = s.store.GetOpenAttendance(ctx, userID, now.Add(-s.maxShiftLength))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if attendance == nil {
+			attendance, err = s.store.GetAttendanceByDate(ctx, userID, day)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		presences = append(presences, &models.MemberPresence{
+			User:       user,
+			Status:     models.NewPresenceStatus(attendance, len(leavesOf[userID]) != 0),
+			Attendance: attendance,
+			Leaves:     leavesOf[userID],
+		})
+	}
+	return presences, nil
+}
+
+// PresenceBroker passes the punches to the live boards subscribed to the users.
+// It only reaches the boards served by the same process as the punch.
+type PresenceBroker interface {
+	Publish(event *models.PresenceEvent)
+	Subscribe(tenantID int64, userIDs []string) (<-chan *models.PresenceEvent, func())
+}
+
+type presenceSubscriber struct {
+	tenantID int64
+	userIDs  map[string]bool
+	events   chan *models.PresenceEvent
+}
+
+type presenceBroker struct {
+	mu          sync.Mutex
+	subscribers map[*presenceSubscriber]struct{}
+}
+
+func NewPresenceBroker() PresenceBroker {
+	return &presenceBroker{
+		subscribers: make(map[*presenceSubscriber]struct{}),
+	}
+}
+
+// Publish passes the event to the subscribers of the user in its tenant.
+// A subscriber too slow to keep up misses the event rather than holding up the punch.
+func (b *presenceBroker) Publish(event *models.PresenceEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if sub.tenantID != event.TenantID || !sub.userIDs[event.UserID] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the events of the users of the tenant until the returned func is called, which closes the channel.
+func (b *presenceBroker) Subscribe(tenantID int64, userIDs []string) (<-chan *models.PresenceEvent, func()) {
+	sub := &presenceSubscriber{
+		tenantID: tenantID,
+		userIDs:  make(map[string]bool),
+		events:   make(chan *models.PresenceEvent, presenceBufferSize),
+	}
+	for _, id := range userIDs {
+		sub.userIDs[id] = true
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, sub)
+			close(sub.events)
+		})
+	}
+	return sub.events, cancel
+}
+
+// publishPresence pushes the status of the user after the punch to the live boards. It does nothing without a broker.
+func publishPresence(ctx context.Context, broker PresenceBroker, attendance *models.Attendance, t *models.AttendanceTime) {
+	if broker == nil {
+		return
+	}
+	tenantID, err := sqlstore.TenantID(ctx)
+	if err != nil {
+		logger.NewWarn(logrus.Fields{"user_id": attendance.UserID}, err.Error())
+		return
+	}
+	broker.Publish(&models.PresenceEvent{
+		TenantID: tenantID,
+		UserID:   attendance.UserID,
+		Status:   models.NewPresenceStatus(attendance, false),
+		Kind:     models.AttendanceKind(t.AttendanceKindID),
+		PushedAt: t.PushedAt,
+	})
+}
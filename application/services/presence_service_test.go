@@ -0,0 +1,204 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func Test_presenceService_GetTeamAttendances(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	defer flextime.Restore()
+	broker := NewPresenceBroker()
+	s := NewPresenceService(store, broker)
+	organizations := NewOrganizationService(store)
+	attendances := NewAttendanceService(store, WithPresenceBroker(broker))
+
+	managerID := uuid.NewV4().String()
+	workerID := uuid.NewV4().String()
+	leaverID := uuid.NewV4().String()
+	otherID := uuid.NewV4().String()
+	for _, id := range []string{managerID, workerID, leaverID, otherID} {
+		if err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: id, Name: "insert user"}); err != nil {
+			t.Errorf("CreateUser() %s", err)
+		}
+	}
+	department, err := organizations.CreateDepartment(sqlstore.NewTestContext(), &models.Department{Name: "開発部"})
+	if err != nil {
+		t.Errorf("CreateDepartment() failed %s", err)
+		return
+	}
+	team, err := organizations.CreateTeam(sqlstore.NewTestContext(), &models.Team{DepartmentID: department.ID, Name: "勤怠チーム"})
+	if err != nil {
+		t.Errorf("CreateTeam() failed %s", err)
+		return
+	}
+	april := time.Date(2020, 4, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	for _, a := range []*models.Assignment{
+		{UserID: workerID, TeamID: team.ID, ManagerID: managerID, StartedAt: april},
+		{UserID: leaverID, TeamID: team.ID, ManagerID: managerID, StartedAt: april},
+		{UserID: otherID, TeamID: team.ID, StartedAt: april},
+	} {
+		if _, err := organizations.CreateAssignment(sqlstore.NewTestContext(), a); err != nil {
+			t.Errorf("CreateAssignment() failed %s", err)
+		}
+	}
+	june := time.Date(2020, 6, 1, 0, 0, 0, 0, timezone.JSTLocation())
+	if err := store.CreateLeaveRequest(sqlstore.NewTestContext(), &models.LeaveRequest{
+		UserID:        leaverID,
+		LeaveKindID:   uint8(models.LeaveKindPaid),
+		LeaveUnitID:   uint8(models.LeaveUnitDay),
+		StartDate:     june,
+		EndDate:       june,
+		Days:          1,
+		LeaveStatusID: uint8(models.LeaveStatusApproved),
+	}); err != nil {
+		t.Errorf("CreateLeaveRequest() failed %s", err)
+	}
+
+	manager := models.NewPrincipal(managerID, []*models.UserRole{{UserID: managerID, RoleID: uint8(models.RoleManager)}})
+	flextime.Fix(time.Date(2020, 6, 1, 8, 0, 0, 0, timezone.JSTLocation()))
+	_, events, cancel, err := s.SubscribeTeam(sqlstore.NewTestContext(), manager, team.ID)
+	if err != nil {
+		t.Errorf("SubscribeTeam() error = %v", err)
+		return
+	}
+	defer cancel()
+
+	flextime.Fix(time.Date(2020, 6, 1, 9, 0, 0, 0, timezone.JSTLocation()))
+	if _, err := attendances.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, workerID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+	if _, err := attendances.ClockIn(sqlstore.NewTestContext(), &models.AttendanceTime{Remark: "in"}, otherID); err != nil {
+		t.Errorf("ClockIn() failed %s", err)
+	}
+
+	t.Run("Should push the punch of a report", func(t *testing.T) {
+		select {
+		case e := <-events:
+			if e.UserID != workerID || e.Status != models.PresenceStatusWorking {
+				t.Errorf("SubscribeTeam() event = %v, want %s working", e, workerID)
+			}
+		default:
+			t.Errorf("SubscribeTeam() got no event")
+		}
+		select {
+		case e := <-events:
+			t.Errorf("SubscribeTeam() event = %v, want none of a user not reporting to the manager", e)
+		default:
+		}
+	})
+
+	tests := []struct {
+		name      string
+		principal *models.Principal
+		at        time.Time
+		want      map[string]models.PresenceStatus
+	}{
+		{
+			name:      "Should return the reports of the manager",
+			principal: manager,
+			at:        flextime.Now(),
+			want: map[string]models.PresenceStatus{
+				workerID: models.PresenceStatusWorking,
+				leaverID: models.PresenceStatusOnLeave,
+			},
+		},
+		{
+			name:      "Should return the reports of the manager on another day",
+			principal: manager,
+			at:        time.Date(2020, 6, 2, 0, 0, 0, 0, timezone.JSTLocation()),
+			want: map[string]models.PresenceStatus{
+				workerID: models.PresenceStatusNotStarted,
+				leaverID: models.PresenceStatusNotStarted,
+			},
+		},
+		{
+			name:      "Should return only itself to a member",
+			principal: models.NewPrincipal(otherID, nil),
+			at:        flextime.Now(),
+			want: map[string]models.PresenceStatus{
+				otherID: models.PresenceStatusWorking,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetTeamAttendances(sqlstore.NewTestContext(), tt.principal, team.ID, tt.at)
+			if err != nil {
+				t.Errorf("GetTeamAttendances() error = %v", err)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("GetTeamAttendances() got %d members, want %d", len(got), len(tt.want))
+			}
+			for _, p := range got {
+				if status, ok := tt.want[p.User.ID]; !ok || p.Status != status {
+					t.Errorf("GetTeamAttendances() %s = %v, want %v", p.User.ID, p.Status, status)
+				}
+			}
+		})
+	}
+}
+
+func Test_presenceBroker(t *testing.T) {
+	broker := NewPresenceBroker()
+	events, cancel := broker.Subscribe(1, []string{"member"})
+
+	tests := []struct {
+		name    string
+		event   *models.PresenceEvent
+		wantHas bool
+	}{
+		{
+			name:    "Should pass the event of a member",
+			event:   &models.PresenceEvent{TenantID: 1, UserID: "member", Status: models.PresenceStatusWorking},
+			wantHas: true,
+		},
+		{
+			name:  "Should not pass the event of another user",
+			event: &models.PresenceEvent{TenantID: 1, UserID: "other", Status: models.PresenceStatusWorking},
+		},
+		{
+			name:  "Should not pass the event of another tenant",
+			event: &models.PresenceEvent{TenantID: 2, UserID: "member", Status: models.PresenceStatusWorking},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker.Publish(tt.event)
+			select {
+			case e := <-events:
+				if !tt.wantHas || e != tt.event {
+					t.Errorf("Subscribe() event = %v, want %v", e, tt.wantHas)
+				}
+			default:
+				if tt.wantHas {
+					t.Errorf("Subscribe() got no event")
+				}
+			}
+		})
+	}
+
+	t.Run("Should drop the events a subscriber can not keep up with", func(t *testing.T) {
+		for i := 0; i < presenceBufferSize+1; i++ {
+			broker.Publish(&models.PresenceEvent{TenantID: 1, UserID: "member"})
+		}
+		if len(events) != presenceBufferSize {
+			t.Errorf("Subscribe() buffered %d events, want %d", len(events), presenceBufferSize)
+		}
+	})
+
+	t.Run("Should close the events on cancel", func(t *testing.T) {
+		cancel()
+		cancel()
+		broker.Publish(&models.PresenceEvent{TenantID: 1, UserID: "member"})
+		for range events {
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/Songmu/flextime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type RoleService interface {
+	GetPrincipal(ctx context.Context, userID string) (*models.Principal, error)
+	Authorize(ctx context.Context, principal *models.Principal, userID string, access models.Access) error
+	UpdateUserRoles(ctx context.Context, userID string, roles []models.Role) (*models.Principal, error)
+}
+
+type roleService struct {
+	store         sqlstore.SQLStore
+	initialAdmins map[string]bool
+}
+
+type RoleServiceOption func(s *roleService)
+
+// WithInitialAdmins makes the users system admins of a tenant without any, the first time they are authorized.
+// Roles are given only by a system admin, so this is how the first one is made.
+func WithInitialAdmins(userIDs ...string) RoleServiceOption {
+	return func(s *roleService) {
+		for _, id := range userIDs {
+			s.initialAdmins[id] = true
+		}
+	}
+}
+
+func NewRoleService(ss sqlstore.SQLStore, opts ...RoleServiceOption) RoleService {
+	s := &roleService{
+		store:         ss,
+		initialAdmins: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *roleService) GetPrincipal(ctx context.Context, userID string) (*models.Principal, error) {
+	principal, err := loadPrincipal(ctx, s.store, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.initialAdmins[userID] || principal.HasRole(models.RoleSystemAdmin) {
+		return principal, nil
+	}
+	return s.bootstrapAdmin(ctx, userID)
+}
+
+// bootstrapAdmin gives the system admin role to the initial admin unless the tenant already has a system admin.
+func (s *roleService) bootstrapAdmin(ctx context.Context, userID string) (*models.Principal, error) {
+	principal, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		count, err := s.store.GetUserRolesCount(ctx, models.RoleSystemAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			role := &models.UserRole{UserID: userID, RoleID: uint8(models.RoleSystemAdmin)}
+			if err := s.store.CreateUserRole(ctx, role); err != nil {
+				return nil, err
+			}
+			logger.NewWarn(logrus.Fields{"user_id": userID}, "initial admin is made a system admin")
+		}
+		return loadPrincipal(ctx, s.store, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return principal.(*models.Principal), nil
+}
+
+// Authorize returns models.ErrForbidden unless the principal can access the data of the user.
+func (s *roleService) Authorize(ctx context.Context, principal *models.Principal, userID string, access models.Access) error {
+	return authorize(ctx, s.store, principal, userID, access)
+}
+
+// UpdateUserRoles replaces the roles of the user. The employee role is implicit and not stored.
+func (s *roleService) UpdateUserRoles(ctx context.Context, userID string, roles []models.Role) (*models.Principal, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	userRoles := make([]*models.UserRole, 0)
+	seen := make(map[models.Role]bool)
+	for _, r := range roles {
+		if !r.IsValid() {
+			return nil, xerrors.Errorf("role %d is invalid", r)
+		}
+		if r == models.RoleEmployee || seen[r] {
+			continue
+		}
+		seen[r] = true
+		userRoles = append(userRoles, &models.UserRole{UserID: userID, RoleID: uint8(r)})
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := requireUser(ctx, s.store, userID); err != nil {
+			return nil, err
+		}
+		return nil, s.store.UpdateUserRoles(ctx, userID, userRoles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPrincipal(userID, userRoles), nil
+}
+
+// loadPrincipal returns the principal of the user with the roles given to it.
+func loadPrincipal(ctx context.Context, store sqlstore.SQLStore, userID string) (*models.Principal, error) {
+	if userID == "" {
+		return nil, xerrors.New("user id is empty")
+	}
+	roles, err := store.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPrincipal(userID, roles), nil
+}
+
+// authorize returns models.ErrForbidden unless the principal can access the data of the user.
+// The user is a report of the principal when the principal is above it in the reporting line today.
+func authorize(ctx context.Context, store sqlstore.SQLStore, principal *models.Principal, userID string, access models.Access) error {
+	if principal == nil {
+		return models.ErrForbidden
+	}
+	if principal.CanAccess(userID, false, access) {
+		return nil
+	}
+	managers, err := managerChain(ctx, store, userID, flextime.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range managers {
+		if id == principal.UserID && principal.CanAccess(userID, true, access) {
+			return nil
+		}
+	}
+	return models.ErrForbidden
+}
+
+// reviewableUserIDs returns the users whose requests the reviewer can review, or nil when it can review everyone's.
+func reviewableUserIDs(ctx context.Context, store sqlstore.SQLStore, reviewerID string) ([]string, error) {
+	reviewer, err := loadPrincipal(ctx, store, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer.Can(models.PermissionWriteAll) {
+		return nil, nil
+	}
+	if !reviewer.Can(models.PermissionReviewReports) {
+		return []string{}, nil
+	}
+	reports, err := reportsOf(ctx, store, reviewerID, flextime.Now(), true)
+	if err != nil {
+		return nil, err
+	}
+	return reports.UserIDs(), nil
+}
+
+func requireUser(ctx context.Context, store sqlstore.SQLStore, userID string) error {
+	user, err := store.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.ID == "" {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+)
+
+func Test_roleService_GetPrincipal(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	// A tenant of its own, so that the system admins of other tests do not count.
+	tenant := &models.Tenant{Name: "bootstrap", AuthTenantID: uuid.NewV4().String()}
+	if err := store.CreateTenant(context.Background(), tenant); err != nil {
+		t.Errorf("CreateTenant() failed %s", err)
+		return
+	}
+	ctx := sqlstore.WithTenant(context.Background(), tenant.ID)
+
+	firstID := uuid.NewV4().String()
+	secondID := uuid.NewV4().String()
+	userID := uuid.NewV4().String()
+	s := NewRoleService(store, WithInitialAdmins(firstID, secondID))
+
+	tests := []struct {
+		name      string
+		userID    string
+		wantAdmin bool
+	}{
+		{name: "Should not make a user not listed a system admin", userID: userID, wantAdmin: false},
+		{name: "Should make the first initial admin a system admin", userID: firstID, wantAdmin: true},
+		{name: "Should keep the system admin", userID: firstID, wantAdmin: true},
+		{name: "Should not make another initial admin once the tenant has one", userID: secondID, wantAdmin: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetPrincipal(ctx, tt.userID)
+			if err != nil {
+				t.Errorf("GetPrincipal() error = %v", err)
+				return
+			}
+			if got.HasRole(models.RoleSystemAdmin) != tt.wantAdmin {
+				t.Errorf("GetPrincipal() roles = %v, wantAdmin %v", got.Roles, tt.wantAdmin)
+			}
+		})
+	}
+}
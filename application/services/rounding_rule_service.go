@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+)
+
+type RoundingRuleService interface {
+	GetRoundingRules(ctx context.Context) (models.RoundingRules, error)
+	UpdateRoundingRules(ctx context.Context, rules models.RoundingRules) (models.RoundingRules, error)
+}
+
+type roundingRuleService struct {
+	store sqlstore.SQLStore
+}
+
+func NewRoundingRuleService(ss sqlstore.SQLStore) RoundingRuleService {
+	return &roundingRuleService{
+		store: ss,
+	}
+}
+
+func (s *roundingRuleService) GetRoundingRules(ctx context.Context) (models.RoundingRules, error) {
+	return s.store.GetRoundingRules(ctx)
+}
+
+// UpdateRoundingRules replaces all the rounding rules. Punches already recorded keep their rounded time.
+func (s *roundingRuleService) UpdateRoundingRules(ctx context.Context, rules models.RoundingRules) (models.RoundingRules, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := s.store.DeleteRoundingRules(ctx); err != nil {
+			return nil, err
+		}
+		for _, r := range rules {
+			if err := s.store.CreateRoundingRule(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// roundAttendanceTime sets the rounded time of the punch by the rule of its kind, in JST.
+func roundAttendanceTime(ctx context.Context, store sqlstore.SQLStore, t *models.AttendanceTime) error {
+	rules, err := store.GetRoundingRules(ctx)
+	if err != nil {
+		return err
+	}
+	t.RoundedAt = rules.Round(models.AttendanceKind(t.AttendanceKindID), t.PushedAt.In(timezone.JSTLocation()))
+	return nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timeutil"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"github.com/Songmu/flextime"
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type ShiftService interface {
+	GetShiftTemplates(ctx context.Context) ([]*models.ShiftTemplate, error)
+	CreateShiftTemplate(ctx context.Context, template *models.ShiftTemplate) (*models.ShiftTemplate, error)
+	PublishShiftRoster(ctx context.Context, roster *models.ShiftRoster) (*models.ShiftRoster, error)
+	GetShifts(ctx context.Context, params models.GetShiftsParameters) ([]*models.ShiftCheck, error)
+}
+
+type shiftService struct {
+	store sqlstore.SQLStore
+}
+
+func NewShiftService(ss sqlstore.SQLStore) ShiftService {
+	return &shiftService{
+		store: ss,
+	}
+}
+
+func (s *shiftService) GetShiftTemplates(ctx context.Context) ([]*models.ShiftTemplate, error) {
+	return s.store.GetShiftTemplates(ctx)
+}
+
+func (s *shiftService) CreateShiftTemplate(ctx context.Context, template *models.ShiftTemplate) (*models.ShiftTemplate, error) {
+	if template == nil {
+		return nil, xerrors.New("shift template is empty")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateShiftTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// PublishShiftRoster replaces the shifts of everyone on the days of the roster with its shifts.
+// The times of each shift are taken from its template as of now.
+func (s *shiftService) PublishShiftRoster(ctx context.Context, roster *models.ShiftRoster) (*models.ShiftRoster, error) {
+	if roster == nil {
+		return nil, xerrors.New("shift roster is empty")
+	}
+	if err := roster.Validate(); err != nil {
+		return nil, err
+	}
+
+	end := roster.End.AddDate(0, 0, 1).Add(-time.Second)
+	v, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		templates := make(map[int64]*models.ShiftTemplate)
+		shifts := make([]*models.ShiftAssignment, 0, len(roster.Shifts))
+		for _, planned := range roster.Shifts {
+			template, ok := templates[planned.ShiftTemplateID]
+			if !ok {
+				t, err := s.store.GetShiftTemplate(ctx, planned.ShiftTemplateID)
+				if err != nil {
+					return nil, err
+				}
+				if t == nil {
+					return nil, models.ErrShiftTemplateNotFound
+				}
+				templates[t.ID] = t
+				template = t
+			}
+			shifts = append(shifts, template.Assign(planned.UserID, planned.WorkDate))
+		}
+
+		if err := s.store.DeleteShiftAssignments(ctx, roster.Start, end); err != nil {
+			return nil, err
+		}
+		for _, shift := range shifts {
+			if err := s.store.CreateShiftAssignment(ctx, shift); err != nil {
+				return nil, err
+			}
+		}
+		return shifts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	published := &models.ShiftRoster{
+		Start:  roster.Start,
+		End:    roster.End,
+		Shifts: v.([]*models.ShiftAssignment),
+	}
+	return published, nil
+}
+
+// GetShifts returns the shifts of the month compared with the attendances.
+// Everyone's shifts are returned when the user id is empty.
+func (s *shiftService) GetShifts(ctx context.Context, params models.GetShiftsParameters) ([]*models.ShiftCheck, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	start, end, err := timeutil.GetMonthRange(params.Month)
+	if err != nil {
+		return nil, err
+	}
+	return checkShifts(ctx, s.store, params.UserID, start, end)
+}
+
+// checkShifts compares the shifts on the days between start and end with the attendances of their users.
+func checkShifts(ctx context.Context, store sqlstore.SQLStore, userID string, start, end time.Time) ([]*models.ShiftCheck, error) {
+	shifts, err := store.GetShiftAssignments(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances := make(models.Attendances, 0)
+	loaded := make(map[string]bool)
+	for _, shift := range shifts {
+		if loaded[shift.UserID] {
+			continue
+		}
+		loaded[shift.UserID] = true
+		a, err := store.GetAttendancesBetween(ctx, shift.UserID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		attendances = append(attendances, a...)
+	}
+	return models.CheckShifts(timezone.JSTLocation(), shifts, attendances, flextime.Now()), nil
+}
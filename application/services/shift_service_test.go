@@ -0,0 +1,76 @@
+package services
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/timezone"
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func Test_shiftService_PublishShiftRoster(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	s := NewShiftService(store)
+
+	template, err := s.CreateShiftTemplate(sqlstore.NewTestContext(), &models.ShiftTemplate{
+		Name:         "早番",
+		StartMinutes: 9 * 60,
+		EndMinutes:   18 * 60,
+		BreakMinutes: 60,
+	})
+	if err != nil {
+		t.Fatalf("CreateShiftTemplate() error = %v", err)
+	}
+
+	day := func(d int) time.Time {
+		return time.Date(2020, 6, d, 0, 0, 0, 0, timezone.JSTLocation())
+	}
+	newRoster := func(templateID int64, days ...int) *models.ShiftRoster {
+		r := &models.ShiftRoster{Start: day(1), End: day(7)}
+		for _, d := range days {
+			r.Shifts = append(r.Shifts, &models.ShiftAssignment{UserID: "asdiekawei42lasedi356ladfkjfity", ShiftTemplateID: templateID, WorkDate: day(d)})
+		}
+		return r
+	}
+	tests := []struct {
+		name    string
+		roster  *models.ShiftRoster
+		wantErr error
+	}{
+		{
+			name:   "Should publish roster",
+			roster: newRoster(template.ID, 1, 2, 3),
+		},
+		{
+			name:   "Should replace roster",
+			roster: newRoster(template.ID, 4, 5),
+		},
+		{
+			name:    "Should not publish roster with unknown template",
+			roster:  newRoster(template.ID+1, 6),
+			wantErr: models.ErrShiftTemplateNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.PublishShiftRoster(sqlstore.NewTestContext(), tt.roster); !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("PublishShiftRoster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	checks, err := s.GetShifts(sqlstore.NewTestContext(), models.GetShiftsParameters{Month: 202006})
+	if err != nil {
+		t.Errorf("GetShifts() error = %v", err)
+		return
+	}
+	if len(checks) != 2 {
+		t.Errorf("GetShifts() got %d shifts, want %d", len(checks), 2)
+		return
+	}
+	if !checks[0].IsNoShow || !checks[0].Shift.StartedAt.Equal(day(4).Add(9*time.Hour)) {
+		t.Errorf("GetShifts() first = %+v, want a no-show at 9:00 on 4th", checks[0])
+	}
+}
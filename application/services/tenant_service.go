@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type TenantService interface {
+	ResolveTenant(ctx context.Context, authTenantID string) (*models.Tenant, error)
+	GetTenants(ctx context.Context) ([]*models.Tenant, error)
+	CreateTenant(ctx context.Context, tenant *models.Tenant, adminUserID string) (*models.Tenant, error)
+}
+
+type tenantService struct {
+	store sqlstore.SQLStore
+}
+
+func NewTenantService(ss sqlstore.SQLStore) TenantService {
+	return &tenantService{
+		store: ss,
+	}
+}
+
+// ResolveTenant returns the tenant of the identity platform tenant the user signed in with.
+// It returns models.ErrTenantNotFound for a tenant that is not registered, so that its users can not sign in.
+func (s *tenantService) ResolveTenant(ctx context.Context, authTenantID string) (*models.Tenant, error) {
+	tenant, err := s.store.GetTenantByAuthTenantID(ctx, authTenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, models.ErrTenantNotFound
+	}
+	return tenant, nil
+}
+
+func (s *tenantService) GetTenants(ctx context.Context) ([]*models.Tenant, error) {
+	return s.store.GetTenants(ctx)
+}
+
+// CreateTenant registers a subsidiary and makes the user its first system admin, who then gives the other roles.
+// Its users sign in with the identity platform tenant, so it must be a tenant of its own.
+func (s *tenantService) CreateTenant(ctx context.Context, tenant *models.Tenant, adminUserID string) (*models.Tenant, error) {
+	if err := tenant.Validate(); err != nil {
+		return nil, err
+	}
+	if tenant.AuthTenantID == "" {
+		return nil, xerrors.New("auth tenant id is empty")
+	}
+	if adminUserID == "" {
+		return nil, xerrors.New("admin user id is empty")
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		registered, err := s.store.GetTenantByAuthTenantID(ctx, tenant.AuthTenantID)
+		if err != nil {
+			return nil, err
+		}
+		if registered != nil {
+			return nil, models.ErrTenantAlreadyExists
+		}
+		if err := s.store.CreateTenant(ctx, tenant); err != nil {
+			return nil, err
+		}
+		role := &models.UserRole{UserID: adminUserID, RoleID: uint8(models.RoleSystemAdmin)}
+		if err := s.store.CreateUserRole(sqlstore.WithTenant(ctx, tenant.ID), role); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.NewWarn(logrus.Fields{"tenant_id": tenant.ID, "user_id": adminUserID}, "tenant is created with its first system admin")
+	return tenant, nil
+}
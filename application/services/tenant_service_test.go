@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	uuid "github.com/satori/go.uuid"
+	"golang.org/x/xerrors"
+	"testing"
+)
+
+func Test_tenantService_CreateTenant(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	s := NewTenantService(store)
+
+	authTenantID := uuid.NewV4().String()
+	adminUserID := uuid.NewV4().String()
+
+	tests := []struct {
+		name        string
+		tenant      *models.Tenant
+		adminUserID string
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{
+			name:        "Should not create a tenant without an auth tenant id",
+			tenant:      &models.Tenant{Name: "subsidiary"},
+			adminUserID: adminUserID,
+			wantAnyErr:  true,
+		},
+		{
+			name:       "Should not create a tenant without an admin",
+			tenant:     &models.Tenant{Name: "subsidiary", AuthTenantID: authTenantID},
+			wantAnyErr: true,
+		},
+		{
+			name:        "Should create a tenant with its first system admin",
+			tenant:      &models.Tenant{Name: "subsidiary", AuthTenantID: authTenantID},
+			adminUserID: adminUserID,
+		},
+		{
+			name:        "Should not create a tenant of a registered auth tenant",
+			tenant:      &models.Tenant{Name: "subsidiary", AuthTenantID: authTenantID},
+			adminUserID: adminUserID,
+			wantErr:     models.ErrTenantAlreadyExists,
+			wantAnyErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.CreateTenant(context.Background(), tt.tenant, tt.adminUserID)
+			if (err != nil) != tt.wantAnyErr || (tt.wantErr != nil && !xerrors.Is(err, tt.wantErr)) {
+				t.Errorf("CreateTenant() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			tenant, err := s.ResolveTenant(context.Background(), authTenantID)
+			if err != nil || tenant.ID != got.ID {
+				t.Errorf("ResolveTenant() = %v, %v, want %v", tenant, err, got)
+				return
+			}
+			principal, err := loadPrincipal(sqlstore.WithTenant(context.Background(), got.ID), store, tt.adminUserID)
+			if err != nil {
+				t.Errorf("loadPrincipal() error = %v", err)
+				return
+			}
+			if !principal.HasRole(models.RoleSystemAdmin) {
+				t.Errorf("CreateTenant() admin roles = %v, want a system admin", principal)
+			}
+		})
+	}
+}
@@ -8,8 +8,8 @@ import (
 )
 
 type UserService interface {
-	GetOrCreateUser(params models.GetOrCreateUserParams) (*models.GetOrCreateUserResults, error)
-	UpdateUser(user *models.User) error
+	GetOrCreateUser(ctx context.Context, params models.GetOrCreateUserParams) (*models.GetOrCreateUserResults, error)
+	UpdateUser(ctx context.Context, user *models.User) error
 }
 
 type userService struct {
@@ -22,7 +22,7 @@ func NewUserService(ss sqlstore.SQLStore) UserService {
 	}
 }
 
-func (s *userService) GetOrCreateUser(params models.GetOrCreateUserParams) (*models.GetOrCreateUserResults, error) {
+func (s *userService) GetOrCreateUser(ctx context.Context, params models.GetOrCreateUserParams) (*models.GetOrCreateUserResults, error) {
 	var (
 		user *models.User
 		err  error
@@ -31,10 +31,8 @@ func (s *userService) GetOrCreateUser(params models.GetOrCreateUserParams) (*mod
 	if params.UserID == "" {
 		return nil, xerrors.New("user id is empty")
 	}
-	ctx := context.Background()
 	defer s.store.Close(ctx)
 
-
 	user, err = s.store.GetUser(ctx, params.UserID)
 	if err != nil {
 		return nil, err
@@ -62,11 +60,11 @@ func (s *userService) GetOrCreateUser(params models.GetOrCreateUserParams) (*mod
 	return &res, nil
 }
 
-func (s *userService) UpdateUser(user *models.User) error {
+func (s *userService) UpdateUser(ctx context.Context, user *models.User) error {
 	if user == nil {
 		return xerrors.New("user pointer is empty")
 	}
-	_, err := s.store.InTransaction(context.Background(), func(ctx context.Context) (interface{}, error) {
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
 		return nil, s.store.UpdateUser(ctx, user)
 	})
 
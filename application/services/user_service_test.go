@@ -1,7 +1,6 @@
 package services
 
 import (
-	"context"
 	"github.com/KouT127/attendance-management/domain/models"
 	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
 	"github.com/Songmu/flextime"
@@ -80,7 +79,7 @@ func Test_userService_GetOrCreateUser(t *testing.T) {
 			s := &userService{
 				store: tt.fields.store,
 			}
-			got, err := s.GetOrCreateUser(tt.args.params)
+			got, err := s.GetOrCreateUser(sqlstore.NewTestContext(), tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetOrCreateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -95,7 +94,7 @@ func Test_userService_GetOrCreateUser(t *testing.T) {
 func Test_userService_UpdateUser(t *testing.T) {
 	store := sqlstore.InitTestDatabase()
 	userID := uuid.NewV4().String()
-	err := store.CreateUser(context.Background(), &models.User{ID: userID})
+	err := store.CreateUser(sqlstore.NewTestContext(), &models.User{ID: userID})
 	if err != nil {
 		t.Errorf("CreateUser() %s", err)
 	}
@@ -155,12 +154,12 @@ func Test_userService_UpdateUser(t *testing.T) {
 			s := &userService{
 				store: tt.fields.store,
 			}
-			if err := s.UpdateUser(tt.args.user); (err != nil) != tt.wantErr {
+			if err := s.UpdateUser(sqlstore.NewTestContext(), tt.args.user); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
-			got, err := s.GetOrCreateUser(models.GetOrCreateUserParams{UserID: tt.args.user.ID})
+			got, err := s.GetOrCreateUser(sqlstore.NewTestContext(), models.GetOrCreateUserParams{UserID: tt.args.user.ID})
 			if got == nil {
 				// Failed用
 				return
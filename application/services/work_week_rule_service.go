@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"golang.org/x/xerrors"
+)
+
+type WorkWeekRuleService interface {
+	GetWorkWeekRule(ctx context.Context) (*models.WorkWeekRule, error)
+	UpdateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) (*models.WorkWeekRule, error)
+}
+
+type workWeekRuleService struct {
+	store sqlstore.SQLStore
+}
+
+func NewWorkWeekRuleService(ss sqlstore.SQLStore) WorkWeekRuleService {
+	return &workWeekRuleService{
+		store: ss,
+	}
+}
+
+// GetWorkWeekRule returns the work week rule of the company, with Sunday as the statutory holiday when not set.
+func (s *workWeekRuleService) GetWorkWeekRule(ctx context.Context) (*models.WorkWeekRule, error) {
+	return loadWorkWeekRule(ctx, s.store)
+}
+
+// UpdateWorkWeekRule sets the work week rule of the company, creating it on first use.
+// The work breakdowns and overtime limits are computed with it from then on, including past months.
+func (s *workWeekRuleService) UpdateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) (*models.WorkWeekRule, error) {
+	if rule == nil {
+		return nil, xerrors.New("work week rule is empty")
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := s.store.InTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		current, err := s.store.GetWorkWeekRule(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, s.store.CreateWorkWeekRule(ctx, rule)
+		}
+		rule.ID = current.ID
+		rule.CreatedAt = current.CreatedAt
+		return nil, s.store.UpdateWorkWeekRule(ctx, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func loadWorkWeekRule(ctx context.Context, store sqlstore.SQLStore) (*models.WorkWeekRule, error) {
+	rule, err := store.GetWorkWeekRule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return models.DefaultWorkWeekRule(), nil
+	}
+	return rule, nil
+}
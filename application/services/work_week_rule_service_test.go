@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func Test_workWeekRuleService_UpdateWorkWeekRule(t *testing.T) {
+	store := sqlstore.InitTestDatabase()
+	// A tenant of its own, so that moving the statutory holiday does not affect the breakdowns of other tests.
+	tenant := &models.Tenant{Name: "work week", AuthTenantID: uuid.NewV4().String()}
+	if err := store.CreateTenant(context.Background(), tenant); err != nil {
+		t.Errorf("CreateTenant() failed %s", err)
+		return
+	}
+	ctx := sqlstore.WithTenant(context.Background(), tenant.ID)
+	s := NewWorkWeekRuleService(store)
+
+	tests := []struct {
+		name    string
+		rule    *models.WorkWeekRule
+		want    time.Weekday
+		wantErr bool
+	}{
+		{name: "Should default to Sunday", want: time.Sunday},
+		{name: "Should create the rule", rule: &models.WorkWeekRule{StatutoryHolidayWeekday: uint8(time.Saturday)}, want: time.Saturday},
+		{name: "Should update the rule", rule: &models.WorkWeekRule{StatutoryHolidayWeekday: uint8(time.Wednesday)}, want: time.Wednesday},
+		{name: "Should not set an invalid weekday", rule: &models.WorkWeekRule{StatutoryHolidayWeekday: 7}, want: time.Wednesday, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.rule != nil {
+				if _, err := s.UpdateWorkWeekRule(ctx, tt.rule); (err != nil) != tt.wantErr {
+					t.Errorf("UpdateWorkWeekRule() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+			}
+			got, err := s.GetWorkWeekRule(ctx)
+			if err != nil {
+				t.Errorf("GetWorkWeekRule() error = %v", err)
+				return
+			}
+			if got.HolidayWeekday() != tt.want {
+				t.Errorf("GetWorkWeekRule() = %v, want %v", got.HolidayWeekday(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"net"
+	"time"
+)
+
+type NetworkStatus uint8
+
+const (
+	// NetworkStatusNone is a punch that was not checked, e.g. before any network was allowed.
+	NetworkStatusNone NetworkStatus = iota
+	NetworkStatusAllowed
+	NetworkStatusOutside
+)
+
+// NetworkRestrictionMode is what is done with a punch from outside the allowed networks.
+// A punch from outside is always flagged, whatever work location it declares.
+type NetworkRestrictionMode uint8
+
+const (
+	NetworkRestrictionModeNone NetworkRestrictionMode = iota
+	// NetworkRestrictionModeFlag records the punch and its status to be shown in the attendance list.
+	NetworkRestrictionModeFlag
+	// NetworkRestrictionModeReject refuses the punch.
+	NetworkRestrictionModeReject
+)
+
+var (
+	ErrAllowedNetworkNotFound = xerrors.New("allowed network is not found")
+	ErrPunchOutsideNetwork    = xerrors.New("punch is outside of the allowed networks")
+)
+
+// Allows reports whether a punch of the status is recorded in the mode.
+func (m NetworkRestrictionMode) Allows(status NetworkStatus) bool {
+	if m != NetworkRestrictionModeReject {
+		return true
+	}
+	return status != NetworkStatusOutside
+}
+
+// NetworkRestriction is the policy of the company for punches from outside the allowed networks.
+type NetworkRestriction struct {
+	ID                       int64
+	TenantID                 int64
+	NetworkRestrictionModeID uint8
+	CreatedAt                time.Time `xorm:"created"`
+	UpdatedAt                time.Time `xorm:"updated"`
+}
+
+func (NetworkRestriction) TableName() string {
+	return "network_restrictions"
+}
+
+// DefaultNetworkRestriction flags punches from outside, for a company that has not set its policy.
+func DefaultNetworkRestriction() *NetworkRestriction {
+	return &NetworkRestriction{NetworkRestrictionModeID: uint8(NetworkRestrictionModeFlag)}
+}
+
+func (r *NetworkRestriction) Mode() NetworkRestrictionMode {
+	return NetworkRestrictionMode(r.NetworkRestrictionModeID)
+}
+
+func (r *NetworkRestriction) Validate() error {
+	if r.Mode() != NetworkRestrictionModeFlag && r.Mode() != NetworkRestrictionModeReject {
+		return xerrors.New("network restriction mode is invalid")
+	}
+	return nil
+}
+
+// Check refuses the punch when it is from outside the allowed networks and the policy rejects it.
+func (r *NetworkRestriction) Check(t *AttendanceTime) error {
+	if !r.Mode().Allows(t.NetworkStatus()) {
+		return ErrPunchOutsideNetwork
+	}
+	return nil
+}
+
+// AllowedNetwork is a network of an office, in CIDR notation, that punches are allowed from.
+// The allowed networks apply to the whole company.
+type AllowedNetwork struct {
+	ID        int64
+	TenantID  int64
+	Name      string
+	CIDR      string
+	CreatedAt time.Time `xorm:"created"`
+	UpdatedAt time.Time `xorm:"updated"`
+}
+
+func (AllowedNetwork) TableName() string {
+	return "allowed_networks"
+}
+
+func (n *AllowedNetwork) Validate() error {
+	if n.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	if _, _, err := net.ParseCIDR(n.CIDR); err != nil {
+		return xerrors.Errorf("cidr is invalid: %w", err)
+	}
+	return nil
+}
+
+// Contains reports whether the ip is in the network. An invalid network contains nothing.
+func (n *AllowedNetwork) Contains(ip net.IP) bool {
+	_, ipNet, err := net.ParseCIDR(n.CIDR)
+	if err != nil {
+		return false
+	}
+	return ipNet.Contains(ip)
+}
+
+type AllowedNetworks []*AllowedNetwork
+
+// Check returns the network status of a punch from the ip.
+// Punches are not checked without allowed networks, and an unknown ip is outside.
+func (networks AllowedNetworks) Check(ip net.IP) NetworkStatus {
+	if len(networks) == 0 {
+		return NetworkStatusNone
+	}
+	for _, n := range networks {
+		if ip != nil && n.Contains(ip) {
+			return NetworkStatusAllowed
+		}
+	}
+	return NetworkStatusOutside
+}
+
+func (t *AttendanceTime) NetworkStatus() NetworkStatus {
+	return NetworkStatus(t.NetworkStatusID)
+}
+
+func (s NetworkStatus) String() string {
+	switch s {
+	case NetworkStatusAllowed:
+		return "許可ネットワーク"
+	case NetworkStatusOutside:
+		return "許可ネットワーク外"
+	}
+	return "未確認"
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAllowedNetworks_Check(t *testing.T) {
+	networks := AllowedNetworks{
+		{ID: 1, Name: "東京オフィス", CIDR: "203.0.113.0/24"},
+		{ID: 2, Name: "VPN", CIDR: "2001:db8::/32"},
+	}
+	tests := []struct {
+		name     string
+		networks AllowedNetworks
+		ip       net.IP
+		want     NetworkStatus
+	}{
+		{
+			name:     "Should not check without allowed networks",
+			networks: AllowedNetworks{},
+			ip:       net.ParseIP("198.51.100.1"),
+			want:     NetworkStatusNone,
+		},
+		{
+			name:     "Should allow an address of the office",
+			networks: networks,
+			ip:       net.ParseIP("203.0.113.25"),
+			want:     NetworkStatusAllowed,
+		},
+		{
+			name:     "Should allow an IPv6 address of the VPN",
+			networks: networks,
+			ip:       net.ParseIP("2001:db8::1"),
+			want:     NetworkStatusAllowed,
+		},
+		{
+			name:     "Should be outside from another network",
+			networks: networks,
+			ip:       net.ParseIP("198.51.100.1"),
+			want:     NetworkStatusOutside,
+		},
+		{
+			name:     "Should be outside without address",
+			networks: networks,
+			ip:       nil,
+			want:     NetworkStatusOutside,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.networks.Check(tt.ip); got != tt.want {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkRestrictionMode_Allows(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   NetworkRestrictionMode
+		status NetworkStatus
+		want   bool
+	}{
+		{
+			name:   "Should flag a punch from outside",
+			mode:   NetworkRestrictionModeFlag,
+			status: NetworkStatusOutside,
+			want:   true,
+		},
+		{
+			name:   "Should reject a punch from outside",
+			mode:   NetworkRestrictionModeReject,
+			status: NetworkStatusOutside,
+			want:   false,
+		},
+		{
+			name:   "Should allow a punch from an allowed network",
+			mode:   NetworkRestrictionModeReject,
+			status: NetworkStatusAllowed,
+			want:   true,
+		},
+		{
+			name:   "Should allow a punch not checked",
+			mode:   NetworkRestrictionModeReject,
+			status: NetworkStatusNone,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mode.Allows(tt.status); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkRestriction_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    NetworkRestrictionMode
+		wantErr bool
+	}{
+		{name: "Should validate flag", mode: NetworkRestrictionModeFlag},
+		{name: "Should validate reject", mode: NetworkRestrictionModeReject},
+		{name: "Should not validate without a mode", mode: NetworkRestrictionModeNone, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NetworkRestriction{NetworkRestrictionModeID: uint8(tt.mode)}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type AnomalyKind uint8
+
+const (
+	AnomalyKindNone AnomalyKind = iota
+	AnomalyKindMissingClockOut
+	AnomalyKindLongShift
+	AnomalyKindHolidayPunch
+	AnomalyKindDuplicatePunch
+	AnomalyKindAutomaticClockOut
+)
+
+// AnomalyRules are the thresholds an attendance is checked against.
+type AnomalyRules struct {
+	// ClockOutCutoff is how long a session can stay open before the clock-out is considered missing.
+	ClockOutCutoff time.Duration
+	// LongShiftLength is the length over which a closed session is implausible.
+	LongShiftLength time.Duration
+	// DuplicateWindow is the interval under which two punches in a row are considered a double punch.
+	DuplicateWindow time.Duration
+}
+
+func DefaultAnomalyRules() AnomalyRules {
+	return AnomalyRules{
+		ClockOutCutoff:  16 * time.Hour,
+		LongShiftLength: 13 * time.Hour,
+		DuplicateWindow: 10 * time.Second,
+	}
+}
+
+// AttendanceAnomaly is a finding on an attendance that needs a correction or a confirmation.
+// It is resolved when the attendance no longer has it, e.g. after the missing clock-out was corrected.
+type AttendanceAnomaly struct {
+	ID           int64
+	TenantID     int64
+	UserID       string
+	AttendanceID int64
+	// AttendanceTimeID is the punch the anomaly is about.
+	AttendanceTimeID int64
+	AnomalyKindID    uint8
+	AttendedAt       time.Time
+	PushedAt         time.Time
+	ResolvedAt       time.Time
+	CreatedAt        time.Time `xorm:"created"`
+	UpdatedAt        time.Time `xorm:"updated"`
+}
+
+func (AttendanceAnomaly) TableName() string {
+	return "attendance_anomalies"
+}
+
+func (a *AttendanceAnomaly) Kind() AnomalyKind {
+	return AnomalyKind(a.AnomalyKindID)
+}
+
+func (a *AttendanceAnomaly) IsResolved() bool {
+	return !a.ResolvedAt.IsZero()
+}
+
+// Key identifies the same finding across scans.
+func (a *AttendanceAnomaly) Key() string {
+	return fmt.Sprintf("%d-%d-%d", a.AnomalyKindID, a.AttendanceID, a.AttendanceTimeID)
+}
+
+// Detect returns the anomalies of the attendances at now.
+// A session closed by an automatic clock-out is reported as such to be confirmed, whatever its length.
+// A punch on a holiday is expected when a shift is planned on the day.
+func (r AnomalyRules) Detect(cal *Calendar, attendances Attendances, shifts []*ShiftAssignment, now time.Time) []*AttendanceAnomaly {
+	planned := make(map[string]bool)
+	for _, s := range shifts {
+		planned[s.UserID+cal.dayOf(s.WorkDate).Format(holidayKeyLayout)] = true
+	}
+
+	anomalies := make([]*AttendanceAnomaly, 0)
+	for _, a := range attendances {
+		found := func(kind AnomalyKind, t *AttendanceTime) {
+			anomalies = append(anomalies, &AttendanceAnomaly{
+				UserID:           a.UserID,
+				AttendanceID:     a.ID,
+				AttendanceTimeID: t.ID,
+				AnomalyKindID:    uint8(kind),
+				AttendedAt:       a.AttendedAt,
+				PushedAt:         t.PushedAt,
+			})
+		}
+
+		for _, s := range a.Sessions {
+			switch {
+			case !s.IsClosed() && now.Sub(s.ClockedIn.PushedAt) > r.ClockOutCutoff:
+				found(AnomalyKindMissingClockOut, s.ClockedIn)
+			case s.IsClosed() && s.ClockedOut.IsAutomatic:
+				found(AnomalyKindAutomaticClockOut, s.ClockedOut)
+			case s.IsClosed() && s.ClockedOut.PushedAt.Sub(s.ClockedIn.PushedAt) > r.LongShiftLength:
+				found(AnomalyKindLongShift, s.ClockedIn)
+			}
+		}
+
+		times := a.Times()
+		if len(times) != 0 && cal.IsHoliday(a.AttendedAt) && !planned[a.UserID+cal.dayOf(a.AttendedAt).Format(holidayKeyLayout)] {
+			found(AnomalyKindHolidayPunch, times[0])
+		}
+		for i := 1; i < len(times); i++ {
+			if times[i].PushedAt.Sub(times[i-1].PushedAt) < r.DuplicateWindow {
+				found(AnomalyKindDuplicatePunch, times[i])
+			}
+		}
+	}
+	return anomalies
+}
+
+func (k AnomalyKind) String() string {
+	switch k {
+	case AnomalyKindMissingClockOut:
+		return "退勤打刻漏れ"
+	case AnomalyKindLongShift:
+		return "長時間勤務"
+	case AnomalyKindHolidayPunch:
+		return "休日打刻"
+	case AnomalyKindDuplicatePunch:
+		return "二重打刻"
+	case AnomalyKindAutomaticClockOut:
+		return "自動退勤"
+	}
+	return "不明"
+}
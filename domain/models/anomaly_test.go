@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnomalyRules_Detect(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	rules := DefaultAnomalyRules()
+	open := &Attendance{AttendedAt: date(2020, 6, 1).Add(9 * time.Hour)}
+	open.SetTimes([]*AttendanceTime{{ID: 1, AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(9 * time.Hour)}})
+	doubled := newTestShift(date(2020, 6, 1), 9, 9, 0)
+	doubled.SetTimes(append(doubled.Times(),
+		&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(18*time.Hour + 3*time.Second)},
+		&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: date(2020, 6, 1).Add(19 * time.Hour)},
+	))
+	holiday := newTestShift(date(2020, 7, 23), 9, 9, 1)
+	closed := newTestShift(date(2020, 6, 1), 9, 20, 0)
+	closed.ClockedOut.IsAutomatic = true
+
+	tests := []struct {
+		name        string
+		attendances Attendances
+		shifts      []*ShiftAssignment
+		now         time.Time
+		want        []AnomalyKind
+	}{
+		{
+			name:        "Should not detect a normal day",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 9, 9, 1)},
+			now:         date(2020, 6, 2),
+		},
+		{
+			name:        "Should detect a missing clock-out past the cutoff",
+			attendances: Attendances{open},
+			now:         date(2020, 6, 2).Add(2 * time.Hour),
+			want:        []AnomalyKind{AnomalyKindMissingClockOut},
+		},
+		{
+			name:        "Should not detect a session still in the cutoff",
+			attendances: Attendances{open},
+			now:         date(2020, 6, 1).Add(20 * time.Hour),
+		},
+		{
+			name:        "Should detect a long shift",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 8, 14, 1)},
+			now:         date(2020, 6, 2),
+			want:        []AnomalyKind{AnomalyKindLongShift},
+		},
+		{
+			name:        "Should detect an automatic clock-out instead of a long shift",
+			attendances: Attendances{closed},
+			now:         date(2020, 6, 2).Add(6 * time.Hour),
+			want:        []AnomalyKind{AnomalyKindAutomaticClockOut},
+		},
+		{
+			name:        "Should detect a punch on a holiday",
+			attendances: Attendances{holiday},
+			now:         date(2020, 7, 24),
+			want:        []AnomalyKind{AnomalyKindHolidayPunch},
+		},
+		{
+			name:        "Should not detect a punch on a holiday with a shift",
+			attendances: Attendances{holiday},
+			shifts:      []*ShiftAssignment{{WorkDate: date(2020, 7, 23)}},
+			now:         date(2020, 7, 24),
+		},
+		{
+			name:        "Should detect punches seconds apart",
+			attendances: Attendances{doubled},
+			now:         date(2020, 6, 2),
+			want:        []AnomalyKind{AnomalyKindDuplicatePunch},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Detect(cal, tt.attendances, tt.shifts, tt.now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Detect() = %d anomalies, want %d", len(got), len(tt.want))
+			}
+			for i, a := range got {
+				if a.Kind() != tt.want[i] {
+					t.Errorf("Detect()[%d] = %s, want %s", i, a.Kind(), tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"golang.org/x/xerrors"
+	"sort"
 	"time"
 )
 
@@ -10,81 +13,347 @@ const (
 	AttendanceKindNone AttendanceKind = iota
 	AttendanceKindClockIn
 	AttendanceKindClockOut
+	AttendanceKindBreakStart
+	AttendanceKindBreakEnd
 )
 
+type AttendanceStatus uint8
+
+const (
+	AttendanceStatusNotStarted AttendanceStatus = iota
+	AttendanceStatusWorking
+	AttendanceStatusOnBreak
+	AttendanceStatusFinished
+)
+
+var ErrAttendanceNotFound = xerrors.New("attendance is not found")
+
+// AttendanceConflictError is returned when a punch is not allowed in the current attendance status,
+// e.g. clocking out without a clock-in.
+type AttendanceConflictError struct {
+	Status AttendanceStatus
+	Kind   AttendanceKind
+}
+
+func (e *AttendanceConflictError) Error() string {
+	return fmt.Sprintf("%sの状態では%sできません", e.Status, e.Kind)
+}
+
 type AttendanceTime struct {
-	ID               int64
-	Remark           string
-	AttendanceID     int64
-	AttendanceKindID uint8
-	IsModified       bool
-	PushedAt         time.Time
-	CreatedAt        time.Time `xorm:"created"`
-	UpdatedAt        time.Time `xorm:"updated"`
+	ID                  int64
+	TenantID            int64
+	Remark              string
+	AttendanceID        int64
+	AttendanceKindID    uint8
+	IsModified          bool
+	IsAutomatic         bool
+	WorkLocationID      uint8
+	HasLocation         bool
+	Latitude            float64
+	Longitude           float64
+	LocationAccuracy    float64
+	GeofenceID          int64
+	GeofenceStatusID    uint8
+	IPAddress           string
+	NetworkStatusID     uint8
+	KioskID             int64
+	PushedAt            time.Time
+	RoundedAt           time.Time
+	ApprovedBy          string
+	CorrectionRequestID int64
+	CreatedAt           time.Time `xorm:"created"`
+	UpdatedAt           time.Time `xorm:"updated"`
 }
 
 func (AttendanceTime) TableName() string {
 	return "attendances_time"
 }
 
+// PayrollAt returns the rounded time of the punch, or the raw time when it was recorded without rounding.
+func (t *AttendanceTime) PayrollAt() time.Time {
+	if t.RoundedAt.IsZero() {
+		return t.PushedAt
+	}
+	return t.RoundedAt
+}
+
+// ChangedBy returns who recorded the punch of the user's attendance:
+// the approver for a punch written by a correction request, the system for an automatic clock-out,
+// otherwise the user who punched.
+func (t *AttendanceTime) ChangedBy(userID string) string {
+	if t.IsAutomatic {
+		return SystemActor
+	}
+	if t.ApprovedBy != "" {
+		return t.ApprovedBy
+	}
+	return userID
+}
+
 type Attendance struct {
 	ID         int64
+	TenantID   int64
 	UserID     string
 	AttendedAt time.Time
 	CreatedAt  time.Time `xorm:"created"`
 	UpdatedAt  time.Time `xorm:"updated"`
 
-	ClockedIn  *AttendanceTime `xorm:"-"`
-	ClockedOut *AttendanceTime `xorm:"-"`
+	ClockedIn  *AttendanceTime      `xorm:"-"`
+	ClockedOut *AttendanceTime      `xorm:"-"`
+	Sessions   []*AttendanceSession `xorm:"-"`
+	Breaks     []*AttendanceBreak   `xorm:"-"`
 }
 
 func (Attendance) TableName() string {
 	return "attendances"
 }
 
-type Attendances []*Attendance
+// AttendanceSession is a single work session from a clock-in to its clock-out, with the breaks taken in it.
+// ClockedOut is nil while the session is still in progress.
+type AttendanceSession struct {
+	ClockedIn  *AttendanceTime
+	ClockedOut *AttendanceTime
+	Breaks     []*AttendanceBreak
+}
 
-func (attendances Attendances) ManipulateTotalWorkHours() float64 {
-	var total float64
-	for _, attendance := range attendances {
-		if attendance.ClockedOut == nil {
+func (s *AttendanceSession) IsClosed() bool {
+	return s.ClockedOut != nil
+}
+
+// AttendanceBreak pairs a break-start punch with the break-end punch that closed it.
+// End is nil while the break is still in progress, or when its session was clocked out during the break.
+type AttendanceBreak struct {
+	Start *AttendanceTime
+	End   *AttendanceTime
+}
+
+// SetTimes rebuilds the sessions and breaks of the attendance from the active punches of the day.
+// Punches are applied in the order they were pushed.
+func (a *Attendance) SetTimes(times []*AttendanceTime) {
+	sorted := make([]*AttendanceTime, len(times))
+	copy(sorted, times)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PushedAt.Before(sorted[j].PushedAt)
+	})
+
+	a.ClockedIn = nil
+	a.ClockedOut = nil
+	a.Sessions = nil
+	a.Breaks = nil
+	for _, t := range sorted {
+		a.AddTime(t)
+	}
+}
+
+// AddTime applies a punch to the attendance.
+// ClockedIn stays the first clock-in of the day and ClockedOut follows the latest session.
+// A break belongs to the session it started in, so clocking out ends a break still in progress.
+func (a *Attendance) AddTime(t *AttendanceTime) {
+	switch AttendanceKind(t.AttendanceKindID) {
+	case AttendanceKindClockIn:
+		a.Sessions = append(a.Sessions, &AttendanceSession{ClockedIn: t})
+		if a.ClockedIn == nil {
+			a.ClockedIn = t
+		}
+		a.ClockedOut = nil
+	case AttendanceKindClockOut:
+		if len(a.Sessions) == 0 {
+			return
+		}
+		a.Sessions[len(a.Sessions)-1].ClockedOut = t
+		a.ClockedOut = t
+	case AttendanceKindBreakStart:
+		b := &AttendanceBreak{Start: t}
+		a.Breaks = append(a.Breaks, b)
+		if s := a.LatestSession(); s != nil && !s.IsClosed() {
+			s.Breaks = append(s.Breaks, b)
+		}
+	case AttendanceKindBreakEnd:
+		if !a.HasOpenBreak() {
+			return
+		}
+		s := a.LatestSession()
+		s.Breaks[len(s.Breaks)-1].End = t
+	}
+}
+
+// Times returns the active punches of the attendance in the order they were pushed.
+func (a *Attendance) Times() []*AttendanceTime {
+	times := make([]*AttendanceTime, 0)
+	if a == nil {
+		return times
+	}
+	for _, s := range a.Sessions {
+		times = append(times, s.ClockedIn)
+		if s.ClockedOut != nil {
+			times = append(times, s.ClockedOut)
+		}
+	}
+	for _, b := range a.Breaks {
+		times = append(times, b.Start)
+		if b.End != nil {
+			times = append(times, b.End)
+		}
+	}
+	sort.SliceStable(times, func(i, j int) bool {
+		return times[i].PushedAt.Before(times[j].PushedAt)
+	})
+	return times
+}
+
+// ValidateSessionTimes checks that clock-ins and clock-outs alternate in the order they were pushed,
+// starting with a clock-in.
+func ValidateSessionTimes(times []*AttendanceTime) error {
+	sorted := make([]*AttendanceTime, len(times))
+	copy(sorted, times)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PushedAt.Before(sorted[j].PushedAt)
+	})
+
+	expected := AttendanceKindClockIn
+	for _, t := range sorted {
+		kind := AttendanceKind(t.AttendanceKindID)
+		if kind != AttendanceKindClockIn && kind != AttendanceKindClockOut {
+			continue
+		}
+		if kind != expected {
+			return xerrors.Errorf("unexpected %s at %s", kind, t.PushedAt)
+		}
+		if expected == AttendanceKindClockIn {
+			expected = AttendanceKindClockOut
+		} else {
+			expected = AttendanceKindClockIn
+		}
+	}
+	return nil
+}
+
+// LatestSession returns the session clocked in last, or nil before the first clock-in.
+func (a *Attendance) LatestSession() *AttendanceSession {
+	if a == nil || len(a.Sessions) == 0 {
+		return nil
+	}
+	return a.Sessions[len(a.Sessions)-1]
+}
+
+// HasOpenBreak reports whether a break was started in the session in progress and not ended yet.
+func (a *Attendance) HasOpenBreak() bool {
+	s := a.LatestSession()
+	if s == nil || s.IsClosed() || len(s.Breaks) == 0 {
+		return false
+	}
+	return s.Breaks[len(s.Breaks)-1].End == nil
+}
+
+// BreakDuration returns the break time taken within closed sessions.
+// A break that was never ended is counted until the clock-out of its session.
+func (a *Attendance) BreakDuration() time.Duration {
+	return a.breakDuration(rawTime)
+}
+
+// WorkDuration returns the time worked in closed sessions excluding breaks.
+func (a *Attendance) WorkDuration() time.Duration {
+	return a.workDuration(rawTime)
+}
+
+// RoundedWorkDuration returns the time worked by the rounded punches, as paid by payroll.
+func (a *Attendance) RoundedWorkDuration() time.Duration {
+	return a.workDuration(payrollTime)
+}
+
+func rawTime(t *AttendanceTime) time.Time {
+	return t.PushedAt
+}
+
+func payrollTime(t *AttendanceTime) time.Time {
+	return t.PayrollAt()
+}
+
+func (a *Attendance) breakDuration(at func(t *AttendanceTime) time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range a.Sessions {
+		if !s.IsClosed() {
 			continue
 		}
-		workTime := attendance.ClockedOut.PushedAt.Sub(attendance.ClockedIn.PushedAt)
-		total += workTime.Hours()
+		in := at(s.ClockedIn)
+		out := at(s.ClockedOut)
+		for _, b := range s.Breaks {
+			start := at(b.Start)
+			end := out
+			if b.End != nil {
+				end = at(b.End)
+			}
+			if start.Before(in) {
+				start = in
+			}
+			if end.After(out) {
+				end = out
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
 	}
 	return total
 }
 
-type AttendanceDetail struct {
-	Attendance     `xorm:"extends"`
-	ClockedInTime  *AttendanceTime `xorm:"extends"`
-	ClockedOutTime *AttendanceTime `xorm:"extends"`
+// workDuration never goes below zero, which rounding in opposite directions can cause for a very short session.
+func (a *Attendance) workDuration(at func(t *AttendanceTime) time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range a.Sessions {
+		if !s.IsClosed() {
+			continue
+		}
+		if out := at(s.ClockedOut); out.After(at(s.ClockedIn)) {
+			total += out.Sub(at(s.ClockedIn))
+		}
+	}
+	if total -= a.breakDuration(at); total < 0 {
+		return 0
+	}
+	return total
+}
+
+// Status returns the state of the attendance. A nil attendance has not started yet.
+func (a *Attendance) Status() AttendanceStatus {
+	session := a.LatestSession()
+	switch {
+	case session == nil:
+		return AttendanceStatusNotStarted
+	case session.IsClosed():
+		return AttendanceStatusFinished
+	case a.HasOpenBreak():
+		return AttendanceStatusOnBreak
+	}
+	return AttendanceStatusWorking
 }
 
-func (d AttendanceDetail) ToAttendance() *Attendance {
-	var (
-		in  *AttendanceTime
-		out *AttendanceTime
-	)
-	a := d.Attendance
-	if d.ClockedInTime.ID != 0 {
-		in = d.ClockedInTime
+// ValidatePush returns an AttendanceConflictError when the punch of the kind is not allowed.
+func (a *Attendance) ValidatePush(kind AttendanceKind) error {
+	status := a.Status()
+	if !status.CanPush(kind) {
+		return &AttendanceConflictError{Status: status, Kind: kind}
 	}
-	if d.ClockedOutTime.ID != 0 {
-		out = d.ClockedOutTime
+	return nil
+}
+
+type Attendances []*Attendance
+
+func (attendances Attendances) ManipulateTotalWorkHours() float64 {
+	var total float64
+	for _, attendance := range attendances {
+		total += attendance.WorkDuration().Hours()
 	}
+	return total
+}
 
-	attendance := &Attendance{
-		ID:         a.ID,
-		UserID:     a.UserID,
-		AttendedAt: a.AttendedAt,
-		ClockedIn:  in,
-		ClockedOut: out,
-		CreatedAt:  a.CreatedAt,
-		UpdatedAt:  a.UpdatedAt,
+// ManipulateTotalRoundedWorkHours returns the hours worked by the rounded punches.
+func (attendances Attendances) ManipulateTotalRoundedWorkHours() float64 {
+	var total float64
+	for _, attendance := range attendances {
+		total += attendance.RoundedWorkDuration().Hours()
 	}
-	return attendance
+	return total
 }
 
 func (k AttendanceKind) String() string {
@@ -93,6 +362,38 @@ func (k AttendanceKind) String() string {
 		return "出勤"
 	case AttendanceKindClockOut:
 		return "退勤"
+	case AttendanceKindBreakStart:
+		return "休憩開始"
+	case AttendanceKindBreakEnd:
+		return "休憩終了"
+	}
+	return "不明"
+}
+
+func (s AttendanceStatus) CanPush(kind AttendanceKind) bool {
+	switch s {
+	case AttendanceStatusNotStarted:
+		return kind == AttendanceKindClockIn
+	case AttendanceStatusWorking:
+		return kind == AttendanceKindClockOut || kind == AttendanceKindBreakStart
+	case AttendanceStatusOnBreak:
+		return kind == AttendanceKindClockOut || kind == AttendanceKindBreakEnd
+	case AttendanceStatusFinished:
+		return kind == AttendanceKindClockIn
+	}
+	return false
+}
+
+func (s AttendanceStatus) String() string {
+	switch s {
+	case AttendanceStatusNotStarted:
+		return "未出勤"
+	case AttendanceStatusWorking:
+		return "勤務中"
+	case AttendanceStatusOnBreak:
+		return "休憩中"
+	case AttendanceStatusFinished:
+		return "退勤済"
 	}
 	return "不明"
 }
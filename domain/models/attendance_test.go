@@ -0,0 +1,177 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestAttendanceTime(kind AttendanceKind, hour, min int) *AttendanceTime {
+	return &AttendanceTime{
+		AttendanceKindID: uint8(kind),
+		PushedAt:         time.Date(2020, 1, 2, hour, min, 0, 0, time.UTC),
+	}
+}
+
+func newTestAttendance(times ...*AttendanceTime) *Attendance {
+	a := &Attendance{}
+	a.SetTimes(times)
+	return a
+}
+
+func TestAttendances_ManipulateTotalWorkHours(t *testing.T) {
+	tests := []struct {
+		name        string
+		attendances Attendances
+		want        float64
+	}{
+		{
+			name: "Should count whole span without breaks",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindClockOut, 18, 0),
+				),
+			},
+			want: 9,
+		},
+		{
+			name: "Should subtract multiple breaks",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 12, 0),
+					newTestAttendanceTime(AttendanceKindBreakEnd, 13, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 15, 0),
+					newTestAttendanceTime(AttendanceKindBreakEnd, 15, 30),
+					newTestAttendanceTime(AttendanceKindClockOut, 18, 0),
+				),
+			},
+			want: 7.5,
+		},
+		{
+			name: "Should count open break until clock out",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 17, 0),
+					newTestAttendanceTime(AttendanceKindClockOut, 18, 0),
+				),
+			},
+			want: 8,
+		},
+		{
+			name: "Should skip attendance not clocked out",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 12, 0),
+				),
+			},
+			want: 0,
+		},
+		{
+			name: "Should sum closed sessions of a split shift",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindClockOut, 12, 0),
+					newTestAttendanceTime(AttendanceKindClockIn, 17, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 19, 0),
+					newTestAttendanceTime(AttendanceKindBreakEnd, 19, 30),
+					newTestAttendanceTime(AttendanceKindClockOut, 21, 0),
+					newTestAttendanceTime(AttendanceKindClockIn, 22, 0),
+				),
+			},
+			want: 6.5,
+		},
+		{
+			name: "Should end a break at the clock out of its session",
+			attendances: Attendances{
+				newTestAttendance(
+					newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+					newTestAttendanceTime(AttendanceKindBreakStart, 11, 0),
+					newTestAttendanceTime(AttendanceKindClockOut, 12, 0),
+					newTestAttendanceTime(AttendanceKindClockIn, 17, 0),
+					newTestAttendanceTime(AttendanceKindClockOut, 21, 0),
+				),
+			},
+			want: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attendances.ManipulateTotalWorkHours(); got != tt.want {
+				t.Errorf("ManipulateTotalWorkHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttendance_ValidatePush(t *testing.T) {
+	working := newTestAttendance(
+		newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+	)
+	onBreak := newTestAttendance(
+		newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+		newTestAttendanceTime(AttendanceKindBreakStart, 12, 0),
+	)
+	finished := newTestAttendance(
+		newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+		newTestAttendanceTime(AttendanceKindClockOut, 18, 0),
+	)
+	clockedOutOnBreak := newTestAttendance(
+		newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+		newTestAttendanceTime(AttendanceKindBreakStart, 11, 0),
+		newTestAttendanceTime(AttendanceKindClockOut, 12, 0),
+		newTestAttendanceTime(AttendanceKindClockIn, 17, 0),
+	)
+
+	tests := []struct {
+		name       string
+		attendance *Attendance
+		kind       AttendanceKind
+		wantErr    bool
+	}{
+		{name: "Should clock in when not started", attendance: nil, kind: AttendanceKindClockIn, wantErr: false},
+		{name: "Should not clock out when not started", attendance: nil, kind: AttendanceKindClockOut, wantErr: true},
+		{name: "Should not clock in twice", attendance: working, kind: AttendanceKindClockIn, wantErr: true},
+		{name: "Should clock out when working", attendance: working, kind: AttendanceKindClockOut, wantErr: false},
+		{name: "Should not end break when working", attendance: working, kind: AttendanceKindBreakEnd, wantErr: true},
+		{name: "Should clock out when on break", attendance: onBreak, kind: AttendanceKindClockOut, wantErr: false},
+		{name: "Should not start break when on break", attendance: onBreak, kind: AttendanceKindBreakStart, wantErr: true},
+		{name: "Should not clock out when finished", attendance: finished, kind: AttendanceKindClockOut, wantErr: true},
+		{name: "Should clock in again when finished", attendance: finished, kind: AttendanceKindClockIn, wantErr: false},
+		{name: "Should start break in a session after one clocked out on break", attendance: clockedOutOnBreak, kind: AttendanceKindBreakStart, wantErr: false},
+		{name: "Should not end the break of a clocked out session", attendance: clockedOutOnBreak, kind: AttendanceKindBreakEnd, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attendance.ValidatePush(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePush() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if _, ok := err.(*AttendanceConflictError); err != nil && !ok {
+				t.Errorf("ValidatePush() error type = %T", err)
+			}
+		})
+	}
+}
+
+func TestAttendanceTime_ChangedBy(t *testing.T) {
+	tests := []struct {
+		name           string
+		attendanceTime *AttendanceTime
+		want           string
+	}{
+		{name: "Should be the user for own punch", attendanceTime: &AttendanceTime{}, want: "user"},
+		{name: "Should be the approver for corrected punch", attendanceTime: &AttendanceTime{ApprovedBy: "manager"}, want: "manager"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attendanceTime.ChangedBy("user"); got != tt.want {
+				t.Errorf("ChangedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
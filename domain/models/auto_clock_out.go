@@ -0,0 +1,68 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+const (
+	// DefaultAutoClockOutRemark is written on an automatic clock-out when the policy has no remark.
+	DefaultAutoClockOutRemark = "自動退勤"
+	// SystemActor is who recorded a punch written by the system.
+	SystemActor = "system"
+)
+
+var ErrAutoClockOutPolicyNotFound = xerrors.New("auto clock-out policy is not set")
+
+// AutoClockOutPolicy closes the attendances left open at the cutoff time of the day after they were attended,
+// so that the hours are not lost when someone forgets to clock out.
+type AutoClockOutPolicy struct {
+	ID        int64
+	TenantID  int64
+	IsEnabled bool
+	// CutoffMinutes is the cutoff time in minutes from midnight of the next day.
+	CutoffMinutes int
+	Remark        string
+	CreatedAt     time.Time `xorm:"created"`
+	UpdatedAt     time.Time `xorm:"updated"`
+}
+
+func (AutoClockOutPolicy) TableName() string {
+	return "auto_clock_out_policies"
+}
+
+func (p *AutoClockOutPolicy) Validate() error {
+	if p.CutoffMinutes < 0 || p.CutoffMinutes >= minutesPerDay {
+		return xerrors.New("cutoff time is invalid")
+	}
+	return nil
+}
+
+// CutoffOf returns the cutoff of the attendance attended at the time, on the next day in the location of the time.
+func (p *AutoClockOutPolicy) CutoffOf(attendedAt time.Time) time.Time {
+	next := time.Date(attendedAt.Year(), attendedAt.Month(), attendedAt.Day()+1, 0, 0, 0, 0, attendedAt.Location())
+	return next.Add(time.Duration(p.CutoffMinutes) * time.Minute)
+}
+
+// ClockOut returns the automatic clock-out of the attendance when its session is still open past the cutoff at now,
+// otherwise nil.
+func (p *AutoClockOutPolicy) ClockOut(a *Attendance, now time.Time) *AttendanceTime {
+	if !p.IsEnabled || !a.Status().CanPush(AttendanceKindClockOut) {
+		return nil
+	}
+	cutoff := p.CutoffOf(a.AttendedAt)
+	if now.Before(cutoff) {
+		return nil
+	}
+	remark := p.Remark
+	if remark == "" {
+		remark = DefaultAutoClockOutRemark
+	}
+	return &AttendanceTime{
+		AttendanceID:     a.ID,
+		AttendanceKindID: uint8(AttendanceKindClockOut),
+		PushedAt:         cutoff,
+		Remark:           remark,
+		IsAutomatic:      true,
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoClockOutPolicy_ClockOut(t *testing.T) {
+	policy := &AutoClockOutPolicy{IsEnabled: true, CutoffMinutes: 5 * 60}
+	open := func(inHour int) *Attendance {
+		a := &Attendance{AttendedAt: date(2020, 6, 1).Add(time.Duration(inHour) * time.Hour)}
+		a.SetTimes([]*AttendanceTime{{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: a.AttendedAt}})
+		return a
+	}
+	onBreak := open(9)
+	onBreak.AddTime(&AttendanceTime{AttendanceKindID: uint8(AttendanceKindBreakStart), PushedAt: date(2020, 6, 1).Add(12 * time.Hour)})
+	cutoff := date(2020, 6, 2).Add(5 * time.Hour)
+
+	tests := []struct {
+		name       string
+		policy     *AutoClockOutPolicy
+		attendance *Attendance
+		now        time.Time
+		want       bool
+	}{
+		{
+			name:       "Should clock out at the cutoff of the next day",
+			policy:     policy,
+			attendance: open(9),
+			now:        cutoff.Add(time.Minute),
+			want:       true,
+		},
+		{
+			name:       "Should clock out a late night session",
+			policy:     policy,
+			attendance: open(23),
+			now:        cutoff,
+			want:       true,
+		},
+		{
+			name:       "Should clock out on a break",
+			policy:     policy,
+			attendance: onBreak,
+			now:        cutoff,
+			want:       true,
+		},
+		{
+			name:       "Should not clock out before the cutoff",
+			policy:     policy,
+			attendance: open(9),
+			now:        cutoff.Add(-time.Minute),
+		},
+		{
+			name:       "Should not clock out a finished attendance",
+			policy:     policy,
+			attendance: newTestShift(date(2020, 6, 1), 9, 9, 1),
+			now:        cutoff,
+		},
+		{
+			name:       "Should not clock out when disabled",
+			policy:     &AutoClockOutPolicy{CutoffMinutes: 5 * 60},
+			attendance: open(9),
+			now:        cutoff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.ClockOut(tt.attendance, tt.now)
+			if (got != nil) != tt.want {
+				t.Fatalf("ClockOut() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if !got.IsAutomatic || !got.PushedAt.Equal(cutoff) || got.Remark != DefaultAutoClockOutRemark {
+				t.Errorf("ClockOut() = %+v, want an automatic clock-out at %v", got, cutoff)
+			}
+			tt.attendance.AddTime(got)
+			if tt.attendance.Status() != AttendanceStatusFinished {
+				t.Errorf("ClockOut() left the attendance %s", tt.attendance.Status())
+			}
+		})
+	}
+}
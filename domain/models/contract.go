@@ -0,0 +1,194 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type EmploymentType uint8
+
+const (
+	EmploymentTypeNone EmploymentType = iota
+	EmploymentTypeFullTime
+	EmploymentTypePartTime
+	EmploymentTypeShortTime
+)
+
+// Workdays is a set of weekdays, one bit per time.Weekday.
+type Workdays uint8
+
+const (
+	// ProportionalLeaveMaxWeeklyHours and ProportionalLeaveMaxWorkdays bound the contracts
+	// granted paid leave in proportion to their workdays.
+	ProportionalLeaveMaxWeeklyHours = 30
+	ProportionalLeaveMaxWorkdays    = 4
+)
+
+// DefaultWorkdays is Monday to Friday.
+const DefaultWorkdays Workdays = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+
+var ErrContractOverlapped = xerrors.New("contract must start after the current contract")
+
+func NewWorkdays(weekdays ...time.Weekday) Workdays {
+	var w Workdays
+	for _, d := range weekdays {
+		w |= 1 << d
+	}
+	return w
+}
+
+func (w Workdays) Contains(d time.Weekday) bool {
+	return w&(1<<d) != 0
+}
+
+func (w Workdays) Count() int {
+	count := 0
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if w.Contains(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// EmploymentContract is the working conditions of a user from StartedAt until FinishedAt.
+// A zero FinishedAt means the contract is still in effect.
+type EmploymentContract struct {
+	ID               int64
+	TenantID         int64
+	UserID           string
+	EmploymentTypeID uint8
+	WeeklyHours      float64
+	Workdays         uint8
+	StartedAt        time.Time
+	FinishedAt       time.Time
+	CreatedAt        time.Time `xorm:"created"`
+	UpdatedAt        time.Time `xorm:"updated"`
+}
+
+func (EmploymentContract) TableName() string {
+	return "employment_contracts"
+}
+
+func (c *EmploymentContract) Type() EmploymentType {
+	return EmploymentType(c.EmploymentTypeID)
+}
+
+func (c *EmploymentContract) Validate() error {
+	if c.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if c.Type() == EmploymentTypeNone || c.Type() > EmploymentTypeShortTime {
+		return xerrors.New("employment type is invalid")
+	}
+	if c.WeeklyHours <= 0 {
+		return xerrors.New("weekly hours must be positive")
+	}
+	if Workdays(c.Workdays).Count() == 0 {
+		return xerrors.New("workdays are empty")
+	}
+	if c.StartedAt.IsZero() {
+		return xerrors.New("started at is empty")
+	}
+	return nil
+}
+
+// DailyHours returns the contracted hours of a workday.
+func (c *EmploymentContract) DailyHours() float64 {
+	return c.WeeklyHours / float64(Workdays(c.Workdays).Count())
+}
+
+// HasProportionalLeave reports whether the contract is under 30 hours a week on at most four days,
+// so that paid leave is granted in proportion to its workdays.
+func (c *EmploymentContract) HasProportionalLeave() bool {
+	return c.WeeklyHours < ProportionalLeaveMaxWeeklyHours && Workdays(c.Workdays).Count() <= ProportionalLeaveMaxWorkdays
+}
+
+func (c *EmploymentContract) IsEffective(at time.Time) bool {
+	if at.Before(c.StartedAt) {
+		return false
+	}
+	return c.FinishedAt.IsZero() || at.Before(c.FinishedAt)
+}
+
+// EmploymentContracts is the contract history of a user.
+type EmploymentContracts []*EmploymentContract
+
+// At returns the contract in effect at the time, or nil.
+func (contracts EmploymentContracts) At(at time.Time) *EmploymentContract {
+	for _, c := range contracts {
+		if c.IsEffective(at) {
+			return c
+		}
+	}
+	return nil
+}
+
+// FirstStartedAt returns the start of the earliest contract, or the zero time without one.
+func (contracts EmploymentContracts) FirstStartedAt() time.Time {
+	var first time.Time
+	for _, c := range contracts {
+		if first.IsZero() || c.StartedAt.Before(first) {
+			first = c.StartedAt
+		}
+	}
+	return first
+}
+
+// DailyHoursAt returns the contracted hours of a workday at the time, or the standard hours without a contract.
+func (contracts EmploymentContracts) DailyHoursAt(at time.Time) float64 {
+	if c := contracts.At(at); c != nil {
+		return c.DailyHours()
+	}
+	return StandardWorkingHoursPerDay
+}
+
+// IsWorkday reports whether the day is a workday of the contract in effect and not a holiday.
+// Without a contract in effect, the business days of the calendar are the workdays.
+func (contracts EmploymentContracts) IsWorkday(cal *Calendar, d time.Time) bool {
+	d = cal.dayOf(d)
+	c := contracts.At(d)
+	if c == nil {
+		return cal.IsBusinessDay(d)
+	}
+	return Workdays(c.Workdays).Contains(d.Weekday()) && !cal.IsHoliday(d)
+}
+
+// CountWorkdays returns the number of workdays between the days of start and end, both inclusive.
+func (contracts EmploymentContracts) CountWorkdays(cal *Calendar, start, end time.Time) int {
+	count := 0
+	last := cal.dayOf(end)
+	for d := cal.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if contracts.IsWorkday(cal, d) {
+			count++
+		}
+	}
+	return count
+}
+
+// RequiredHours returns the contracted hours between the days of start and end.
+// Each day counts the daily hours of the contract in effect when it is one of its workdays and not a holiday.
+func (contracts EmploymentContracts) RequiredHours(cal *Calendar, start, end time.Time) float64 {
+	var total float64
+	last := cal.dayOf(end)
+	for d := cal.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+		c := contracts.At(d)
+		if c == nil || !Workdays(c.Workdays).Contains(d.Weekday()) || cal.IsHoliday(d) {
+			continue
+		}
+		total += c.DailyHours()
+	}
+	return total
+}
+
+func (t EmploymentType) String() string {
+	switch t {
+	case EmploymentTypeFullTime:
+		return "正社員"
+	case EmploymentTypePartTime:
+		return "パートタイム"
+	case EmploymentTypeShortTime:
+		return "短時間勤務"
+	}
+	return "不明"
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmploymentContracts_RequiredHours(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	fullTime := &EmploymentContract{
+		EmploymentTypeID: uint8(EmploymentTypeFullTime),
+		WeeklyHours:      40,
+		Workdays:         uint8(DefaultWorkdays),
+		StartedAt:        date(2019, 4, 1),
+	}
+	partTime := &EmploymentContract{
+		EmploymentTypeID: uint8(EmploymentTypePartTime),
+		WeeklyHours:      18,
+		Workdays:         uint8(NewWorkdays(time.Monday, time.Wednesday, time.Friday)),
+		StartedAt:        date(2019, 4, 1),
+	}
+	changed := &EmploymentContract{
+		EmploymentTypeID: uint8(EmploymentTypeFullTime),
+		WeeklyHours:      40,
+		Workdays:         uint8(DefaultWorkdays),
+		StartedAt:        date(2019, 4, 1),
+		FinishedAt:       date(2020, 6, 15),
+	}
+	shortTime := &EmploymentContract{
+		EmploymentTypeID: uint8(EmploymentTypeShortTime),
+		WeeklyHours:      30,
+		Workdays:         uint8(DefaultWorkdays),
+		StartedAt:        date(2020, 6, 15),
+	}
+	tests := []struct {
+		name      string
+		contracts EmploymentContracts
+		start     time.Time
+		end       time.Time
+		want      float64
+	}{
+		{name: "Should count full time workdays", contracts: EmploymentContracts{fullTime}, start: date(2020, 6, 1), end: date(2020, 6, 30), want: 176},
+		{name: "Should count part time workdays only", contracts: EmploymentContracts{partTime}, start: date(2020, 6, 1), end: date(2020, 6, 30), want: 78},
+		{name: "Should exclude holidays on workdays", contracts: EmploymentContracts{partTime}, start: date(2020, 2, 1), end: date(2020, 2, 29), want: 66},
+		{name: "Should switch contracts in the middle of the month", contracts: EmploymentContracts{changed, shortTime}, start: date(2020, 6, 1), end: date(2020, 6, 30), want: 152},
+		{name: "Should not count days before the first contract", contracts: EmploymentContracts{shortTime}, start: date(2020, 6, 1), end: date(2020, 6, 30), want: 72},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.contracts.RequiredHours(cal, tt.start, tt.end); got != tt.want {
+				t.Errorf("RequiredHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmploymentContracts_DailyHoursAt(t *testing.T) {
+	contracts := EmploymentContracts{
+		{WeeklyHours: 40, Workdays: uint8(DefaultWorkdays), StartedAt: date(2019, 4, 1), FinishedAt: date(2020, 4, 1)},
+		{WeeklyHours: 18, Workdays: uint8(NewWorkdays(time.Monday, time.Wednesday, time.Friday)), StartedAt: date(2020, 4, 1)},
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{name: "Should use the standard hours before the first contract", at: date(2019, 1, 1), want: StandardWorkingHoursPerDay},
+		{name: "Should use the finished contract", at: date(2020, 3, 31), want: 8},
+		{name: "Should use the current contract", at: date(2020, 4, 1), want: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contracts.DailyHoursAt(tt.at); got != tt.want {
+				t.Errorf("DailyHoursAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmploymentContracts_FirstStartedAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		contracts EmploymentContracts
+		want      time.Time
+	}{
+		{name: "Should return the zero time without a contract", contracts: EmploymentContracts{}},
+		{
+			name: "Should return the start of the earliest contract",
+			contracts: EmploymentContracts{
+				{WeeklyHours: 18, StartedAt: date(2020, 4, 1)},
+				{WeeklyHours: 40, StartedAt: date(2019, 4, 1), FinishedAt: date(2020, 4, 1)},
+			},
+			want: date(2019, 4, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.contracts.FirstStartedAt(); !got.Equal(tt.want) {
+				t.Errorf("FirstStartedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
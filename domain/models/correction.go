@@ -0,0 +1,123 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type CorrectionStatus uint8
+
+const (
+	CorrectionStatusNone CorrectionStatus = iota
+	CorrectionStatusPending
+	CorrectionStatusApproved
+	CorrectionStatusRejected
+)
+
+var (
+	ErrCorrectionRequestNotFound    = xerrors.New("correction request is not found")
+	ErrCorrectionRequestNotPending  = xerrors.New("correction request is already reviewed")
+	ErrCorrectionRequestSelfReview  = xerrors.New("correction request cannot be reviewed by the requester")
+	ErrCorrectionRequestInvalidTime = xerrors.New("requested time is inconsistent with the attendance")
+)
+
+// CorrectionRequest is a proposal by an employee to fix a clock-in or clock-out of a past day.
+// AttendanceTimeID is the punch to be replaced, or zero when a forgotten punch is added.
+type CorrectionRequest struct {
+	ID                 int64
+	TenantID           int64
+	UserID             string
+	AttendanceID       int64
+	AttendanceTimeID   int64
+	AttendanceKindID   uint8
+	TargetDate         time.Time
+	PushedAt           time.Time
+	Reason             string
+	CorrectionStatusID uint8
+	ReviewerID         string
+	ReviewComment      string
+	ReviewedAt         time.Time
+	CreatedAt          time.Time `xorm:"created"`
+	UpdatedAt          time.Time `xorm:"updated"`
+}
+
+func (CorrectionRequest) TableName() string {
+	return "correction_requests"
+}
+
+func (r *CorrectionRequest) Status() CorrectionStatus {
+	return CorrectionStatus(r.CorrectionStatusID)
+}
+
+func (r *CorrectionRequest) Validate() error {
+	if r.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	kind := AttendanceKind(r.AttendanceKindID)
+	if kind != AttendanceKindClockIn && kind != AttendanceKindClockOut {
+		return xerrors.New("attendance kind must be clock in or clock out")
+	}
+	if r.TargetDate.IsZero() || r.PushedAt.IsZero() {
+		return xerrors.New("date is empty")
+	}
+	if r.PushedAt.Before(r.TargetDate) || !r.PushedAt.Before(r.TargetDate.AddDate(0, 0, 2)) {
+		return ErrCorrectionRequestInvalidTime
+	}
+	if r.Reason == "" {
+		return xerrors.New("reason is empty")
+	}
+	return nil
+}
+
+// Review moves a pending request to the approved or rejected status.
+func (r *CorrectionRequest) Review(reviewerID string, status CorrectionStatus, comment string, now time.Time) error {
+	if r.Status() != CorrectionStatusPending {
+		return ErrCorrectionRequestNotPending
+	}
+	if reviewerID == r.UserID {
+		return ErrCorrectionRequestSelfReview
+	}
+	r.CorrectionStatusID = uint8(status)
+	r.ReviewerID = reviewerID
+	r.ReviewComment = comment
+	r.ReviewedAt = now
+	return nil
+}
+
+// ReplacedTime returns the punch the request supersedes within the attendance, or nil when it adds a new one.
+// Without an explicit punch, a clock-in replaces the first clock-in and a clock-out replaces the clock-out
+// of the latest session.
+func (r *CorrectionRequest) ReplacedTime(attendance *Attendance) *AttendanceTime {
+	if attendance == nil {
+		return nil
+	}
+	for _, s := range attendance.Sessions {
+		for _, t := range []*AttendanceTime{s.ClockedIn, s.ClockedOut} {
+			if t != nil && r.AttendanceTimeID != 0 && t.ID == r.AttendanceTimeID {
+				return t
+			}
+		}
+	}
+	if r.AttendanceTimeID != 0 {
+		return nil
+	}
+	if AttendanceKind(r.AttendanceKindID) == AttendanceKindClockIn {
+		return attendance.ClockedIn
+	}
+	if session := attendance.LatestSession(); session != nil {
+		return session.ClockedOut
+	}
+	return nil
+}
+
+func (s CorrectionStatus) String() string {
+	switch s {
+	case CorrectionStatusPending:
+		return "申請中"
+	case CorrectionStatusApproved:
+		return "承認済"
+	case CorrectionStatusRejected:
+		return "却下"
+	}
+	return "不明"
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+const (
+	MaxFlextimePeriodMonths = 3
+	minutesPerDay           = 24 * 60
+)
+
+var ErrFlextimeRuleNotFound = xerrors.New("flextime rule is not set")
+
+// FlextimeRule is the flextime system of the company.
+// Hours are settled over a period of one to three months starting from StartMonth,
+// and everyone must be working during the core time on business days.
+type FlextimeRule struct {
+	ID           int64
+	TenantID     int64
+	PeriodMonths int
+	StartMonth   int
+	// CoreStartMinutes and CoreEndMinutes are the core time in minutes from midnight.
+	CoreStartMinutes int
+	CoreEndMinutes   int
+	// CarryOverSurplus carries the surplus of a period over to the next one.
+	// Otherwise it is paid as overtime and only a deficit is carried over.
+	CarryOverSurplus bool
+	CreatedAt        time.Time `xorm:"created"`
+	UpdatedAt        time.Time `xorm:"updated"`
+}
+
+func (FlextimeRule) TableName() string {
+	return "flextime_rules"
+}
+
+func (r *FlextimeRule) Validate() error {
+	if r.PeriodMonths < 1 || r.PeriodMonths > MaxFlextimePeriodMonths {
+		return xerrors.New("period must be one to three months")
+	}
+	if r.StartMonth < int(time.January) || r.StartMonth > int(time.December) {
+		return xerrors.New("start month is invalid")
+	}
+	if r.CoreStartMinutes < 0 || r.CoreEndMinutes > minutesPerDay || r.CoreStartMinutes > r.CoreEndMinutes {
+		return xerrors.New("core time is invalid")
+	}
+	return nil
+}
+
+// HasCoreTime reports whether the rule has a core time. A rule without it is a super flextime system.
+func (r *FlextimeRule) HasCoreTime() bool {
+	return r.CoreStartMinutes < r.CoreEndMinutes
+}
+
+// PeriodOf returns the first day of the settlement period of the time and the last second of the period.
+func (r *FlextimeRule) PeriodOf(t time.Time) (time.Time, time.Time) {
+	months := (t.Year()*12 + int(t.Month()) - 1) - (t.Year()*12 + r.StartMonth - 1)
+	offset := ((months % r.PeriodMonths) + r.PeriodMonths) % r.PeriodMonths
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, -offset, 0)
+	end := start.AddDate(0, r.PeriodMonths, 0).Add(-time.Second)
+	return start, end
+}
+
+// CoreTime returns the core time on the day of d in the location of d.
+func (r *FlextimeRule) CoreTime(d time.Time) (time.Time, time.Time) {
+	midnight := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+	return midnight.Add(time.Duration(r.CoreStartMinutes) * time.Minute), midnight.Add(time.Duration(r.CoreEndMinutes) * time.Minute)
+}
+
+// CarryOver returns the hours carried over from the balance of the previous period.
+func (r *FlextimeRule) CarryOver(previousBalance float64) float64 {
+	if previousBalance > 0 && !r.CarryOverSurplus {
+		return 0
+	}
+	return previousBalance
+}
+
+// Settle carries the balances over the settlements, which are consecutive periods in order, and returns the last one.
+// Each balance includes the hours carried into its period, so a deficit keeps being carried until it is made up.
+// Nothing is carried over from a period the user did not work in.
+func (r *FlextimeRule) Settle(settlements []FlextimeSettlement) FlextimeSettlement {
+	for i := 1; i < len(settlements); i++ {
+		if previous := settlements[i-1]; previous.WorkedHours != 0 {
+			settlements[i].CarryOverHours = r.CarryOver(previous.Balance())
+		}
+	}
+	return settlements[len(settlements)-1]
+}
+
+// FlextimeDay is a day of a flextime period with its core time violations.
+type FlextimeDay struct {
+	Date        time.Time
+	WorkedHours float64
+	// IsLate is set when the core time started before the first clock-in.
+	IsLate bool
+	// IsEarlyLeave is set when the last clock-out was before the end of the core time.
+	IsEarlyLeave bool
+	// IsAway is set when the user was clocked out in the middle of the core time.
+	IsAway bool
+	// IsAbsent is set on a business day without attendance or leave.
+	IsAbsent bool
+}
+
+func (d *FlextimeDay) HasViolation() bool {
+	return d.IsLate || d.IsEarlyLeave || d.IsAway || d.IsAbsent
+}
+
+// FlextimeDays returns the days between the days of start and end, up to the day of now.
+// Business days are checked against the core time; a day with leave is exempt.
+// Breaks taken during the core time are not violations.
+func (r *FlextimeRule) FlextimeDays(cal *Calendar, attendances Attendances, leaves []*LeaveRequest, start, end, now time.Time) []*FlextimeDay {
+	byDate := make(map[time.Time]Attendances)
+	for _, a := range attendances {
+		d := cal.dayOf(a.AttendedAt)
+		byDate[d] = append(byDate[d], a)
+	}
+
+	last := cal.dayOf(end)
+	if today := cal.dayOf(now); today.Before(last) {
+		last = today
+	}
+	days := make([]*FlextimeDay, 0)
+	for d := cal.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+		day := &FlextimeDay{Date: d}
+		for _, a := range byDate[d] {
+			day.WorkedHours += a.WorkDuration().Hours()
+		}
+		if cal.IsBusinessDay(d) && r.HasCoreTime() && !isOnLeave(cal, leaves, d) {
+			r.checkCoreTime(day, byDate[d], d.Equal(cal.dayOf(now)))
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// checkCoreTime flags the parts of the core time the user was not clocked in.
+// On today, an unfinished core time is not a violation yet.
+func (r *FlextimeRule) checkCoreTime(day *FlextimeDay, attendances Attendances, isToday bool) {
+	if len(attendances) == 0 {
+		day.IsAbsent = !isToday
+		return
+	}
+	coreStart, coreEnd := r.CoreTime(day.Date)
+	uncovered := []timeRange{{start: coreStart, end: coreEnd}}
+	for _, a := range attendances {
+		for _, s := range a.Sessions {
+			session := timeRange{start: s.ClockedIn.PushedAt, end: coreEnd}
+			if s.IsClosed() {
+				session.end = s.ClockedOut.PushedAt
+			}
+			uncovered = subtractRange(uncovered, session)
+		}
+	}
+	for _, u := range uncovered {
+		if u.start.Equal(coreStart) {
+			day.IsLate = true
+		}
+		if u.end.Equal(coreEnd) && !isToday {
+			day.IsEarlyLeave = true
+		}
+		if !u.start.Equal(coreStart) && !u.end.Equal(coreEnd) {
+			day.IsAway = true
+		}
+	}
+}
+
+func isOnLeave(cal *Calendar, leaves []*LeaveRequest, d time.Time) bool {
+	for _, l := range leaves {
+		if !d.Before(cal.dayOf(l.StartDate)) && !d.After(cal.dayOf(l.EndDate)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FlextimeSettlement is the balance of the hours of a settlement period.
+type FlextimeSettlement struct {
+	Start          time.Time
+	End            time.Time
+	RequiredHours  float64
+	WorkedHours    float64
+	LeaveHours     float64
+	CarryOverHours float64
+}
+
+// Balance returns the surplus, or the deficit when negative, of the period including the carry-over.
+func (s FlextimeSettlement) Balance() float64 {
+	return s.WorkedHours + s.LeaveHours + s.CarryOverHours - s.RequiredHours
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlextimeRule_PeriodOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      FlextimeRule
+		t         time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "Should return the month for a one month period",
+			rule:      FlextimeRule{PeriodMonths: 1, StartMonth: 4},
+			t:         date(2020, 6, 15),
+			wantStart: date(2020, 6, 1),
+			wantEnd:   date(2020, 7, 1).Add(-time.Second),
+		},
+		{
+			name:      "Should return the quarter counted from the start month",
+			rule:      FlextimeRule{PeriodMonths: 3, StartMonth: 4},
+			t:         date(2020, 6, 15),
+			wantStart: date(2020, 4, 1),
+			wantEnd:   date(2020, 7, 1).Add(-time.Second),
+		},
+		{
+			name:      "Should return the period across the year",
+			rule:      FlextimeRule{PeriodMonths: 3, StartMonth: 4},
+			t:         date(2021, 2, 1),
+			wantStart: date(2021, 1, 1),
+			wantEnd:   date(2021, 4, 1).Add(-time.Second),
+		},
+		{
+			name:      "Should return the period starting in the previous year",
+			rule:      FlextimeRule{PeriodMonths: 2, StartMonth: 6},
+			t:         date(2021, 1, 31),
+			wantStart: date(2020, 12, 1),
+			wantEnd:   date(2021, 2, 1).Add(-time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.rule.PeriodOf(tt.t)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("PeriodOf() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFlextimeRule_FlextimeDays(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	rule := FlextimeRule{PeriodMonths: 1, StartMonth: 4, CoreStartMinutes: 11 * 60, CoreEndMinutes: 15 * 60}
+	away := newTestShift(date(2020, 6, 1), 9, 3, 0)
+	away.AddTime(&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(13 * time.Hour)})
+	away.AddTime(&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: date(2020, 6, 1).Add(18 * time.Hour)})
+	leave := &LeaveRequest{StartDate: date(2020, 6, 1), EndDate: date(2020, 6, 1)}
+
+	tests := []struct {
+		name        string
+		attendances Attendances
+		leaves      []*LeaveRequest
+		now         time.Time
+		want        FlextimeDay
+	}{
+		{
+			name:        "Should not flag a day covering the core time",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 10, 8, 1)},
+			now:         date(2020, 6, 2),
+			want:        FlextimeDay{WorkedHours: 7},
+		},
+		{
+			name:        "Should flag late and early leave",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 12, 2, 0)},
+			now:         date(2020, 6, 2),
+			want:        FlextimeDay{WorkedHours: 2, IsLate: true, IsEarlyLeave: true},
+		},
+		{
+			name:        "Should flag away in the middle of the core time",
+			attendances: Attendances{away},
+			now:         date(2020, 6, 2),
+			want:        FlextimeDay{WorkedHours: 8, IsAway: true},
+		},
+		{
+			name: "Should flag absent without attendance",
+			now:  date(2020, 6, 2),
+			want: FlextimeDay{IsAbsent: true},
+		},
+		{
+			name:   "Should not flag a day on leave",
+			leaves: []*LeaveRequest{leave},
+			now:    date(2020, 6, 2),
+			want:   FlextimeDay{},
+		},
+		{
+			name:        "Should not flag the unfinished core time of today",
+			attendances: Attendances{{AttendedAt: date(2020, 6, 1).Add(10 * time.Hour), Sessions: []*AttendanceSession{{ClockedIn: &AttendanceTime{PushedAt: date(2020, 6, 1).Add(10 * time.Hour)}}}}},
+			now:         date(2020, 6, 1).Add(12 * time.Hour),
+			want:        FlextimeDay{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := rule.FlextimeDays(cal, tt.attendances, tt.leaves, date(2020, 6, 1), date(2020, 6, 1), tt.now)
+			if len(days) != 1 {
+				t.Fatalf("FlextimeDays() returned %d days, want 1", len(days))
+			}
+			got := *days[0]
+			tt.want.Date = date(2020, 6, 1)
+			if got != tt.want {
+				t.Errorf("FlextimeDays() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlextimeSettlement_Balance(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     FlextimeRule
+		previous FlextimeSettlement
+		current  FlextimeSettlement
+		want     float64
+	}{
+		{
+			name:     "Should carry over a deficit",
+			rule:     FlextimeRule{},
+			previous: FlextimeSettlement{RequiredHours: 160, WorkedHours: 150},
+			current:  FlextimeSettlement{RequiredHours: 160, WorkedHours: 168},
+			want:     -2,
+		},
+		{
+			name:     "Should not carry over a surplus paid as overtime",
+			rule:     FlextimeRule{},
+			previous: FlextimeSettlement{RequiredHours: 160, WorkedHours: 170},
+			current:  FlextimeSettlement{RequiredHours: 160, WorkedHours: 152, LeaveHours: 8},
+			want:     0,
+		},
+		{
+			name:     "Should carry over a surplus when allowed",
+			rule:     FlextimeRule{CarryOverSurplus: true},
+			previous: FlextimeSettlement{RequiredHours: 160, WorkedHours: 170},
+			current:  FlextimeSettlement{RequiredHours: 160, WorkedHours: 152},
+			want:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.current.CarryOverHours = tt.rule.CarryOver(tt.previous.Balance())
+			if got := tt.current.Balance(); got != tt.want {
+				t.Errorf("Balance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlextimeRule_Settle(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        FlextimeRule
+		settlements []FlextimeSettlement
+		want        float64
+	}{
+		{
+			name: "Should carry a deficit over several periods",
+			rule: FlextimeRule{},
+			settlements: []FlextimeSettlement{
+				{RequiredHours: 160, WorkedHours: 150},
+				{RequiredHours: 160, WorkedHours: 164},
+				{RequiredHours: 160, WorkedHours: 160},
+			},
+			want: -6,
+		},
+		{
+			name: "Should carry a surplus over several periods when allowed",
+			rule: FlextimeRule{CarryOverSurplus: true},
+			settlements: []FlextimeSettlement{
+				{RequiredHours: 160, WorkedHours: 170},
+				{RequiredHours: 160, WorkedHours: 165},
+				{RequiredHours: 160, WorkedHours: 160},
+			},
+			want: 15,
+		},
+		{
+			name: "Should not carry over from a period without work",
+			rule: FlextimeRule{},
+			settlements: []FlextimeSettlement{
+				{RequiredHours: 160, WorkedHours: 150},
+				{RequiredHours: 160},
+				{RequiredHours: 160, WorkedHours: 160},
+			},
+			want: 0,
+		},
+		{
+			name: "Should settle a single period",
+			rule: FlextimeRule{},
+			settlements: []FlextimeSettlement{
+				{RequiredHours: 160, WorkedHours: 158},
+			},
+			want: -2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Settle(tt.settlements).Balance(); got != tt.want {
+				t.Errorf("Settle() balance = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"math"
+	"time"
+)
+
+// earthRadiusMeters is the mean radius of the earth used for the great-circle distance.
+const earthRadiusMeters = 6371000
+
+type GeofenceStatus uint8
+
+const (
+	// GeofenceStatusNone is a punch that was not checked, e.g. worked remotely or before any geofence was set.
+	GeofenceStatusNone GeofenceStatus = iota
+	GeofenceStatusInside
+	GeofenceStatusOutside
+	// GeofenceStatusUnlocated is a checked punch sent without a location.
+	GeofenceStatusUnlocated
+)
+
+// GeofenceMode is what is done with a punch outside every geofence.
+type GeofenceMode uint8
+
+const (
+	GeofenceModeOff GeofenceMode = iota
+	// GeofenceModeFlag records the punch and its status to be shown in the attendance list.
+	GeofenceModeFlag
+	// GeofenceModeReject refuses the punch.
+	GeofenceModeReject
+)
+
+var (
+	ErrGeofenceNotFound     = xerrors.New("geofence is not found")
+	ErrPunchOutsideGeofence = xerrors.New("punch is outside of the geofences")
+)
+
+// ParseGeofenceMode returns the mode of its name: off, flag or reject.
+func ParseGeofenceMode(name string) (GeofenceMode, error) {
+	switch name {
+	case "off":
+		return GeofenceModeOff, nil
+	case "flag":
+		return GeofenceModeFlag, nil
+	case "reject":
+		return GeofenceModeReject, nil
+	}
+	return GeofenceModeOff, xerrors.Errorf("geofence mode %s is invalid", name)
+}
+
+// Allows reports whether a punch of the status is recorded in the mode.
+func (m GeofenceMode) Allows(status GeofenceStatus) bool {
+	if m != GeofenceModeReject {
+		return true
+	}
+	return status == GeofenceStatusNone || status == GeofenceStatusInside
+}
+
+// Geofence is the area around an office where punches are allowed.
+type Geofence struct {
+	ID           int64
+	TenantID     int64
+	Name         string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	CreatedAt    time.Time `xorm:"created"`
+	UpdatedAt    time.Time `xorm:"updated"`
+}
+
+func (Geofence) TableName() string {
+	return "geofences"
+}
+
+func (g *Geofence) Validate() error {
+	if g.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	if !isValidCoordinate(g.Latitude, g.Longitude) {
+		return xerrors.New("coordinate is invalid")
+	}
+	if g.RadiusMeters <= 0 {
+		return xerrors.New("radius must be positive")
+	}
+	return nil
+}
+
+// Distance returns the great-circle distance in meters from the center of the geofence, by the haversine formula.
+func (g *Geofence) Distance(latitude, longitude float64) float64 {
+	lat1 := g.Latitude * math.Pi / 180
+	lat2 := latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (longitude - g.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Contains reports whether the location can be inside the geofence.
+// The accuracy of the location, the radius of its uncertainty, is given the benefit of the doubt
+// up to the radius of the geofence, so that a coarse location far away is not taken as inside.
+func (g *Geofence) Contains(latitude, longitude, accuracy float64) bool {
+	return g.Distance(latitude, longitude) <= g.RadiusMeters+math.Min(math.Max(accuracy, 0), g.RadiusMeters)
+}
+
+type Geofences []*Geofence
+
+// Check sets the geofence status of the punch and the geofence it was inside.
+// Punches are not checked without geofences.
+func (geofences Geofences) Check(t *AttendanceTime) {
+	t.GeofenceID = 0
+	switch {
+	case len(geofences) == 0:
+		t.GeofenceStatusID = uint8(GeofenceStatusNone)
+		return
+	case !t.HasLocation:
+		t.GeofenceStatusID = uint8(GeofenceStatusUnlocated)
+		return
+	}
+	for _, g := range geofences {
+		if g.Contains(t.Latitude, t.Longitude, t.LocationAccuracy) {
+			t.GeofenceID = g.ID
+			t.GeofenceStatusID = uint8(GeofenceStatusInside)
+			return
+		}
+	}
+	t.GeofenceStatusID = uint8(GeofenceStatusOutside)
+}
+
+func (t *AttendanceTime) GeofenceStatus() GeofenceStatus {
+	return GeofenceStatus(t.GeofenceStatusID)
+}
+
+func isValidCoordinate(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+func (s GeofenceStatus) String() string {
+	switch s {
+	case GeofenceStatusInside:
+		return "許可範囲内"
+	case GeofenceStatusOutside:
+		return "許可範囲外"
+	case GeofenceStatusUnlocated:
+		return "位置情報なし"
+	}
+	return "未確認"
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// tokyoOffice is a geofence of 200m around Tokyo Station.
+var tokyoOffice = &Geofence{ID: 1, Name: "東京オフィス", Latitude: 35.681236, Longitude: 139.767125, RadiusMeters: 200}
+
+func TestGeofence_Distance(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      float64
+	}{
+		{
+			name:      "Should be zero at the center",
+			latitude:  35.681236,
+			longitude: 139.767125,
+			want:      0,
+		},
+		{
+			name:      "Should measure the distance to Shinjuku Station",
+			latitude:  35.690921,
+			longitude: 139.700258,
+			want:      6126,
+		},
+		{
+			name:      "Should measure the distance to Osaka Station",
+			latitude:  34.702485,
+			longitude: 135.495951,
+			want:      403400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokyoOffice.Distance(tt.latitude, tt.longitude)
+			if math.Abs(got-tt.want) > tt.want*0.005+1 {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeofences_Check(t *testing.T) {
+	osakaOffice := &Geofence{ID: 2, Name: "大阪オフィス", Latitude: 34.702485, Longitude: 135.495951, RadiusMeters: 300}
+	tests := []struct {
+		name       string
+		geofences  Geofences
+		time       *AttendanceTime
+		wantID     int64
+		wantStatus GeofenceStatus
+	}{
+		{
+			name:       "Should not check without geofences",
+			geofences:  Geofences{},
+			time:       &AttendanceTime{HasLocation: true, Latitude: 35.690921, Longitude: 139.700258},
+			wantStatus: GeofenceStatusNone,
+		},
+		{
+			name:       "Should be unlocated without location",
+			geofences:  Geofences{tokyoOffice},
+			time:       &AttendanceTime{},
+			wantStatus: GeofenceStatusUnlocated,
+		},
+		{
+			name:       "Should be inside the geofence containing the location",
+			geofences:  Geofences{tokyoOffice, osakaOffice},
+			time:       &AttendanceTime{HasLocation: true, Latitude: 34.7030, Longitude: 135.4965},
+			wantID:     2,
+			wantStatus: GeofenceStatusInside,
+		},
+		{
+			name:       "Should be outside every geofence",
+			geofences:  Geofences{tokyoOffice, osakaOffice},
+			time:       &AttendanceTime{HasLocation: true, Latitude: 35.690921, Longitude: 139.700258},
+			wantStatus: GeofenceStatusOutside,
+		},
+		{
+			name:       "Should be inside within the accuracy of the location",
+			geofences:  Geofences{tokyoOffice},
+			time:       &AttendanceTime{HasLocation: true, Latitude: 35.6840, Longitude: 139.767125, LocationAccuracy: 150},
+			wantID:     1,
+			wantStatus: GeofenceStatusInside,
+		},
+		{
+			name:       "Should not take more accuracy than the radius",
+			geofences:  Geofences{tokyoOffice},
+			time:       &AttendanceTime{HasLocation: true, Latitude: 35.6860, Longitude: 139.767125, LocationAccuracy: 5000},
+			wantStatus: GeofenceStatusOutside,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.geofences.Check(tt.time)
+			if tt.time.GeofenceID != tt.wantID || tt.time.GeofenceStatus() != tt.wantStatus {
+				t.Errorf("Check() = %v %v, want %v %v", tt.time.GeofenceID, tt.time.GeofenceStatus(), tt.wantID, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGeofenceMode_Allows(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   GeofenceMode
+		status GeofenceStatus
+		want   bool
+	}{
+		{
+			name:   "Should flag a punch outside",
+			mode:   GeofenceModeFlag,
+			status: GeofenceStatusOutside,
+			want:   true,
+		},
+		{
+			name:   "Should reject a punch outside",
+			mode:   GeofenceModeReject,
+			status: GeofenceStatusOutside,
+			want:   false,
+		},
+		{
+			name:   "Should reject a punch without location",
+			mode:   GeofenceModeReject,
+			status: GeofenceStatusUnlocated,
+			want:   false,
+		},
+		{
+			name:   "Should allow a punch inside",
+			mode:   GeofenceModeReject,
+			status: GeofenceStatusInside,
+			want:   true,
+		},
+		{
+			name:   "Should allow a punch not checked",
+			mode:   GeofenceModeReject,
+			status: GeofenceStatusNone,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mode.Allows(tt.status); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
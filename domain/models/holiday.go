@@ -0,0 +1,234 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"sort"
+	"time"
+)
+
+const holidayKeyLayout = "2006-01-02"
+
+var (
+	ErrHolidayNotFound      = xerrors.New("holiday is not found")
+	ErrHolidayAlreadyExists = xerrors.New("the date is already a holiday")
+)
+
+// Holiday is a day off for the whole company.
+// National holidays are computed and have no ID; company closure days are stored in the holidays table.
+type Holiday struct {
+	ID         int64
+	TenantID   int64
+	Date       time.Time
+	Name       string
+	IsNational bool      `xorm:"-"`
+	CreatedAt  time.Time `xorm:"created"`
+	UpdatedAt  time.Time `xorm:"updated"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
+
+func (h *Holiday) Validate() error {
+	if h.Date.IsZero() {
+		return xerrors.New("date is empty")
+	}
+	if h.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	return nil
+}
+
+// NationalHolidays returns the national holidays of Japan in the year at midnight of loc,
+// including substitute holidays and the days between two holidays.
+// The equinox days are computed by the approximation valid from 1980 to 2099.
+func NationalHolidays(year int, loc *time.Location) []*Holiday {
+	days := make(map[string]*Holiday)
+	add := func(month time.Month, day int, name string) {
+		d := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		days[d.Format(holidayKeyLayout)] = &Holiday{Date: d, Name: name, IsNational: true}
+	}
+	nthMonday := func(month time.Month, n int) int {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+		return 1 + offset + (n-1)*7
+	}
+	equinox := func(base float64) int {
+		y := float64(year - 1980)
+		return int(base+0.242194*y) - (year-1980)/4
+	}
+
+	add(time.January, 1, "元日")
+	add(time.January, nthMonday(time.January, 2), "成人の日")
+	add(time.February, 11, "建国記念の日")
+	if year >= 2020 {
+		add(time.February, 23, "天皇誕生日")
+	}
+	add(time.March, equinox(20.8431), "春分の日")
+	if year >= 2007 {
+		add(time.April, 29, "昭和の日")
+		add(time.May, 4, "みどりの日")
+	} else {
+		add(time.April, 29, "みどりの日")
+	}
+	add(time.May, 3, "憲法記念日")
+	add(time.May, 5, "こどもの日")
+
+	switch year {
+	case 2020:
+		add(time.July, 23, "海の日")
+		add(time.July, 24, "スポーツの日")
+		add(time.August, 10, "山の日")
+	case 2021:
+		add(time.July, 22, "海の日")
+		add(time.July, 23, "スポーツの日")
+		add(time.August, 8, "山の日")
+	default:
+		add(time.July, nthMonday(time.July, 3), "海の日")
+		if year >= 2016 {
+			add(time.August, 11, "山の日")
+		}
+		if year >= 2020 {
+			add(time.October, nthMonday(time.October, 2), "スポーツの日")
+		} else {
+			add(time.October, nthMonday(time.October, 2), "体育の日")
+		}
+	}
+
+	add(time.September, nthMonday(time.September, 3), "敬老の日")
+	add(time.September, equinox(23.2488), "秋分の日")
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+	if year <= 2018 {
+		add(time.December, 23, "天皇誕生日")
+	}
+	if year == 2019 {
+		add(time.May, 1, "即位の日")
+		add(time.October, 22, "即位礼正殿の儀")
+	}
+
+	holidays := make([]*Holiday, 0, len(days))
+	for _, h := range days {
+		holidays = append(holidays, h)
+	}
+
+	// A day between two holidays is a holiday (国民の休日).
+	for _, h := range holidays {
+		next := h.Date.AddDate(0, 0, 1)
+		if _, ok := days[next.Format(holidayKeyLayout)]; ok || next.Weekday() == time.Sunday {
+			continue
+		}
+		if _, ok := days[next.AddDate(0, 0, 1).Format(holidayKeyLayout)]; ok {
+			add(next.Month(), next.Day(), "国民の休日")
+		}
+	}
+
+	// A holiday on Sunday moves to the next day that is not a holiday (振替休日).
+	sundays := make([]time.Time, 0)
+	for _, h := range days {
+		if h.Date.Weekday() == time.Sunday {
+			sundays = append(sundays, h.Date)
+		}
+	}
+	for _, d := range sundays {
+		next := d.AddDate(0, 0, 1)
+		for {
+			if _, ok := days[next.Format(holidayKeyLayout)]; !ok {
+				break
+			}
+			next = next.AddDate(0, 0, 1)
+		}
+		if next.Year() == year {
+			add(next.Month(), next.Day(), "振替休日")
+		}
+	}
+
+	holidays = holidays[:0]
+	for _, h := range days {
+		holidays = append(holidays, h)
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// Calendar tells business days from weekends, national holidays and company closure days.
+// Dates are compared by their calendar day in the location of the calendar.
+type Calendar struct {
+	loc      *time.Location
+	closures map[string]*Holiday
+	national map[int]map[string]*Holiday
+}
+
+func NewCalendar(loc *time.Location, closures []*Holiday) *Calendar {
+	c := &Calendar{
+		loc:      loc,
+		closures: make(map[string]*Holiday),
+		national: make(map[int]map[string]*Holiday),
+	}
+	for _, h := range closures {
+		c.closures[h.Date.In(loc).Format(holidayKeyLayout)] = h
+	}
+	return c
+}
+
+// Holiday returns the holiday on the day of d, or nil on a working day or a plain weekend.
+// A company closure on a national holiday takes precedence.
+func (c *Calendar) Holiday(d time.Time) *Holiday {
+	d = d.In(c.loc)
+	key := d.Format(holidayKeyLayout)
+	if h, ok := c.closures[key]; ok {
+		return h
+	}
+	national, ok := c.national[d.Year()]
+	if !ok {
+		national = make(map[string]*Holiday)
+		for _, h := range NationalHolidays(d.Year(), c.loc) {
+			national[h.Date.Format(holidayKeyLayout)] = h
+		}
+		c.national[d.Year()] = national
+	}
+	return national[key]
+}
+
+func (c *Calendar) IsHoliday(d time.Time) bool {
+	return c.Holiday(d) != nil
+}
+
+func (c *Calendar) IsBusinessDay(d time.Time) bool {
+	d = d.In(c.loc)
+	if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.IsHoliday(d)
+}
+
+// CountBusinessDays returns the number of business days between the days of start and end, both inclusive.
+func (c *Calendar) CountBusinessDays(start, end time.Time) int {
+	count := 0
+	last := c.dayOf(end)
+	for d := c.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// Holidays returns the national holidays and company closures between the days of start and end in date order.
+func (c *Calendar) Holidays(start, end time.Time) []*Holiday {
+	holidays := make([]*Holiday, 0)
+	last := c.dayOf(end)
+	for d := c.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if h := c.Holiday(d); h != nil {
+			holidays = append(holidays, h)
+		}
+	}
+	return holidays
+}
+
+func (c *Calendar) dayOf(d time.Time) time.Time {
+	d = d.In(c.loc)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, c.loc)
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNationalHolidays(t *testing.T) {
+	tests := []struct {
+		name string
+		year int
+		want []string
+	}{
+		{
+			name: "Should compute holidays moved for the Olympics with substitute holidays",
+			year: 2020,
+			want: []string{
+				"2020-01-01", "2020-01-13", "2020-02-11", "2020-02-23", "2020-02-24", "2020-03-20",
+				"2020-04-29", "2020-05-03", "2020-05-04", "2020-05-05", "2020-05-06", "2020-07-23",
+				"2020-07-24", "2020-08-10", "2020-09-21", "2020-09-22", "2020-11-03", "2020-11-23",
+			},
+		},
+		{
+			name: "Should compute the days between two holidays",
+			year: 2019,
+			want: []string{
+				"2019-01-01", "2019-01-14", "2019-02-11", "2019-03-21", "2019-04-29", "2019-04-30",
+				"2019-05-01", "2019-05-02", "2019-05-03", "2019-05-04", "2019-05-05", "2019-05-06",
+				"2019-07-15", "2019-08-11", "2019-08-12", "2019-09-16", "2019-09-23", "2019-10-14",
+				"2019-10-22", "2019-11-03", "2019-11-04", "2019-11-23",
+			},
+		},
+		{
+			name: "Should compute a silver week",
+			year: 2026,
+			want: []string{
+				"2026-01-01", "2026-01-12", "2026-02-11", "2026-02-23", "2026-03-20", "2026-04-29",
+				"2026-05-03", "2026-05-04", "2026-05-05", "2026-05-06", "2026-07-20", "2026-08-11",
+				"2026-09-21", "2026-09-22", "2026-09-23", "2026-10-12", "2026-11-03", "2026-11-23",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NationalHolidays(tt.year, time.UTC)
+			if len(got) != len(tt.want) {
+				t.Errorf("NationalHolidays() got %d holidays, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if i >= len(tt.want) || got[i].Date.Format("2006-01-02") != tt.want[i] {
+					t.Errorf("NationalHolidays()[%d] = %s %s", i, got[i].Date.Format("2006-01-02"), got[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendar_CountBusinessDays(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	closures := []*Holiday{
+		{Date: time.Date(2020, 12, 29, 0, 0, 0, 0, jst), Name: "年末休暇"},
+	}
+	cal := NewCalendar(jst, closures)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  int
+	}{
+		{
+			name:  "Should exclude weekends and national holidays",
+			start: time.Date(2020, 5, 1, 0, 0, 0, 0, jst),
+			end:   time.Date(2020, 5, 31, 0, 0, 0, 0, jst),
+			want:  18,
+		},
+		{
+			name:  "Should exclude company closures",
+			start: time.Date(2020, 12, 28, 0, 0, 0, 0, jst),
+			end:   time.Date(2020, 12, 30, 0, 0, 0, 0, jst),
+			want:  2,
+		},
+		{
+			name:  "Should compare days in the location of the calendar",
+			start: time.Date(2020, 5, 6, 15, 0, 0, 0, time.UTC),
+			end:   time.Date(2020, 5, 6, 15, 0, 0, 0, time.UTC),
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.CountBusinessDays(tt.start, tt.end); got != tt.want {
+				t.Errorf("CountBusinessDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"golang.org/x/xerrors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kioskTokenBytes = 32
+	punchCodeMACLen = 16
+)
+
+var (
+	ErrKioskNotFound         = xerrors.New("kiosk is not found")
+	ErrPunchCodeInvalid      = xerrors.New("punch code is invalid")
+	ErrPunchCodeExpired      = xerrors.New("punch code is expired")
+	ErrPunchCodeUserNotFound = xerrors.New("user of the punch code is not found")
+)
+
+// Kiosk is a shared terminal registered to punch for employees without a personal device.
+// It authenticates with its token, of which only the hash is kept.
+type Kiosk struct {
+	ID         int64
+	TenantID   int64
+	Name       string
+	TokenHash  string
+	LastUsedAt time.Time
+	CreatedAt  time.Time `xorm:"created"`
+	UpdatedAt  time.Time `xorm:"updated"`
+}
+
+func (Kiosk) TableName() string {
+	return "kiosks"
+}
+
+func (k *Kiosk) Validate() error {
+	if k.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	return nil
+}
+
+// NewKioskToken returns a new random kiosk token and its hash.
+func NewKioskToken() (string, string, error) {
+	b := make([]byte, kioskTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(b)
+	return token, HashKioskToken(token), nil
+}
+
+func HashKioskToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// PunchCode is a personal code shown as a QR code or printed on a badge, to punch at a kiosk until it expires.
+type PunchCode struct {
+	UserID    string
+	Code      string
+	ExpiresAt time.Time
+}
+
+// PunchCodeSigner issues and verifies punch codes.
+// A code is the user ID and its expiry signed with the secret, so that it can not be forged
+// and rotates every time one is issued.
+type PunchCodeSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewPunchCodeSigner(secret []byte, ttl time.Duration) *PunchCodeSigner {
+	return &PunchCodeSigner{
+		secret: secret,
+		ttl:    ttl,
+	}
+}
+
+// Issue returns a code of the user valid from now for the lifetime of the signer.
+func (s *PunchCodeSigner) Issue(userID string, now time.Time) *PunchCode {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(userID)) + "." + strconv.FormatInt(expiresAt.Unix(), 36)
+	return &PunchCode{
+		UserID:    userID,
+		Code:      payload + "." + s.sign(payload),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Verify returns the user ID of the code.
+// It returns ErrPunchCodeInvalid when the code was not issued by the signer and ErrPunchCodeExpired after its expiry.
+func (s *PunchCodeSigner) Verify(code string, now time.Time) (string, error) {
+	parts := strings.Split(code, ".")
+	if len(parts) != 3 {
+		return "", ErrPunchCodeInvalid
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return "", ErrPunchCodeInvalid
+	}
+	userID, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrPunchCodeInvalid
+	}
+	expiry, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil {
+		return "", ErrPunchCodeInvalid
+	}
+	if !now.Before(time.Unix(expiry, 0)) {
+		return "", ErrPunchCodeExpired
+	}
+	return string(userID), nil
+}
+
+func (s *PunchCodeSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:punchCodeMACLen])
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"testing"
+	"time"
+)
+
+func TestPunchCodeSigner_Verify(t *testing.T) {
+	now := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	signer := NewPunchCodeSigner([]byte("secret"), 5*time.Minute)
+	code := signer.Issue("user-1", now).Code
+
+	tests := []struct {
+		name    string
+		signer  *PunchCodeSigner
+		code    string
+		now     time.Time
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "Should return the user of the code",
+			signer: signer,
+			code:   code,
+			now:    now.Add(4 * time.Minute),
+			want:   "user-1",
+		},
+		{
+			name:    "Should not accept an expired code",
+			signer:  signer,
+			code:    code,
+			now:     now.Add(5 * time.Minute),
+			wantErr: ErrPunchCodeExpired,
+		},
+		{
+			name:    "Should not accept a code of another secret",
+			signer:  NewPunchCodeSigner([]byte("another"), 5*time.Minute),
+			code:    code,
+			now:     now,
+			wantErr: ErrPunchCodeInvalid,
+		},
+		{
+			name:    "Should not accept a code for another user",
+			signer:  signer,
+			code:    signer.Issue("user-2", now).Code[:len("dXNlci0y")] + code[len("dXNlci0x"):],
+			now:     now,
+			wantErr: ErrPunchCodeInvalid,
+		},
+		{
+			name:    "Should not accept a malformed code",
+			signer:  signer,
+			code:    "user-1",
+			now:     now,
+			wantErr: ErrPunchCodeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.signer.Verify(tt.code, tt.now)
+			if !xerrors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKioskToken(t *testing.T) {
+	token, hash, err := NewKioskToken()
+	if err != nil {
+		t.Fatalf("NewKioskToken() error = %v", err)
+	}
+	if hash != HashKioskToken(token) || hash == token {
+		t.Errorf("NewKioskToken() hash = %v, want the hash of the token", hash)
+	}
+	if another, _, _ := NewKioskToken(); another == token {
+		t.Errorf("NewKioskToken() returned the same token twice")
+	}
+}
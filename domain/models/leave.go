@@ -0,0 +1,372 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"sort"
+	"time"
+)
+
+type LeaveKind uint8
+
+const (
+	LeaveKindNone LeaveKind = iota
+	LeaveKindPaid
+	LeaveKindSick
+	LeaveKindSpecial
+	LeaveKindUnpaid
+)
+
+type LeaveUnit uint8
+
+const (
+	LeaveUnitNone LeaveUnit = iota
+	LeaveUnitDay
+	LeaveUnitHalfDay
+	LeaveUnitHour
+)
+
+type LeaveStatus uint8
+
+const (
+	LeaveStatusNone LeaveStatus = iota
+	LeaveStatusPending
+	LeaveStatusApproved
+	LeaveStatusRejected
+)
+
+const (
+	// StandardWorkingHoursPerDay converts leave days into hours for a user without a contract.
+	StandardWorkingHoursPerDay = 8
+	// MinLeaveAttendanceRate is the attendance rate required for a statutory paid leave grant.
+	MinLeaveAttendanceRate = 0.8
+	// LeaveExpiryYears is how long granted paid leave can be taken.
+	LeaveExpiryYears = 2
+)
+
+var (
+	ErrLeaveRequestNotFound     = xerrors.New("leave request is not found")
+	ErrLeaveRequestNotPending   = xerrors.New("leave request is already reviewed")
+	ErrLeaveRequestSelfReview   = xerrors.New("leave request cannot be reviewed by the requester")
+	ErrLeaveBalanceInsufficient = xerrors.New("paid leave balance is insufficient")
+	ErrLeaveHoursExceedDay      = xerrors.New("hours must be less than a day")
+)
+
+// statutoryLeaveDays is the paid leave granted by the Labor Standards Act
+// after 0.5, 1.5, 2.5 ... years of service.
+var statutoryLeaveDays = []float64{10, 11, 12, 14, 16, 18, 20}
+
+// proportionalLeaveDays is the paid leave granted in proportion to the workdays a week (比例付与)
+// to a user under 30 hours a week on at most four days, indexed like statutoryLeaveDays.
+var proportionalLeaveDays = map[int][]float64{
+	4: {7, 8, 9, 10, 12, 13, 15},
+	3: {5, 6, 6, 8, 9, 10, 11},
+	2: {3, 4, 4, 5, 6, 6, 7},
+	1: {1, 2, 2, 2, 3, 3, 3},
+}
+
+// LeaveRequest is a request to take leave from StartDate to EndDate.
+// Half-day and hourly leave are taken on StartDate only.
+// Days is fixed by the calendar when the request is made.
+type LeaveRequest struct {
+	ID            int64
+	TenantID      int64
+	UserID        string
+	LeaveKindID   uint8
+	LeaveUnitID   uint8
+	StartDate     time.Time
+	EndDate       time.Time
+	Hours         float64
+	Days          float64
+	Reason        string
+	LeaveStatusID uint8
+	ReviewerID    string
+	ReviewComment string
+	ReviewedAt    time.Time
+	CreatedAt     time.Time `xorm:"created"`
+	UpdatedAt     time.Time `xorm:"updated"`
+}
+
+func (LeaveRequest) TableName() string {
+	return "leave_requests"
+}
+
+func (r *LeaveRequest) Kind() LeaveKind {
+	return LeaveKind(r.LeaveKindID)
+}
+
+func (r *LeaveRequest) Unit() LeaveUnit {
+	return LeaveUnit(r.LeaveUnitID)
+}
+
+func (r *LeaveRequest) Status() LeaveStatus {
+	return LeaveStatus(r.LeaveStatusID)
+}
+
+func (r *LeaveRequest) Validate() error {
+	if r.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if r.Kind() == LeaveKindNone || r.Kind() > LeaveKindUnpaid {
+		return xerrors.New("leave kind is invalid")
+	}
+	if r.StartDate.IsZero() {
+		return xerrors.New("start date is empty")
+	}
+	switch r.Unit() {
+	case LeaveUnitDay:
+		if r.EndDate.Before(r.StartDate) {
+			return xerrors.New("end date is before start date")
+		}
+	case LeaveUnitHalfDay, LeaveUnitHour:
+		if !r.EndDate.Equal(r.StartDate) {
+			return xerrors.New("partial day leave must be taken on a single day")
+		}
+		if r.Unit() == LeaveUnitHour && r.Hours <= 0 {
+			return xerrors.New("hours must be positive")
+		}
+	default:
+		return xerrors.New("leave unit is invalid")
+	}
+	return nil
+}
+
+// ValidateHours checks that hourly leave is shorter than the daily hours of the contract in effect on the day.
+func (r *LeaveRequest) ValidateHours(contracts EmploymentContracts) error {
+	if r.Unit() == LeaveUnitHour && r.Hours >= contracts.DailyHoursAt(r.StartDate) {
+		return ErrLeaveHoursExceedDay
+	}
+	return nil
+}
+
+// CountDays returns the length of the leave in days. Only the workdays of the contracts of the user are counted,
+// and hourly leave is converted by the daily hours of the contract in effect on the day.
+func (r *LeaveRequest) CountDays(cal *Calendar, contracts EmploymentContracts) float64 {
+	switch r.Unit() {
+	case LeaveUnitDay:
+		return float64(contracts.CountWorkdays(cal, r.StartDate, r.EndDate))
+	case LeaveUnitHalfDay:
+		return 0.5
+	case LeaveUnitHour:
+		return r.Hours / contracts.DailyHoursAt(r.StartDate)
+	}
+	return 0
+}
+
+// DaysBetween returns the days of leave taken between the dates, both inclusive.
+func (r *LeaveRequest) DaysBetween(cal *Calendar, contracts EmploymentContracts, start, end time.Time) float64 {
+	if r.Unit() == LeaveUnitDay {
+		start, end, ok := r.overlap(start, end)
+		if !ok {
+			return 0
+		}
+		return float64(contracts.CountWorkdays(cal, start, end))
+	}
+	if r.StartDate.Before(start) || r.StartDate.After(end) {
+		return 0
+	}
+	return r.CountDays(cal, contracts)
+}
+
+// HoursBetween returns the hours of leave taken between the dates, both inclusive.
+// A day of leave counts as the daily hours of the contract in effect on each workday and hourly leave as the hours taken.
+func (r *LeaveRequest) HoursBetween(cal *Calendar, contracts EmploymentContracts, start, end time.Time) float64 {
+	if r.Unit() == LeaveUnitDay {
+		start, end, ok := r.overlap(start, end)
+		if !ok {
+			return 0
+		}
+		var total float64
+		last := cal.dayOf(end)
+		for d := cal.dayOf(start); !d.After(last); d = d.AddDate(0, 0, 1) {
+			if contracts.IsWorkday(cal, d) {
+				total += contracts.DailyHoursAt(d)
+			}
+		}
+		return total
+	}
+	if r.StartDate.Before(start) || r.StartDate.After(end) {
+		return 0
+	}
+	if r.Unit() == LeaveUnitHour {
+		return r.Hours
+	}
+	return r.CountDays(cal, contracts) * contracts.DailyHoursAt(r.StartDate)
+}
+
+// overlap returns the part of the leave between the dates, and false when there is none.
+func (r *LeaveRequest) overlap(start, end time.Time) (time.Time, time.Time, bool) {
+	if r.StartDate.After(start) {
+		start = r.StartDate
+	}
+	if r.EndDate.Before(end) {
+		end = r.EndDate
+	}
+	return start, end, !end.Before(start)
+}
+
+// Review moves a pending request to the approved or rejected status.
+func (r *LeaveRequest) Review(reviewerID string, status LeaveStatus, comment string, now time.Time) error {
+	if r.Status() != LeaveStatusPending {
+		return ErrLeaveRequestNotPending
+	}
+	if reviewerID == r.UserID {
+		return ErrLeaveRequestSelfReview
+	}
+	r.LeaveStatusID = uint8(status)
+	r.ReviewerID = reviewerID
+	r.ReviewComment = comment
+	r.ReviewedAt = now
+	return nil
+}
+
+// LeaveGrant is paid leave granted to a user, taken until it expires.
+type LeaveGrant struct {
+	ID        int64
+	TenantID  int64
+	UserID    string
+	GrantedAt time.Time
+	ExpiresAt time.Time
+	Days      float64
+	UsedDays  float64
+	CreatedAt time.Time `xorm:"created"`
+	UpdatedAt time.Time `xorm:"updated"`
+}
+
+func (LeaveGrant) TableName() string {
+	return "leave_grants"
+}
+
+func (g *LeaveGrant) Remaining() float64 {
+	return g.Days - g.UsedDays
+}
+
+func (g *LeaveGrant) IsActive(at time.Time) bool {
+	return !at.Before(g.GrantedAt) && at.Before(g.ExpiresAt)
+}
+
+type LeaveGrants []*LeaveGrant
+
+// Balance returns the paid leave days left at the time.
+func (grants LeaveGrants) Balance(at time.Time) float64 {
+	var total float64
+	for _, g := range grants {
+		if g.IsActive(at) {
+			total += g.Remaining()
+		}
+	}
+	return total
+}
+
+// Consume uses the days from the grants expiring first and returns the grants it changed.
+// Nothing is changed when the balance is insufficient.
+func (grants LeaveGrants) Consume(days float64, at time.Time) (LeaveGrants, error) {
+	if grants.Balance(at) < days {
+		return nil, ErrLeaveBalanceInsufficient
+	}
+	active := make(LeaveGrants, 0)
+	for _, g := range grants {
+		if g.IsActive(at) && g.Remaining() > 0 {
+			active = append(active, g)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].ExpiresAt.Before(active[j].ExpiresAt)
+	})
+
+	changed := make(LeaveGrants, 0)
+	for _, g := range active {
+		if days <= 0 {
+			break
+		}
+		used := g.Remaining()
+		if used > days {
+			used = days
+		}
+		g.UsedDays += used
+		days -= used
+		changed = append(changed, g)
+	}
+	return changed, nil
+}
+
+// HasGrantedAt reports whether a grant was given on the date.
+func (grants LeaveGrants) HasGrantedAt(date time.Time) bool {
+	for _, g := range grants {
+		if g.GrantedAt.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeaveGrantDates returns the statutory grant dates until the time:
+// six months after hire and every year after that.
+func LeaveGrantDates(hiredAt, until time.Time) []time.Time {
+	dates := make([]time.Time, 0)
+	if hiredAt.IsZero() {
+		return dates
+	}
+	for d := hiredAt.AddDate(0, 6, 0); !d.After(until); d = d.AddDate(1, 0, 0) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// NewStatutoryLeaveGrant returns the paid leave granted on the date for a user hired at hiredAt.
+// A user whose contract on the date is eligible for the proportional grant gets the days of its workdays a week.
+func NewStatutoryLeaveGrant(userID string, contracts EmploymentContracts, hiredAt, grantedAt time.Time) *LeaveGrant {
+	table := statutoryLeaveDays
+	if c := contracts.At(grantedAt); c != nil && c.HasProportionalLeave() {
+		table = proportionalLeaveDays[Workdays(c.Workdays).Count()]
+	}
+	years := 0
+	for d := hiredAt.AddDate(1, 6, 0); !d.After(grantedAt); d = d.AddDate(1, 0, 0) {
+		years++
+	}
+	if years >= len(table) {
+		years = len(table) - 1
+	}
+	return &LeaveGrant{
+		UserID:    userID,
+		GrantedAt: grantedAt,
+		ExpiresAt: grantedAt.AddDate(LeaveExpiryYears, 0, 0),
+		Days:      table[years],
+	}
+}
+
+// CountsAsWorked reports whether approved leave of the kind counts toward the worked hours.
+// Unpaid leave is excused but not credited.
+func (k LeaveKind) CountsAsWorked() bool {
+	return k == LeaveKindPaid || k == LeaveKindSick || k == LeaveKindSpecial
+}
+
+// ConsumesBalance reports whether the leave is taken from the paid leave balance.
+func (k LeaveKind) ConsumesBalance() bool {
+	return k == LeaveKindPaid
+}
+
+func (k LeaveKind) String() string {
+	switch k {
+	case LeaveKindPaid:
+		return "有給休暇"
+	case LeaveKindSick:
+		return "病気休暇"
+	case LeaveKindSpecial:
+		return "特別休暇"
+	case LeaveKindUnpaid:
+		return "無給休暇"
+	}
+	return "不明"
+}
+
+func (s LeaveStatus) String() string {
+	switch s {
+	case LeaveStatusPending:
+		return "申請中"
+	case LeaveStatusApproved:
+		return "承認済"
+	case LeaveStatusRejected:
+		return "却下"
+	}
+	return "不明"
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLeaveRequest_CountDays(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	partTime := EmploymentContracts{
+		{WeeklyHours: 18, Workdays: uint8(NewWorkdays(time.Monday, time.Wednesday, time.Friday)), StartedAt: date(2019, 4, 1)},
+	}
+	tests := []struct {
+		name      string
+		request   *LeaveRequest
+		contracts EmploymentContracts
+		want      float64
+		wantErr   bool
+	}{
+		{
+			name:    "Should count business days",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitDay), StartDate: date(2020, 5, 15), EndDate: date(2020, 5, 18)},
+			want:    2,
+		},
+		{
+			name:    "Should count half day",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHalfDay), StartDate: date(2020, 5, 15), EndDate: date(2020, 5, 15)},
+			want:    0.5,
+		},
+		{
+			name:    "Should count hours",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHour), StartDate: date(2020, 5, 15), EndDate: date(2020, 5, 15), Hours: 2},
+			want:    0.25,
+		},
+		{
+			name:    "Should not count weekend",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitDay), StartDate: date(2020, 5, 16), EndDate: date(2020, 5, 17)},
+			want:    0,
+		},
+		{
+			name:    "Should not count national holidays",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitDay), StartDate: date(2020, 5, 4), EndDate: date(2020, 5, 7)},
+			want:    1,
+		},
+		{
+			name:      "Should count the workdays of the contract",
+			request:   &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitDay), StartDate: date(2020, 6, 1), EndDate: date(2020, 6, 7)},
+			contracts: partTime,
+			want:      3,
+		},
+		{
+			name:      "Should count hours by the daily hours of the contract",
+			request:   &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHour), StartDate: date(2020, 6, 1), EndDate: date(2020, 6, 1), Hours: 3},
+			contracts: partTime,
+			want:      0.5,
+		},
+		{
+			name:    "Should not validate half day over multiple days",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHalfDay), StartDate: date(2020, 5, 14), EndDate: date(2020, 5, 15)},
+			wantErr: true,
+		},
+		{
+			name:    "Should not validate a whole day of hours",
+			request: &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHour), StartDate: date(2020, 5, 15), EndDate: date(2020, 5, 15), Hours: 8},
+			wantErr: true,
+		},
+		{
+			name:      "Should not validate a whole day of hours of the contract",
+			request:   &LeaveRequest{UserID: "user", LeaveKindID: uint8(LeaveKindPaid), LeaveUnitID: uint8(LeaveUnitHour), StartDate: date(2020, 6, 1), EndDate: date(2020, 6, 1), Hours: 6},
+			contracts: partTime,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if err == nil {
+				err = tt.request.ValidateHours(tt.contracts)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := tt.request.CountDays(cal, tt.contracts); got != tt.want {
+				t.Errorf("CountDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaveRequest_HoursBetween(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	partTime := EmploymentContracts{
+		{WeeklyHours: 18, Workdays: uint8(NewWorkdays(time.Monday, time.Wednesday, time.Friday)), StartedAt: date(2019, 4, 1)},
+	}
+	request := &LeaveRequest{LeaveUnitID: uint8(LeaveUnitDay), StartDate: date(2020, 4, 27), EndDate: date(2020, 5, 8)}
+	tests := []struct {
+		name      string
+		contracts EmploymentContracts
+		start     time.Time
+		end       time.Time
+		want      float64
+	}{
+		{name: "Should count the business days in the range", start: date(2020, 5, 1), end: date(2020, 5, 31), want: 24},
+		{name: "Should count the workdays of the contract", contracts: partTime, start: date(2020, 5, 1), end: date(2020, 5, 31), want: 12},
+		{name: "Should not count outside the range", start: date(2020, 6, 1), end: date(2020, 6, 30), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := request.HoursBetween(cal, tt.contracts, tt.start, tt.end); got != tt.want {
+				t.Errorf("HoursBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatutoryLeaveGrant(t *testing.T) {
+	hiredAt := date(2018, 4, 1)
+	threeDays := EmploymentContracts{
+		{WeeklyHours: 18, Workdays: uint8(NewWorkdays(time.Monday, time.Wednesday, time.Friday)), StartedAt: hiredAt},
+	}
+	shortFourDays := EmploymentContracts{
+		{WeeklyHours: 24, Workdays: uint8(NewWorkdays(time.Monday, time.Tuesday, time.Thursday, time.Friday)), StartedAt: hiredAt},
+	}
+	longFourDays := EmploymentContracts{
+		{WeeklyHours: 32, Workdays: uint8(NewWorkdays(time.Monday, time.Tuesday, time.Thursday, time.Friday)), StartedAt: hiredAt},
+	}
+	tests := []struct {
+		name      string
+		contracts EmploymentContracts
+		grantedAt time.Time
+		want      float64
+	}{
+		{name: "Should grant 10 days after half a year", grantedAt: date(2018, 10, 1), want: 10},
+		{name: "Should grant 11 days after one and a half years", grantedAt: date(2019, 10, 1), want: 11},
+		{name: "Should grant 14 days after three and a half years", grantedAt: date(2021, 10, 1), want: 14},
+		{name: "Should grant at most 20 days", grantedAt: date(2030, 10, 1), want: 20},
+		{name: "Should grant in proportion to three days a week", contracts: threeDays, grantedAt: date(2018, 10, 1), want: 5},
+		{name: "Should grant in proportion to four days a week", contracts: shortFourDays, grantedAt: date(2021, 10, 1), want: 10},
+		{name: "Should grant at most 11 days for three days a week", contracts: threeDays, grantedAt: date(2030, 10, 1), want: 11},
+		{name: "Should grant in full for 30 hours or more a week", contracts: longFourDays, grantedAt: date(2018, 10, 1), want: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewStatutoryLeaveGrant("user", tt.contracts, hiredAt, tt.grantedAt)
+			if got.Days != tt.want {
+				t.Errorf("NewStatutoryLeaveGrant() days = %v, want %v", got.Days, tt.want)
+			}
+			if want := tt.grantedAt.AddDate(2, 0, 0); !got.ExpiresAt.Equal(want) {
+				t.Errorf("NewStatutoryLeaveGrant() expires at = %v, want %v", got.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+func TestLeaveGrantDates(t *testing.T) {
+	got := LeaveGrantDates(date(2018, 4, 1), date(2020, 5, 1))
+	want := []time.Time{date(2018, 10, 1), date(2019, 10, 1)}
+	if len(got) != len(want) {
+		t.Errorf("LeaveGrantDates() = %v, want %v", got, want)
+		return
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("LeaveGrantDates() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLeaveGrants_Consume(t *testing.T) {
+	now := date(2020, 5, 1)
+	newGrants := func() LeaveGrants {
+		return LeaveGrants{
+			{ID: 1, GrantedAt: date(2018, 4, 1), ExpiresAt: date(2020, 4, 1), Days: 10},
+			{ID: 2, GrantedAt: date(2019, 4, 1), ExpiresAt: date(2021, 4, 1), Days: 11, UsedDays: 9},
+			{ID: 3, GrantedAt: date(2020, 4, 1), ExpiresAt: date(2022, 4, 1), Days: 12},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		days        float64
+		wantUsed    []float64
+		wantBalance float64
+		wantErr     error
+	}{
+		{name: "Should use grant expiring first", days: 1, wantUsed: []float64{0, 10, 0}, wantBalance: 13},
+		{name: "Should carry over to next grant", days: 3.5, wantUsed: []float64{0, 11, 1.5}, wantBalance: 10.5},
+		{name: "Should not use expired grant", days: 15, wantUsed: []float64{0, 9, 0}, wantBalance: 14, wantErr: ErrLeaveBalanceInsufficient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants := newGrants()
+			if _, err := grants.Consume(tt.days, now); err != tt.wantErr {
+				t.Errorf("Consume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i, g := range grants {
+				if g.UsedDays != tt.wantUsed[i] {
+					t.Errorf("Consume() used days of grant %d = %v, want %v", g.ID, g.UsedDays, tt.wantUsed[i])
+				}
+			}
+			if got := grants.Balance(now); got != tt.wantBalance {
+				t.Errorf("Balance() = %v, want %v", got, tt.wantBalance)
+			}
+		})
+	}
+}
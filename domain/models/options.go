@@ -2,6 +2,7 @@ package models
 
 import (
 	"golang.org/x/xerrors"
+	"time"
 	"xorm.io/xorm"
 )
 
@@ -22,9 +23,12 @@ func (opt *DefaultSearchOption) SetPaginatedSession(eng *xorm.Session) *xorm.Ses
 	return eng.Limit(int(p.Limit), int(page))
 }
 
+// GetAttendancesParameters selects the attendances of the user in the month.
+// With a work location, only the attendances with a session worked there are selected.
 type GetAttendancesParameters struct {
-	UserID string
-	Month  int
+	UserID       string
+	Month        int
+	WorkLocation WorkLocation
 }
 
 func (p GetAttendancesParameters) Validate() error {
@@ -34,6 +38,9 @@ func (p GetAttendancesParameters) Validate() error {
 	if p.Month == 0 {
 		return xerrors.New("month is zero")
 	}
+	if p.WorkLocation > WorkLocationClientSite {
+		return xerrors.New("work location is invalid")
+	}
 	return nil
 }
 
@@ -41,13 +48,141 @@ type GetAttendanceSummaryParameters struct {
 	UserID string
 }
 
+type GetAttendanceHistoryParameters struct {
+	ID     int64
+	UserID string
+}
+
+type GetWorkBreakdownParameters struct {
+	UserID string
+	Month  int
+}
+
+func (p GetWorkBreakdownParameters) Validate() error {
+	if p.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if p.Month == 0 {
+		return xerrors.New("month is zero")
+	}
+	return nil
+}
+
+type GetFlextimeSummaryParameters struct {
+	UserID string
+	Month  int
+}
+
+func (p GetFlextimeSummaryParameters) Validate() error {
+	if p.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if p.Month == 0 {
+		return xerrors.New("month is zero")
+	}
+	return nil
+}
+
+type GetShiftsParameters struct {
+	UserID string
+	Month  int
+}
+
+func (p GetShiftsParameters) Validate() error {
+	if p.Month == 0 {
+		return xerrors.New("month is zero")
+	}
+	return nil
+}
+
+type GetAnomaliesParameters struct {
+	UserID string
+	Month  int
+}
+
+func (p GetAnomaliesParameters) Validate() error {
+	if p.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if p.Month == 0 {
+		return xerrors.New("month is zero")
+	}
+	return nil
+}
+
 type GetAttendancesResults struct {
 	MaxCnt      int64
 	Attendances []*Attendance
+	Shifts      []*ShiftCheck
 }
 
 type GetAttendanceSummaryResults struct {
-	LatestAttendance Attendance
-	TotalHours       float64
-	RequiredHours    float64
+	LatestAttendance  Attendance
+	TotalHours        float64
+	RoundedTotalHours float64
+	LeaveHours        float64
+	RequiredHours     float64
+	WorkLocationDays  WorkLocationDays
+}
+
+type GetAttendanceHistoryResults struct {
+	Attendance *Attendance
+	Times      []*AttendanceTime
+}
+
+type GetWorkBreakdownResults struct {
+	Days  DailyWorkBreakdowns
+	Total WorkBreakdown
+}
+
+type GetFlextimeSummaryResults struct {
+	Rule       *FlextimeRule
+	Settlement FlextimeSettlement
+	Days       []*FlextimeDay
+}
+
+// GetCorrectionRequestsParameters filters the requests.
+// With ReviewerID, the requests are limited to the ones the reviewer can review.
+type GetCorrectionRequestsParameters struct {
+	UserID        string
+	UserIDs       []string
+	ExcludeUserID string
+	ReviewerID    string
+	Status        CorrectionStatus
+}
+
+type ReviewCorrectionRequestParameters struct {
+	ID         int64
+	ReviewerID string
+	Comment    string
+}
+
+// GetLeaveRequestsParameters filters the requests.
+// With ReviewerID, the requests are limited to the ones the reviewer can review.
+type GetLeaveRequestsParameters struct {
+	UserID        string
+	UserIDs       []string
+	ExcludeUserID string
+	ReviewerID    string
+	Status        LeaveStatus
+	From          time.Time
+	To            time.Time
+}
+
+type ReviewLeaveRequestParameters struct {
+	ID         int64
+	ReviewerID string
+	Comment    string
+}
+
+type GetLeaveBalanceResults struct {
+	Balance float64
+	Grants  LeaveGrants
+}
+
+// GetAssignmentsParameters filters the assignments by any of the user, the team and the manager.
+type GetAssignmentsParameters struct {
+	UserID    string
+	TeamID    int64
+	ManagerID string
 }
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+var (
+	ErrDepartmentNotFound   = xerrors.New("department is not found")
+	ErrDepartmentNotEmpty   = xerrors.New("department has departments or teams")
+	ErrDepartmentCycle      = xerrors.New("department can not be under itself")
+	ErrTeamNotFound         = xerrors.New("team is not found")
+	ErrTeamNotEmpty         = xerrors.New("team has members")
+	ErrAssignmentOverlapped = xerrors.New("assignment must start after the current assignment")
+	ErrReportingCycle       = xerrors.New("manager can not report to the user")
+)
+
+// Department is a node of the organisation tree. A zero ParentID is a top department.
+type Department struct {
+	ID        int64
+	TenantID  int64
+	ParentID  int64
+	Name      string
+	CreatedAt time.Time `xorm:"created"`
+	UpdatedAt time.Time `xorm:"updated"`
+}
+
+func (Department) TableName() string {
+	return "departments"
+}
+
+func (d *Department) Validate() error {
+	if d.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	if d.ID != 0 && d.ID == d.ParentID {
+		return ErrDepartmentCycle
+	}
+	return nil
+}
+
+type Departments []*Department
+
+// IsUnder reports whether the department of id is the department of ancestorID or below it.
+func (departments Departments) IsUnder(id, ancestorID int64) bool {
+	parents := make(map[int64]int64)
+	for _, d := range departments {
+		parents[d.ID] = d.ParentID
+	}
+	// The tree is walked at most once through every department, in case it already has a cycle.
+	for i := 0; id != 0 && i <= len(departments); i++ {
+		if id == ancestorID {
+			return true
+		}
+		id = parents[id]
+	}
+	return false
+}
+
+// Team is a group of users in a department.
+type Team struct {
+	ID           int64
+	TenantID     int64
+	DepartmentID int64
+	Name         string
+	CreatedAt    time.Time `xorm:"created"`
+	UpdatedAt    time.Time `xorm:"updated"`
+}
+
+func (Team) TableName() string {
+	return "teams"
+}
+
+func (t *Team) Validate() error {
+	if t.DepartmentID == 0 {
+		return xerrors.New("department id is empty")
+	}
+	if t.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	return nil
+}
+
+// Assignment is the team of a user and the manager it reports to from StartedAt until FinishedAt.
+// A zero FinishedAt means the assignment is still in effect, a zero TeamID or empty ManagerID that there is none.
+type Assignment struct {
+	ID         int64
+	TenantID   int64
+	UserID     string
+	TeamID     int64
+	ManagerID  string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	CreatedAt  time.Time `xorm:"created"`
+	UpdatedAt  time.Time `xorm:"updated"`
+}
+
+func (Assignment) TableName() string {
+	return "user_assignments"
+}
+
+func (a *Assignment) Validate() error {
+	if a.UserID == "" {
+		return xerrors.New("user id is empty")
+	}
+	if a.UserID == a.ManagerID {
+		return ErrReportingCycle
+	}
+	if a.StartedAt.IsZero() {
+		return xerrors.New("started at is empty")
+	}
+	return nil
+}
+
+func (a *Assignment) IsEffective(at time.Time) bool {
+	if at.Before(a.StartedAt) {
+		return false
+	}
+	return a.FinishedAt.IsZero() || at.Before(a.FinishedAt)
+}
+
+type Assignments []*Assignment
+
+// At returns the assignment in effect at the time, or nil. The assignments are the history of a user.
+func (assignments Assignments) At(at time.Time) *Assignment {
+	for _, a := range assignments {
+		if a.IsEffective(at) {
+			return a
+		}
+	}
+	return nil
+}
+
+// Effective returns the assignments in effect at the time, one per user.
+func (assignments Assignments) Effective(at time.Time) Assignments {
+	effective := make(Assignments, 0)
+	for _, a := range assignments {
+		if a.IsEffective(at) {
+			effective = append(effective, a)
+		}
+	}
+	return effective
+}
+
+func (assignments Assignments) UserIDs() []string {
+	ids := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		ids = append(ids, a.UserID)
+	}
+	return ids
+}
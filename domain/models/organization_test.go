@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDepartments_IsUnder(t *testing.T) {
+	departments := Departments{
+		{ID: 1, Name: "本社"},
+		{ID: 2, ParentID: 1, Name: "開発部"},
+		{ID: 3, ParentID: 2, Name: "基盤課"},
+		{ID: 4, ParentID: 1, Name: "人事部"},
+	}
+	tests := []struct {
+		name       string
+		id         int64
+		ancestorID int64
+		want       bool
+	}{
+		{
+			name:       "Should be under itself",
+			id:         2,
+			ancestorID: 2,
+			want:       true,
+		},
+		{
+			name:       "Should be under the parent of the parent",
+			id:         3,
+			ancestorID: 1,
+			want:       true,
+		},
+		{
+			name:       "Should not be under a sibling",
+			id:         3,
+			ancestorID: 4,
+			want:       false,
+		},
+		{
+			name:       "Should not be under a child",
+			id:         2,
+			ancestorID: 3,
+			want:       false,
+		},
+		{
+			name:       "Should not put the top under anything",
+			id:         0,
+			ancestorID: 1,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := departments.IsUnder(tt.id, tt.ancestorID); got != tt.want {
+				t.Errorf("IsUnder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignments_At(t *testing.T) {
+	april := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	assignments := Assignments{
+		{ID: 1, UserID: "user", ManagerID: "manager", StartedAt: april, FinishedAt: june},
+		{ID: 2, UserID: "user", ManagerID: "head", StartedAt: june},
+	}
+	tests := []struct {
+		name   string
+		at     time.Time
+		wantID int64
+	}{
+		{
+			name:   "Should have no assignment before the first",
+			at:     april.AddDate(0, 0, -1),
+			wantID: 0,
+		},
+		{
+			name:   "Should be the first assignment on its start",
+			at:     april,
+			wantID: 1,
+		},
+		{
+			name:   "Should be the next assignment from its start",
+			at:     june,
+			wantID: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotID int64
+			if got := assignments.At(tt.at); got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("At() = %v, want %v", gotID, tt.wantID)
+			}
+		})
+	}
+}
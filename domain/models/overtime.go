@@ -0,0 +1,250 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"sort"
+	"time"
+)
+
+const (
+	// StatutoryDailyWorkLimit is the working time per day allowed by the Labor Standards Act without overtime.
+	StatutoryDailyWorkLimit = 8 * time.Hour
+	// StatutoryWeeklyWorkLimit is the working time per week allowed by the Labor Standards Act without overtime.
+	StatutoryWeeklyWorkLimit = 40 * time.Hour
+	// DefaultStatutoryHolidayWeekday is the weekly day off required by the Labor Standards Act (法定休日),
+	// for a company that has not set its own in its work week rule.
+	DefaultStatutoryHolidayWeekday = time.Sunday
+
+	lateNightStartHour = 22
+	lateNightEndHour   = 5
+)
+
+// WorkWeekRule is the statutory weekly day off of the company (法定休日), which the employment rules may set to any weekday.
+// Weeks start on this day for the weekly limit.
+type WorkWeekRule struct {
+	ID                      int64
+	TenantID                int64
+	StatutoryHolidayWeekday uint8
+	CreatedAt               time.Time `xorm:"created"`
+	UpdatedAt               time.Time `xorm:"updated"`
+}
+
+func (WorkWeekRule) TableName() string {
+	return "work_week_rules"
+}
+
+func DefaultWorkWeekRule() *WorkWeekRule {
+	return &WorkWeekRule{StatutoryHolidayWeekday: uint8(DefaultStatutoryHolidayWeekday)}
+}
+
+func (r *WorkWeekRule) HolidayWeekday() time.Weekday {
+	return time.Weekday(r.StatutoryHolidayWeekday)
+}
+
+func (r *WorkWeekRule) Validate() error {
+	if r.HolidayWeekday() > time.Saturday {
+		return xerrors.New("statutory holiday weekday is invalid")
+	}
+	return nil
+}
+
+// WorkBreakdown splits working time into the buckets used by payroll.
+// Statutory, LegalOvertime and StatutoryHoliday add up to the time worked.
+// LateNight and NonStatutoryHoliday are premiums paid on top and overlap the other buckets.
+type WorkBreakdown struct {
+	// Statutory is the time worked within 8 hours a day and 40 hours a week.
+	Statutory time.Duration
+	// LegalOvertime is the time worked over 8 hours a day or 40 hours a week.
+	LegalOvertime time.Duration
+	// LateNight is the time worked between 22:00 and 5:00.
+	LateNight time.Duration
+	// StatutoryHoliday is the time worked on the statutory weekly day off.
+	StatutoryHoliday time.Duration
+	// NonStatutoryHoliday is the time worked on the other days off: the weekend, national holidays and company closures.
+	NonStatutoryHoliday time.Duration
+}
+
+func (b *WorkBreakdown) Add(other WorkBreakdown) {
+	b.Statutory += other.Statutory
+	b.LegalOvertime += other.LegalOvertime
+	b.LateNight += other.LateNight
+	b.StatutoryHoliday += other.StatutoryHoliday
+	b.NonStatutoryHoliday += other.NonStatutoryHoliday
+}
+
+// Worked returns the total time worked.
+func (b WorkBreakdown) Worked() time.Duration {
+	return b.Statutory + b.LegalOvertime + b.StatutoryHoliday
+}
+
+// DailyWorkBreakdown is the breakdown of the work of a day.
+type DailyWorkBreakdown struct {
+	Date time.Time
+	WorkBreakdown
+}
+
+type DailyWorkBreakdowns []*DailyWorkBreakdown
+
+func (days DailyWorkBreakdowns) Total() WorkBreakdown {
+	var total WorkBreakdown
+	for _, d := range days {
+		total.Add(d.WorkBreakdown)
+	}
+	return total
+}
+
+// BreakdownWork splits the work of the attendances into payroll buckets for each day between the days of start and end.
+// The work is counted from the rounded punches, as paid by payroll (see AttendanceTime.PayrollAt).
+// A shift belongs to the day it was clocked in on, including the hours after midnight.
+// The weekly limit counts from the start of the week, so attendances earlier in the week of start must be given too.
+func BreakdownWork(cal *Calendar, rule *WorkWeekRule, attendances Attendances, start, end time.Time) DailyWorkBreakdowns {
+	return breakdownWork(cal, rule, attendances, start, end, payrollTime)
+}
+
+// BreakdownActualWork splits the work as BreakdownWork but counted from the raw punches,
+// for the limits of the overtime agreement which apply to the time actually worked whatever payroll rounds.
+func BreakdownActualWork(cal *Calendar, rule *WorkWeekRule, attendances Attendances, start, end time.Time) DailyWorkBreakdowns {
+	return breakdownWork(cal, rule, attendances, start, end, rawTime)
+}
+
+func breakdownWork(cal *Calendar, rule *WorkWeekRule, attendances Attendances, start, end time.Time, at func(t *AttendanceTime) time.Time) DailyWorkBreakdowns {
+	holiday := rule.HolidayWeekday()
+	worked := make(map[time.Time]time.Duration)
+	lateNight := make(map[time.Time]time.Duration)
+	dates := make([]time.Time, 0)
+	for _, a := range attendances {
+		d := cal.dayOf(a.AttendedAt)
+		if _, ok := worked[d]; !ok {
+			dates = append(dates, d)
+		}
+		worked[d] += a.workDuration(at)
+		lateNight[d] += cal.lateNightDuration(a.workIntervals(at))
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	first := cal.dayOf(start)
+	last := cal.dayOf(end)
+	days := make(DailyWorkBreakdowns, 0)
+	var (
+		week       time.Time
+		weeklyWork time.Duration
+	)
+	for _, d := range dates {
+		if weekStart := weekStartOf(d, holiday); !weekStart.Equal(week) {
+			week = weekStart
+			weeklyWork = 0
+		}
+
+		day := &DailyWorkBreakdown{Date: d}
+		day.LateNight = lateNight[d]
+		if d.Weekday() == holiday {
+			day.StatutoryHoliday = worked[d]
+		} else {
+			statutory := worked[d]
+			if statutory > StatutoryDailyWorkLimit {
+				statutory = StatutoryDailyWorkLimit
+			}
+			if remaining := StatutoryWeeklyWorkLimit - weeklyWork; statutory > remaining {
+				statutory = remaining
+			}
+			weeklyWork += statutory
+			day.Statutory = statutory
+			day.LegalOvertime = worked[d] - statutory
+			if !cal.IsBusinessDay(d) {
+				day.NonStatutoryHoliday = worked[d]
+			}
+		}
+
+		if !d.Before(first) && !d.After(last) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// WeekStartOf returns the first day of the week of the day, counted from the statutory day off of the rule.
+func WeekStartOf(cal *Calendar, rule *WorkWeekRule, d time.Time) time.Time {
+	return weekStartOf(cal.dayOf(d), rule.HolidayWeekday())
+}
+
+func weekStartOf(day time.Time, holiday time.Weekday) time.Time {
+	offset := (int(day.Weekday()) - int(holiday) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// lateNightDuration returns the part of the intervals between 22:00 and 5:00 in the location of the calendar.
+func (c *Calendar) lateNightDuration(intervals []timeRange) time.Duration {
+	var total time.Duration
+	for _, r := range intervals {
+		last := c.dayOf(r.end)
+		for d := c.dayOf(r.start).AddDate(0, 0, -1); !d.After(last); d = d.AddDate(0, 0, 1) {
+			night := timeRange{
+				start: time.Date(d.Year(), d.Month(), d.Day(), lateNightStartHour, 0, 0, 0, c.loc),
+				end:   time.Date(d.Year(), d.Month(), d.Day()+1, lateNightEndHour, 0, 0, 0, c.loc),
+			}
+			total += r.overlap(night)
+		}
+	}
+	return total
+}
+
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func (r timeRange) overlap(other timeRange) time.Duration {
+	start := r.start
+	if other.start.After(start) {
+		start = other.start
+	}
+	end := r.end
+	if other.end.Before(end) {
+		end = other.end
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// workIntervals returns the closed sessions of the attendance with the breaks cut out, at the times given by at.
+// A break that was never ended lasts until the clock-out of its session, as in BreakDuration.
+func (a *Attendance) workIntervals(at func(t *AttendanceTime) time.Time) []timeRange {
+	intervals := make([]timeRange, 0)
+	for _, s := range a.Sessions {
+		if !s.IsClosed() {
+			continue
+		}
+		out := at(s.ClockedOut)
+		ranges := []timeRange{{start: at(s.ClockedIn), end: out}}
+		for _, b := range s.Breaks {
+			br := timeRange{start: at(b.Start), end: out}
+			if b.End != nil {
+				br.end = at(b.End)
+			}
+			ranges = subtractRange(ranges, br)
+		}
+		intervals = append(intervals, ranges...)
+	}
+	return intervals
+}
+
+func subtractRange(ranges []timeRange, cut timeRange) []timeRange {
+	result := make([]timeRange, 0, len(ranges)+1)
+	for _, r := range ranges {
+		if r.overlap(cut) == 0 {
+			result = append(result, r)
+			continue
+		}
+		if cut.start.After(r.start) {
+			result = append(result, timeRange{start: r.start, end: cut.start})
+		}
+		if cut.end.Before(r.end) {
+			result = append(result, timeRange{start: cut.end, end: r.end})
+		}
+	}
+	return result
+}
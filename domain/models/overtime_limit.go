@@ -0,0 +1,304 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// OvertimeLimitKind is a limit of the overtime agreement under Article 36 of the Labor Standards Act (36協定).
+type OvertimeLimitKind uint8
+
+const (
+	OvertimeLimitKindNone OvertimeLimitKind = iota
+	// OvertimeLimitKindMonthly is the standard limit of overtime in a month.
+	OvertimeLimitKindMonthly
+	// OvertimeLimitKindAnnual is the standard limit of overtime in an agreement year.
+	OvertimeLimitKindAnnual
+	// OvertimeLimitKindSpecialMonthly is the limit of overtime and holiday work in a month under the special clause.
+	OvertimeLimitKindSpecialMonthly
+	// OvertimeLimitKindSpecialAverage is the limit of the average of overtime and holiday work over any 2 to 6 months.
+	OvertimeLimitKindSpecialAverage
+	// OvertimeLimitKindSpecialAnnual is the limit of overtime in an agreement year under the special clause.
+	OvertimeLimitKindSpecialAnnual
+	// OvertimeLimitKindMonthsOverMonthly is the limit of months over the standard monthly limit in an agreement year.
+	OvertimeLimitKindMonthsOverMonthly
+)
+
+type OvertimeAlertLevel uint8
+
+const (
+	OvertimeAlertLevelNone OvertimeAlertLevel = iota
+	OvertimeAlertLevelWarning
+	OvertimeAlertLevelExceeded
+)
+
+const (
+	DefaultMonthlyOvertimeLimit     = 45 * time.Hour
+	DefaultAnnualOvertimeLimit      = 360 * time.Hour
+	SpecialMonthlyOvertimeLimit     = 100 * time.Hour
+	SpecialAverageOvertimeLimit     = 80 * time.Hour
+	SpecialAnnualOvertimeLimit      = 720 * time.Hour
+	MaxMonthsOverMonthlyLimit       = 6
+	DefaultOvertimeWarningRate      = 0.8
+	DefaultOvertimeAgreementStartAt = time.April
+
+	// overtimeAverageMonths is the longest period the special average limit is checked over.
+	overtimeAverageMonths = 6
+)
+
+// OvertimeLimits is the overtime agreement of the company.
+// The monthly and annual limits are agreed by the company; the special clause limits are set by law.
+type OvertimeLimits struct {
+	Monthly              time.Duration
+	Annual               time.Duration
+	SpecialMonthly       time.Duration
+	SpecialAverage       time.Duration
+	SpecialAnnual        time.Duration
+	MaxMonthsOverMonthly int
+	// WarningRate is the ratio of a limit at which a warning is raised.
+	WarningRate float64
+	// StartMonth is the first month of the agreement year.
+	StartMonth time.Month
+}
+
+func DefaultOvertimeLimits() OvertimeLimits {
+	return OvertimeLimits{
+		Monthly:              DefaultMonthlyOvertimeLimit,
+		Annual:               DefaultAnnualOvertimeLimit,
+		SpecialMonthly:       SpecialMonthlyOvertimeLimit,
+		SpecialAverage:       SpecialAverageOvertimeLimit,
+		SpecialAnnual:        SpecialAnnualOvertimeLimit,
+		MaxMonthsOverMonthly: MaxMonthsOverMonthlyLimit,
+		WarningRate:          DefaultOvertimeWarningRate,
+		StartMonth:           DefaultOvertimeAgreementStartAt,
+	}
+}
+
+// YearStartOf returns the first month of the agreement year of the month.
+func (l OvertimeLimits) YearStartOf(month time.Time) time.Time {
+	year := month.Year()
+	if month.Month() < l.StartMonth {
+		year--
+	}
+	return time.Date(year, l.StartMonth, 1, 0, 0, 0, 0, month.Location())
+}
+
+// CheckedFrom returns the first month the limits at the month are checked over: the start of the agreement year,
+// or five months before the month when earlier, as the special average looks back across the year start.
+// The annual limits only count the months of the agreement year.
+func (l OvertimeLimits) CheckedFrom(month time.Time) time.Time {
+	from := l.YearStartOf(month)
+	if averageStart := month.AddDate(0, -(overtimeAverageMonths - 1), 0); averageStart.Before(from) {
+		from = averageStart
+	}
+	return from
+}
+
+// MonthlyOvertime is the overtime of a user in a month.
+type MonthlyOvertime struct {
+	// Month is the first day of the month.
+	Month time.Time
+	// Overtime is the legal overtime, excluding statutory holiday work.
+	Overtime time.Duration
+	// HolidayWork is the work on statutory holidays.
+	HolidayWork time.Duration
+}
+
+// NewMonthlyOvertime sums up the overtime of the days of the month.
+func NewMonthlyOvertime(month time.Time, days DailyWorkBreakdowns) MonthlyOvertime {
+	total := days.Total()
+	return MonthlyOvertime{
+		Month:       month,
+		Overtime:    total.LegalOvertime,
+		HolidayWork: total.StatutoryHoliday,
+	}
+}
+
+// WithHolidayWork returns the overtime including statutory holiday work, as counted by the special clause limits.
+func (m MonthlyOvertime) WithHolidayWork() time.Duration {
+	return m.Overtime + m.HolidayWork
+}
+
+// Project extrapolates the overtime of the month to its end from the business days elapsed so far.
+func (m MonthlyOvertime) Project(elapsedDays, totalDays int) MonthlyOvertime {
+	if elapsedDays <= 0 || elapsedDays >= totalDays {
+		return m
+	}
+	rate := float64(totalDays) / float64(elapsedDays)
+	return MonthlyOvertime{
+		Month:       m.Month,
+		Overtime:    time.Duration(float64(m.Overtime) * rate),
+		HolidayWork: time.Duration(float64(m.HolidayWork) * rate),
+	}
+}
+
+// OvertimeAlert records that the overtime of a user reached a level of a limit.
+// Month is the period of the limit as yyyymm: the month itself for monthly limits,
+// the first month of the agreement year for annual limits.
+type OvertimeAlert struct {
+	ID                   int64
+	TenantID             int64
+	UserID               string
+	Month                int
+	OvertimeLimitKindID  uint8
+	OvertimeAlertLevelID uint8
+	Value                float64
+	Threshold            float64
+	CreatedAt            time.Time `xorm:"created"`
+	UpdatedAt            time.Time `xorm:"updated"`
+}
+
+func (OvertimeAlert) TableName() string {
+	return "overtime_alerts"
+}
+
+func (a *OvertimeAlert) Kind() OvertimeLimitKind {
+	return OvertimeLimitKind(a.OvertimeLimitKindID)
+}
+
+func (a *OvertimeAlert) Level() OvertimeAlertLevel {
+	return OvertimeAlertLevel(a.OvertimeAlertLevelID)
+}
+
+// IsCoveredBy reports whether one of the alerts already raised the same limit for the period at this level or higher.
+func (a *OvertimeAlert) IsCoveredBy(alerts []*OvertimeAlert) bool {
+	for _, raised := range alerts {
+		if raised.UserID == a.UserID && raised.Month == a.Month && raised.Kind() == a.Kind() && raised.Level() >= a.Level() {
+			return true
+		}
+	}
+	return false
+}
+
+// Check evaluates the limits at the last of the months, which are in month order and include the months of the
+// agreement year so far and the five months before the last one.
+// projected is the last month extrapolated to its end; a limit it would exceed is reported as a warning.
+// Values are in hours, except for the number of months over the monthly limit.
+func (l OvertimeLimits) Check(userID string, months []MonthlyOvertime, projected MonthlyOvertime) []*OvertimeAlert {
+	if len(months) == 0 {
+		return nil
+	}
+	actual := l.evaluate(userID, months)
+
+	trend := make([]MonthlyOvertime, len(months))
+	copy(trend, months)
+	trend[len(trend)-1] = projected
+	trending := l.evaluate(userID, trend)
+
+	alerts := make([]*OvertimeAlert, 0)
+	for i, a := range actual {
+		if a.Level() == OvertimeAlertLevelNone && trending[i].Level() != OvertimeAlertLevelNone {
+			a.OvertimeAlertLevelID = uint8(OvertimeAlertLevelWarning)
+		}
+		if a.Level() != OvertimeAlertLevelNone {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// evaluate returns an alert for each limit, with OvertimeAlertLevelNone when the limit is not approached.
+func (l OvertimeLimits) evaluate(userID string, months []MonthlyOvertime) []*OvertimeAlert {
+	current := months[len(months)-1]
+	yearStart := l.YearStartOf(current.Month)
+
+	var (
+		annual            time.Duration
+		monthsOverMonthly int
+	)
+	for _, m := range months {
+		if m.Month.Before(yearStart) {
+			continue
+		}
+		annual += m.Overtime
+		if m.Overtime > l.Monthly {
+			monthsOverMonthly++
+		}
+	}
+
+	// The average over the worst of the last 2 to 6 months.
+	var (
+		worstAverage time.Duration
+		sum          time.Duration
+	)
+	for n := 1; n <= overtimeAverageMonths && n <= len(months); n++ {
+		sum += months[len(months)-n].WithHolidayWork()
+		if n < 2 {
+			continue
+		}
+		if average := sum / time.Duration(n); average > worstAverage {
+			worstAverage = average
+		}
+	}
+
+	newAlert := func(kind OvertimeLimitKind, period time.Time, value, limit float64, inclusive bool) *OvertimeAlert {
+		return &OvertimeAlert{
+			UserID:               userID,
+			Month:                toMonth(period),
+			OvertimeLimitKindID:  uint8(kind),
+			OvertimeAlertLevelID: uint8(l.levelOf(value, limit, inclusive)),
+			Value:                value,
+			Threshold:            limit,
+		}
+	}
+	return []*OvertimeAlert{
+		newAlert(OvertimeLimitKindMonthly, current.Month, current.Overtime.Hours(), l.Monthly.Hours(), false),
+		newAlert(OvertimeLimitKindAnnual, yearStart, annual.Hours(), l.Annual.Hours(), false),
+		// The special monthly limit must stay below 100 hours, so reaching it is a breach.
+		newAlert(OvertimeLimitKindSpecialMonthly, current.Month, current.WithHolidayWork().Hours(), l.SpecialMonthly.Hours(), true),
+		newAlert(OvertimeLimitKindSpecialAverage, current.Month, worstAverage.Hours(), l.SpecialAverage.Hours(), false),
+		newAlert(OvertimeLimitKindSpecialAnnual, yearStart, annual.Hours(), l.SpecialAnnual.Hours(), false),
+		newAlert(OvertimeLimitKindMonthsOverMonthly, yearStart, float64(monthsOverMonthly), float64(l.MaxMonthsOverMonthly), false),
+	}
+}
+
+func (l OvertimeLimits) levelOf(value, limit float64, inclusive bool) OvertimeAlertLevel {
+	switch {
+	case limit <= 0:
+		return OvertimeAlertLevelNone
+	case value > limit, inclusive && value == limit:
+		return OvertimeAlertLevelExceeded
+	case value >= limit*l.WarningRate && value > 0:
+		return OvertimeAlertLevelWarning
+	}
+	return OvertimeAlertLevelNone
+}
+
+func toMonth(t time.Time) int {
+	month, _ := strconv.Atoi(t.Format("200601"))
+	return month
+}
+
+func (k OvertimeLimitKind) String() string {
+	switch k {
+	case OvertimeLimitKindMonthly:
+		return "月間の限度時間"
+	case OvertimeLimitKindAnnual:
+		return "年間の限度時間"
+	case OvertimeLimitKindSpecialMonthly:
+		return "特別条項の月間上限"
+	case OvertimeLimitKindSpecialAverage:
+		return "特別条項の複数月平均上限"
+	case OvertimeLimitKindSpecialAnnual:
+		return "特別条項の年間上限"
+	case OvertimeLimitKindMonthsOverMonthly:
+		return "月間の限度時間を超えられる回数"
+	}
+	return "不明"
+}
+
+func (l OvertimeAlertLevel) String() string {
+	switch l {
+	case OvertimeAlertLevelWarning:
+		return "警告"
+	case OvertimeAlertLevelExceeded:
+		return "超過"
+	}
+	return "なし"
+}
+
+// OvertimeRisk is a user whose overtime approaches or exceeds the limits.
+type OvertimeRisk struct {
+	User   *User
+	Alerts []*OvertimeAlert
+}
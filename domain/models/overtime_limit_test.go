@@ -0,0 +1,154 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestMonthlyOvertimes(from time.Time, hours ...int) []MonthlyOvertime {
+	months := make([]MonthlyOvertime, 0)
+	for i, h := range hours {
+		months = append(months, MonthlyOvertime{Month: from.AddDate(0, i, 0), Overtime: time.Duration(h) * time.Hour})
+	}
+	return months
+}
+
+func TestOvertimeLimits_Check(t *testing.T) {
+	limits := DefaultOvertimeLimits()
+	type want struct {
+		kind  OvertimeLimitKind
+		level OvertimeAlertLevel
+		month int
+	}
+	tests := []struct {
+		name      string
+		months    []MonthlyOvertime
+		projected time.Duration
+		want      []want
+	}{
+		{
+			name:   "Should not alert within limits",
+			months: newTestMonthlyOvertimes(date(2020, 4, 1), 20, 30),
+		},
+		{
+			name:   "Should warn near the monthly limit",
+			months: newTestMonthlyOvertimes(date(2020, 4, 1), 20, 40),
+			want:   []want{{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelWarning, month: 202005}},
+		},
+		{
+			name:      "Should warn when the month is trending over the monthly limit",
+			months:    newTestMonthlyOvertimes(date(2020, 4, 1), 20, 25),
+			projected: 50 * time.Hour,
+			want:      []want{{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelWarning, month: 202005}},
+		},
+		{
+			name:   "Should exceed the special monthly limit at 100 hours",
+			months: newTestMonthlyOvertimes(date(2020, 4, 1), 100),
+			want: []want{
+				{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelExceeded, month: 202004},
+				{kind: OvertimeLimitKindSpecialMonthly, level: OvertimeAlertLevelExceeded, month: 202004},
+			},
+		},
+		{
+			name:   "Should exceed the average over two months",
+			months: newTestMonthlyOvertimes(date(2020, 4, 1), 90, 75),
+			want: []want{
+				{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelExceeded, month: 202005},
+				{kind: OvertimeLimitKindSpecialAverage, level: OvertimeAlertLevelExceeded, month: 202005},
+			},
+		},
+		{
+			name:   "Should exceed the average across the start of the agreement year",
+			months: newTestMonthlyOvertimes(date(2020, 3, 1), 90, 75),
+			want: []want{
+				{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelExceeded, month: 202004},
+				{kind: OvertimeLimitKindSpecialAverage, level: OvertimeAlertLevelExceeded, month: 202004},
+			},
+		},
+		{
+			name:   "Should count the annual limit from the start of the agreement year",
+			months: newTestMonthlyOvertimes(date(2020, 1, 1), 44, 44, 44, 44, 44, 44, 44, 44, 44, 44),
+			want: []want{
+				{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelWarning, month: 202010},
+				{kind: OvertimeLimitKindAnnual, level: OvertimeAlertLevelWarning, month: 202004},
+			},
+		},
+		{
+			name:   "Should exceed the months over the monthly limit",
+			months: newTestMonthlyOvertimes(date(2020, 4, 1), 50, 50, 50, 50, 50, 50, 50),
+			want: []want{
+				{kind: OvertimeLimitKindMonthly, level: OvertimeAlertLevelExceeded, month: 202010},
+				{kind: OvertimeLimitKindAnnual, level: OvertimeAlertLevelWarning, month: 202004},
+				{kind: OvertimeLimitKindMonthsOverMonthly, level: OvertimeAlertLevelExceeded, month: 202004},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected := tt.months[len(tt.months)-1]
+			if tt.projected != 0 {
+				projected.Overtime = tt.projected
+			}
+			got := limits.Check("user", tt.months, projected)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() got %d alerts, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				if got[i].Kind() != w.kind || got[i].Level() != w.level || got[i].Month != w.month {
+					t.Errorf("Check()[%d] = %s %s %d, want %s %s %d", i, got[i].Kind(), got[i].Level(), got[i].Month, w.kind, w.level, w.month)
+				}
+			}
+		})
+	}
+}
+
+func TestOvertimeLimits_CheckedFrom(t *testing.T) {
+	limits := DefaultOvertimeLimits()
+	tests := []struct {
+		name  string
+		month time.Time
+		want  time.Time
+	}{
+		{name: "Should look back five months across the start of the agreement year", month: date(2020, 5, 1), want: date(2019, 12, 1)},
+		{name: "Should check from the start of the agreement year", month: date(2020, 12, 1), want: date(2020, 4, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limits.CheckedFrom(tt.month); !got.Equal(tt.want) {
+				t.Errorf("CheckedFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOvertimeAlert_IsCoveredBy(t *testing.T) {
+	raised := []*OvertimeAlert{
+		{UserID: "user", Month: 202005, OvertimeLimitKindID: uint8(OvertimeLimitKindMonthly), OvertimeAlertLevelID: uint8(OvertimeAlertLevelWarning)},
+	}
+	tests := []struct {
+		name  string
+		alert *OvertimeAlert
+		want  bool
+	}{
+		{
+			name:  "Should be covered by the same level",
+			alert: &OvertimeAlert{UserID: "user", Month: 202005, OvertimeLimitKindID: uint8(OvertimeLimitKindMonthly), OvertimeAlertLevelID: uint8(OvertimeAlertLevelWarning)},
+			want:  true,
+		},
+		{
+			name:  "Should not be covered by a lower level",
+			alert: &OvertimeAlert{UserID: "user", Month: 202005, OvertimeLimitKindID: uint8(OvertimeLimitKindMonthly), OvertimeAlertLevelID: uint8(OvertimeAlertLevelExceeded)},
+		},
+		{
+			name:  "Should not be covered by another month",
+			alert: &OvertimeAlert{UserID: "user", Month: 202006, OvertimeLimitKindID: uint8(OvertimeLimitKindMonthly), OvertimeAlertLevelID: uint8(OvertimeAlertLevelWarning)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.alert.IsCoveredBy(raised); got != tt.want {
+				t.Errorf("IsCoveredBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestShift returns an attendance clocked in at the hour of the day for the hours, with a break of the break hours at noon.
+func newTestShift(day time.Time, inHour, hours, breakHours int) *Attendance {
+	in := day.Add(time.Duration(inHour) * time.Hour)
+	times := []*AttendanceTime{
+		{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: in},
+		{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: in.Add(time.Duration(hours) * time.Hour)},
+	}
+	if breakHours > 0 {
+		noon := day.Add(12 * time.Hour)
+		times = append(times,
+			&AttendanceTime{AttendanceKindID: uint8(AttendanceKindBreakStart), PushedAt: noon},
+			&AttendanceTime{AttendanceKindID: uint8(AttendanceKindBreakEnd), PushedAt: noon.Add(time.Duration(breakHours) * time.Hour)},
+		)
+	}
+	a := &Attendance{AttendedAt: in}
+	a.SetTimes(times)
+	return a
+}
+
+func TestBreakdownWork(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	week := func(from time.Time, days int) Attendances {
+		attendances := make(Attendances, 0)
+		for i := 0; i < days; i++ {
+			attendances = append(attendances, newTestShift(from.AddDate(0, 0, i), 9, 9, 1))
+		}
+		return attendances
+	}
+	splitShift := &Attendance{AttendedAt: date(2020, 6, 1).Add(9 * time.Hour)}
+	splitShift.SetTimes([]*AttendanceTime{
+		{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(9 * time.Hour)},
+		{AttendanceKindID: uint8(AttendanceKindBreakStart), PushedAt: date(2020, 6, 1).Add(11 * time.Hour)},
+		{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: date(2020, 6, 1).Add(12 * time.Hour)},
+		{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(20 * time.Hour)},
+		{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: date(2020, 6, 1).Add(24 * time.Hour)},
+	})
+	rounded := newTestShift(date(2020, 6, 1), 9, 9, 1)
+	rounded.Sessions[0].ClockedIn.RoundedAt = date(2020, 6, 1).Add(9*time.Hour + 30*time.Minute)
+	saturday := &WorkWeekRule{StatutoryHolidayWeekday: uint8(time.Saturday)}
+	tests := []struct {
+		name        string
+		rule        *WorkWeekRule
+		attendances Attendances
+		start       time.Time
+		end         time.Time
+		wantDays    int
+		want        WorkBreakdown
+	}{
+		{
+			name:        "Should count hours over 8 a day as overtime",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 9, 11, 1)},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 8 * time.Hour, LegalOvertime: 2 * time.Hour},
+		},
+		{
+			name:        "Should count late night hours after midnight to the day clocked in",
+			attendances: Attendances{newTestShift(date(2020, 6, 1), 18, 8, 0)},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 8 * time.Hour, LateNight: 4 * time.Hour},
+		},
+		{
+			name:        "Should not carry a break into the next session",
+			attendances: Attendances{splitShift},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 6 * time.Hour, LateNight: 2 * time.Hour},
+		},
+		{
+			name:        "Should count hours over 40 a week as overtime on Saturday",
+			attendances: week(date(2020, 6, 1), 6),
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    6,
+			want:        WorkBreakdown{Statutory: 40 * time.Hour, LegalOvertime: 8 * time.Hour, NonStatutoryHoliday: 8 * time.Hour},
+		},
+		{
+			name:        "Should count Sunday as statutory holiday work",
+			attendances: Attendances{newTestShift(date(2020, 6, 7), 10, 5, 0)},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{StatutoryHoliday: 5 * time.Hour},
+		},
+		{
+			name:        "Should count national holiday as non statutory holiday work",
+			attendances: Attendances{newTestShift(date(2020, 7, 23), 9, 9, 1)},
+			start:       date(2020, 7, 1),
+			end:         date(2020, 7, 31),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 8 * time.Hour, NonStatutoryHoliday: 8 * time.Hour},
+		},
+		{
+			name:        "Should apply the weekly limit across the month boundary",
+			attendances: week(date(2020, 6, 29), 6),
+			start:       date(2020, 7, 1),
+			end:         date(2020, 7, 31),
+			wantDays:    4,
+			want:        WorkBreakdown{Statutory: 24 * time.Hour, LegalOvertime: 8 * time.Hour, NonStatutoryHoliday: 8 * time.Hour},
+		},
+		{
+			name:        "Should count the statutory holiday of the rule as statutory holiday work",
+			rule:        saturday,
+			attendances: Attendances{newTestShift(date(2020, 6, 6), 10, 5, 0)},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{StatutoryHoliday: 5 * time.Hour},
+		},
+		{
+			name:        "Should count Sunday as non statutory holiday work when the statutory holiday is Saturday",
+			rule:        saturday,
+			attendances: Attendances{newTestShift(date(2020, 6, 7), 9, 9, 1)},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 8 * time.Hour, NonStatutoryHoliday: 8 * time.Hour},
+		},
+		{
+			name:        "Should start the week on the day after the statutory holiday of the rule",
+			rule:        saturday,
+			attendances: week(date(2020, 6, 1), 6),
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    6,
+			want:        WorkBreakdown{Statutory: 40 * time.Hour, StatutoryHoliday: 8 * time.Hour},
+		},
+		{
+			name:        "Should count the rounded punches paid by payroll",
+			attendances: Attendances{rounded},
+			start:       date(2020, 6, 1),
+			end:         date(2020, 6, 30),
+			wantDays:    1,
+			want:        WorkBreakdown{Statutory: 7*time.Hour + 30*time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			if rule == nil {
+				rule = DefaultWorkWeekRule()
+			}
+			days := BreakdownWork(cal, rule, tt.attendances, tt.start, tt.end)
+			if len(days) != tt.wantDays {
+				t.Errorf("BreakdownWork() got %d days, want %d", len(days), tt.wantDays)
+			}
+			if got := days.Total(); got != tt.want {
+				t.Errorf("BreakdownWork() total = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakdownActualWork(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	a := newTestShift(date(2020, 6, 1), 9, 9, 1)
+	a.Sessions[0].ClockedIn.RoundedAt = date(2020, 6, 1).Add(9*time.Hour + 30*time.Minute)
+
+	days := BreakdownActualWork(cal, DefaultWorkWeekRule(), Attendances{a}, date(2020, 6, 1), date(2020, 6, 30))
+	want := WorkBreakdown{Statutory: 8 * time.Hour}
+	if got := days.Total(); got != want {
+		t.Errorf("BreakdownActualWork() total = %+v, want %+v", got, want)
+	}
+}
+
+func TestWeekStartOf(t *testing.T) {
+	cal := NewCalendar(time.UTC, nil)
+	tests := []struct {
+		name    string
+		weekday time.Weekday
+		day     time.Time
+		want    time.Time
+	}{
+		{name: "Should start on Sunday", weekday: time.Sunday, day: date(2020, 6, 7), want: date(2020, 6, 7)},
+		{name: "Should go back to the previous month", weekday: time.Sunday, day: date(2020, 7, 1).Add(10 * time.Hour), want: date(2020, 6, 28)},
+		{name: "Should start on the statutory holiday of the rule", weekday: time.Wednesday, day: date(2020, 6, 7), want: date(2020, 6, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &WorkWeekRule{StatutoryHolidayWeekday: uint8(tt.weekday)}
+			if got := WeekStartOf(cal, rule, tt.day); !got.Equal(tt.want) {
+				t.Errorf("WeekStartOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
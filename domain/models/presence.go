@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// PresenceStatus is where a user is in its working day, as shown on the boards of its team.
+type PresenceStatus uint8
+
+const (
+	PresenceStatusNotStarted PresenceStatus = iota
+	PresenceStatusWorking
+	PresenceStatusOnBreak
+	PresenceStatusFinished
+	PresenceStatusOnLeave
+)
+
+// NewPresenceStatus returns the status of a user from its attendance of the day, nil before the first punch,
+// and whether it takes an approved leave on the day. A user punching on a day of leave is shown by its punches.
+func NewPresenceStatus(attendance *Attendance, onLeave bool) PresenceStatus {
+	switch attendance.Status() {
+	case AttendanceStatusWorking:
+		return PresenceStatusWorking
+	case AttendanceStatusOnBreak:
+		return PresenceStatusOnBreak
+	case AttendanceStatusFinished:
+		return PresenceStatusFinished
+	}
+	if onLeave {
+		return PresenceStatusOnLeave
+	}
+	return PresenceStatusNotStarted
+}
+
+func (s PresenceStatus) String() string {
+	switch s {
+	case PresenceStatusNotStarted:
+		return "未出勤"
+	case PresenceStatusWorking:
+		return "勤務中"
+	case PresenceStatusOnBreak:
+		return "休憩中"
+	case PresenceStatusFinished:
+		return "退勤済"
+	case PresenceStatusOnLeave:
+		return "休暇中"
+	}
+	return "不明"
+}
+
+// MemberPresence is the attendance of a member of a team on a day with the approved leaves it takes on the day.
+type MemberPresence struct {
+	User       *User
+	Status     PresenceStatus
+	Attendance *Attendance
+	Leaves     []*LeaveRequest
+}
+
+// PresenceEvent is a punch changing the status of a user, pushed to the live boards of its tenant.
+type PresenceEvent struct {
+	TenantID int64
+	UserID   string
+	Status   PresenceStatus
+	Kind     AttendanceKind
+	PushedAt time.Time
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPresenceStatus(t *testing.T) {
+	clockIn := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	newAttendance := func(kinds ...AttendanceKind) *Attendance {
+		a := &Attendance{}
+		for i, k := range kinds {
+			a.AddTime(&AttendanceTime{AttendanceKindID: uint8(k), PushedAt: clockIn.Add(time.Duration(i) * time.Hour)})
+		}
+		return a
+	}
+	tests := []struct {
+		name       string
+		attendance *Attendance
+		onLeave    bool
+		want       PresenceStatus
+	}{
+		{
+			name: "Should not be started without attendance",
+			want: PresenceStatusNotStarted,
+		},
+		{
+			name:       "Should be working after clock-in",
+			attendance: newAttendance(AttendanceKindClockIn),
+			want:       PresenceStatusWorking,
+		},
+		{
+			name:       "Should be on break after break start",
+			attendance: newAttendance(AttendanceKindClockIn, AttendanceKindBreakStart),
+			want:       PresenceStatusOnBreak,
+		},
+		{
+			name:       "Should be working after break end",
+			attendance: newAttendance(AttendanceKindClockIn, AttendanceKindBreakStart, AttendanceKindBreakEnd),
+			want:       PresenceStatusWorking,
+		},
+		{
+			name:       "Should be finished after clock-out",
+			attendance: newAttendance(AttendanceKindClockIn, AttendanceKindClockOut),
+			want:       PresenceStatusFinished,
+		},
+		{
+			name:    "Should be on leave without attendance",
+			onLeave: true,
+			want:    PresenceStatusOnLeave,
+		},
+		{
+			name:       "Should be working when punching on a day of leave",
+			attendance: newAttendance(AttendanceKindClockIn),
+			onLeave:    true,
+			want:       PresenceStatusWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPresenceStatus(tt.attendance, tt.onLeave); got != tt.want {
+				t.Errorf("NewPresenceStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type Role uint8
+
+const (
+	RoleNone Role = iota
+	RoleEmployee
+	RoleManager
+	RoleHRAdmin
+	RoleSystemAdmin
+)
+
+type Permission uint8
+
+const (
+	PermissionNone Permission = iota
+	// PermissionReadReports reads the attendances and requests of the users down the reporting line.
+	PermissionReadReports
+	// PermissionReviewReports approves or rejects the requests of the users down the reporting line.
+	PermissionReviewReports
+	// PermissionReadAll reads the data of everyone.
+	PermissionReadAll
+	// PermissionWriteAll writes the data of everyone, e.g. reviews any request or issues badges.
+	PermissionWriteAll
+	// PermissionManageWorkRules sets the calendar, the contracts, the shifts and the rules of the working time.
+	PermissionManageWorkRules
+	// PermissionManageSystem sets the roles and where punches are allowed from.
+	PermissionManageSystem
+	// PermissionManageOrganization sets the departments, the teams and who reports to whom.
+	PermissionManageOrganization
+)
+
+// Access is what is done with the data of a user.
+type Access uint8
+
+const (
+	AccessRead Access = iota
+	AccessReview
+	AccessWrite
+)
+
+var ErrForbidden = xerrors.New("principal is not allowed")
+
+var rolePermissions = map[Role][]Permission{
+	RoleManager: {
+		PermissionReadReports,
+		PermissionReviewReports,
+	},
+	RoleHRAdmin: {
+		PermissionReadReports,
+		PermissionReviewReports,
+		PermissionReadAll,
+		PermissionWriteAll,
+		PermissionManageWorkRules,
+		PermissionManageOrganization,
+	},
+	RoleSystemAdmin: {
+		PermissionReadReports,
+		PermissionReviewReports,
+		PermissionReadAll,
+		PermissionWriteAll,
+		PermissionManageWorkRules,
+		PermissionManageSystem,
+		PermissionManageOrganization,
+	},
+}
+
+// UserRole is a role given to a user on top of the employee role everyone has.
+type UserRole struct {
+	ID        int64
+	TenantID  int64
+	UserID    string
+	RoleID    uint8
+	CreatedAt time.Time `xorm:"created"`
+	UpdatedAt time.Time `xorm:"updated"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+func (r *UserRole) Role() Role {
+	return Role(r.RoleID)
+}
+
+func (r Role) IsValid() bool {
+	return r >= RoleEmployee && r <= RoleSystemAdmin
+}
+
+// Principal is the authenticated user with the roles the requests are authorized with.
+type Principal struct {
+	UserID string
+	Roles  []Role
+}
+
+// NewPrincipal returns the principal of the user with the roles given to it. Everyone is an employee.
+func NewPrincipal(userID string, roles []*UserRole) *Principal {
+	p := &Principal{
+		UserID: userID,
+		Roles:  []Role{RoleEmployee},
+	}
+	for _, r := range roles {
+		if r.Role() != RoleEmployee {
+			p.Roles = append(p.Roles, r.Role())
+		}
+	}
+	return p
+}
+
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether a role of the principal has the permission.
+func (p *Principal) Can(permission Permission) bool {
+	for _, r := range p.Roles {
+		for _, perm := range rolePermissions[r] {
+			if perm == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanAccess reports whether the principal can access the data of the user, isReport being whether the user reports to it.
+// Everyone reads and writes its own data, but a review is always by someone else.
+func (p *Principal) CanAccess(userID string, isReport bool, access Access) bool {
+	isSelf := userID == p.UserID
+	switch access {
+	case AccessRead:
+		return isSelf || p.Can(PermissionReadAll) || (isReport && p.Can(PermissionReadReports))
+	case AccessReview:
+		return !isSelf && (p.Can(PermissionWriteAll) || (isReport && p.Can(PermissionReviewReports)))
+	case AccessWrite:
+		return isSelf || p.Can(PermissionWriteAll)
+	}
+	return false
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleEmployee:
+		return "従業員"
+	case RoleManager:
+		return "マネージャー"
+	case RoleHRAdmin:
+		return "人事管理者"
+	case RoleSystemAdmin:
+		return "システム管理者"
+	}
+	return "不明"
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestPrincipal_CanAccess(t *testing.T) {
+	employee := NewPrincipal("employee", nil)
+	manager := NewPrincipal("manager", []*UserRole{{UserID: "manager", RoleID: uint8(RoleManager)}})
+	admin := NewPrincipal("admin", []*UserRole{{UserID: "admin", RoleID: uint8(RoleHRAdmin)}})
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		userID    string
+		isReport  bool
+		access    Access
+		want      bool
+	}{
+		{
+			name:      "Should read own data",
+			principal: employee,
+			userID:    "employee",
+			access:    AccessRead,
+			want:      true,
+		},
+		{
+			name:      "Should not read data of others as employee",
+			principal: employee,
+			userID:    "other",
+			access:    AccessRead,
+			want:      false,
+		},
+		{
+			name:      "Should not review own request",
+			principal: admin,
+			userID:    "admin",
+			access:    AccessReview,
+			want:      false,
+		},
+		{
+			name:      "Should read data of report as manager",
+			principal: manager,
+			userID:    "report",
+			isReport:  true,
+			access:    AccessRead,
+			want:      true,
+		},
+		{
+			name:      "Should review request of report as manager",
+			principal: manager,
+			userID:    "report",
+			isReport:  true,
+			access:    AccessReview,
+			want:      true,
+		},
+		{
+			name:      "Should not read data of others as manager",
+			principal: manager,
+			userID:    "other",
+			access:    AccessRead,
+			want:      false,
+		},
+		{
+			name:      "Should not write data of report as manager",
+			principal: manager,
+			userID:    "report",
+			isReport:  true,
+			access:    AccessWrite,
+			want:      false,
+		},
+		{
+			name:      "Should read, review and write data of everyone as HR admin",
+			principal: admin,
+			userID:    "other",
+			access:    AccessWrite,
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.CanAccess(tt.userID, tt.isReport, tt.access); got != tt.want {
+				t.Errorf("CanAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrincipal_Can(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []*UserRole
+		permission Permission
+		want       bool
+	}{
+		{
+			name:       "Should not manage work rules as employee",
+			roles:      nil,
+			permission: PermissionManageWorkRules,
+			want:       false,
+		},
+		{
+			name:       "Should manage work rules as HR admin",
+			roles:      []*UserRole{{RoleID: uint8(RoleHRAdmin)}},
+			permission: PermissionManageWorkRules,
+			want:       true,
+		},
+		{
+			name:       "Should not manage system as HR admin",
+			roles:      []*UserRole{{RoleID: uint8(RoleHRAdmin)}},
+			permission: PermissionManageSystem,
+			want:       false,
+		},
+		{
+			name:       "Should manage system as system admin",
+			roles:      []*UserRole{{RoleID: uint8(RoleManager)}, {RoleID: uint8(RoleSystemAdmin)}},
+			permission: PermissionManageSystem,
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPrincipal("user", tt.roles).Can(tt.permission); got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
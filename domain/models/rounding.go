@@ -0,0 +1,115 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+type RoundingDirection uint8
+
+const (
+	RoundingDirectionNone RoundingDirection = iota
+	RoundingDirectionUp
+	RoundingDirectionDown
+	RoundingDirectionNearest
+)
+
+// RoundingRule rounds the punches of a kind to a unit of minutes for payroll.
+// The raw time is kept in PushedAt for legal records and the rounded time in RoundedAt.
+type RoundingRule struct {
+	ID                  int64
+	TenantID            int64
+	AttendanceKindID    uint8
+	UnitMinutes         int
+	RoundingDirectionID uint8
+	CreatedAt           time.Time `xorm:"created"`
+	UpdatedAt           time.Time `xorm:"updated"`
+}
+
+func (RoundingRule) TableName() string {
+	return "rounding_rules"
+}
+
+func (r *RoundingRule) Kind() AttendanceKind {
+	return AttendanceKind(r.AttendanceKindID)
+}
+
+func (r *RoundingRule) Direction() RoundingDirection {
+	return RoundingDirection(r.RoundingDirectionID)
+}
+
+func (r *RoundingRule) Validate() error {
+	if r.Kind() == AttendanceKindNone || r.Kind() > AttendanceKindBreakEnd {
+		return xerrors.New("attendance kind is invalid")
+	}
+	if r.UnitMinutes <= 0 || 24*60%r.UnitMinutes != 0 {
+		return xerrors.New("unit must divide a day")
+	}
+	if r.Direction() == RoundingDirectionNone || r.Direction() > RoundingDirectionNearest {
+		return xerrors.New("rounding direction is invalid")
+	}
+	return nil
+}
+
+// Round rounds the time to the unit counted from midnight in the location of t.
+func (r *RoundingRule) Round(t time.Time) time.Time {
+	unit := time.Duration(r.UnitMinutes) * time.Minute
+	if unit <= 0 {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	elapsed := t.Sub(midnight)
+	rounded := elapsed - elapsed%unit
+	switch r.Direction() {
+	case RoundingDirectionUp:
+		if rounded != elapsed {
+			rounded += unit
+		}
+	case RoundingDirectionNearest:
+		if elapsed-rounded >= unit/2 {
+			rounded += unit
+		}
+	case RoundingDirectionDown:
+	default:
+		return t
+	}
+	return midnight.Add(rounded)
+}
+
+type RoundingRules []*RoundingRule
+
+// Round rounds the punch of the kind by its rule. Without a rule the time is returned as is.
+func (rules RoundingRules) Round(kind AttendanceKind, t time.Time) time.Time {
+	for _, r := range rules {
+		if r.Kind() == kind {
+			return r.Round(t)
+		}
+	}
+	return t
+}
+
+func (rules RoundingRules) Validate() error {
+	kinds := make(map[AttendanceKind]bool)
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if kinds[r.Kind()] {
+			return xerrors.Errorf("rule of %s is duplicated", r.Kind())
+		}
+		kinds[r.Kind()] = true
+	}
+	return nil
+}
+
+func (d RoundingDirection) String() string {
+	switch d {
+	case RoundingDirectionUp:
+		return "切り上げ"
+	case RoundingDirectionDown:
+		return "切り捨て"
+	case RoundingDirectionNearest:
+		return "四捨五入"
+	}
+	return "不明"
+}
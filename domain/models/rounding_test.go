@@ -0,0 +1,99 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundingRule_Round(t *testing.T) {
+	at := func(hour, min, sec int) time.Time {
+		return time.Date(2020, 1, 2, hour, min, sec, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		rule RoundingRule
+		at   time.Time
+		want time.Time
+	}{
+		{name: "Should round up", rule: RoundingRule{UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionUp)}, at: at(8, 50, 12), want: at(9, 0, 0)},
+		{name: "Should keep time on the unit when rounding up", rule: RoundingRule{UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionUp)}, at: at(9, 0, 0), want: at(9, 0, 0)},
+		{name: "Should round down", rule: RoundingRule{UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionDown)}, at: at(18, 14, 59), want: at(18, 0, 0)},
+		{name: "Should round to nearest", rule: RoundingRule{UnitMinutes: 30, RoundingDirectionID: uint8(RoundingDirectionNearest)}, at: at(18, 15, 0), want: at(18, 30, 0)},
+		{name: "Should round up over midnight", rule: RoundingRule{UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionUp)}, at: at(23, 50, 0), want: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Round(tt.at); !got.Equal(tt.want) {
+				t.Errorf("Round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttendance_RoundedWorkDuration(t *testing.T) {
+	rules := RoundingRules{
+		{AttendanceKindID: uint8(AttendanceKindClockIn), UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionUp)},
+		{AttendanceKindID: uint8(AttendanceKindClockOut), UnitMinutes: 15, RoundingDirectionID: uint8(RoundingDirectionDown)},
+	}
+	round := func(times ...*AttendanceTime) *Attendance {
+		for _, t := range times {
+			t.RoundedAt = rules.Round(AttendanceKind(t.AttendanceKindID), t.PushedAt)
+		}
+		return newTestAttendance(times...)
+	}
+	tests := []struct {
+		name       string
+		attendance *Attendance
+		wantRaw    time.Duration
+		wantRound  time.Duration
+	}{
+		{
+			name: "Should round clock in up and clock out down",
+			attendance: round(
+				newTestAttendanceTime(AttendanceKindClockIn, 8, 50),
+				newTestAttendanceTime(AttendanceKindClockOut, 18, 10),
+			),
+			wantRaw:   9*time.Hour + 20*time.Minute,
+			wantRound: 9 * time.Hour,
+		},
+		{
+			name: "Should not round breaks without rule",
+			attendance: round(
+				newTestAttendanceTime(AttendanceKindClockIn, 9, 0),
+				newTestAttendanceTime(AttendanceKindBreakStart, 12, 5),
+				newTestAttendanceTime(AttendanceKindBreakEnd, 12, 55),
+				newTestAttendanceTime(AttendanceKindClockOut, 18, 0),
+			),
+			wantRaw:   8*time.Hour + 10*time.Minute,
+			wantRound: 8*time.Hour + 10*time.Minute,
+		},
+		{
+			name: "Should not go below zero",
+			attendance: round(
+				newTestAttendanceTime(AttendanceKindClockIn, 9, 1),
+				newTestAttendanceTime(AttendanceKindClockOut, 9, 14),
+			),
+			wantRaw:   13 * time.Minute,
+			wantRound: 0,
+		},
+		{
+			name: "Should use raw time when not rounded",
+			attendance: newTestAttendance(
+				newTestAttendanceTime(AttendanceKindClockIn, 8, 50),
+				newTestAttendanceTime(AttendanceKindClockOut, 18, 10),
+			),
+			wantRaw:   9*time.Hour + 20*time.Minute,
+			wantRound: 9*time.Hour + 20*time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attendance.WorkDuration(); got != tt.wantRaw {
+				t.Errorf("WorkDuration() = %v, want %v", got, tt.wantRaw)
+			}
+			if got := tt.attendance.RoundedWorkDuration(); got != tt.wantRound {
+				t.Errorf("RoundedWorkDuration() = %v, want %v", got, tt.wantRound)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+// MaxRosterDays is the longest roster published at once, a month.
+const MaxRosterDays = 31
+
+var ErrShiftTemplateNotFound = xerrors.New("shift template is not found")
+
+// ShiftTemplate is a shift pattern such as an early or a late shift.
+// A shift ending at or before its start time ends on the next day.
+type ShiftTemplate struct {
+	ID       int64
+	TenantID int64
+	Name     string
+	// StartMinutes and EndMinutes are the start and end of the shift in minutes from midnight.
+	StartMinutes int
+	EndMinutes   int
+	BreakMinutes int
+	CreatedAt    time.Time `xorm:"created"`
+	UpdatedAt    time.Time `xorm:"updated"`
+}
+
+func (ShiftTemplate) TableName() string {
+	return "shift_templates"
+}
+
+func (t *ShiftTemplate) Validate() error {
+	if t.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	if t.StartMinutes < 0 || t.StartMinutes >= minutesPerDay || t.EndMinutes < 0 || t.EndMinutes >= minutesPerDay {
+		return xerrors.New("shift time is invalid")
+	}
+	if t.BreakMinutes < 0 || time.Duration(t.BreakMinutes)*time.Minute >= t.Length() {
+		return xerrors.New("break is longer than the shift")
+	}
+	return nil
+}
+
+// Length returns the time from the start to the end of the shift including the break.
+func (t *ShiftTemplate) Length() time.Duration {
+	minutes := t.EndMinutes - t.StartMinutes
+	if minutes <= 0 {
+		minutes += minutesPerDay
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// Assign returns the shift of the template for the user on the day of d in the location of d.
+func (t *ShiftTemplate) Assign(userID string, d time.Time) *ShiftAssignment {
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+	start := day.Add(time.Duration(t.StartMinutes) * time.Minute)
+	return &ShiftAssignment{
+		UserID:          userID,
+		ShiftTemplateID: t.ID,
+		WorkDate:        day,
+		StartedAt:       start,
+		FinishedAt:      start.Add(t.Length()),
+		BreakMinutes:    t.BreakMinutes,
+	}
+}
+
+// ShiftAssignment is the shift planned for a user on a day of a published roster.
+// The times are copied from the template so that editing a template does not change published shifts.
+type ShiftAssignment struct {
+	ID              int64
+	TenantID        int64
+	UserID          string
+	ShiftTemplateID int64
+	WorkDate        time.Time
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	BreakMinutes    int
+	CreatedAt       time.Time `xorm:"created"`
+	UpdatedAt       time.Time `xorm:"updated"`
+}
+
+func (ShiftAssignment) TableName() string {
+	return "shift_assignments"
+}
+
+// ShiftCheck compares a planned shift with the attendance of its day.
+// Attendance is nil when the user did not clock in.
+type ShiftCheck struct {
+	Shift      *ShiftAssignment
+	Attendance *Attendance
+	// IsLate is set when the first clock-in was after the start of the shift.
+	IsLate bool
+	// IsEarlyLeave is set when the user finished before the end of the shift.
+	IsEarlyLeave bool
+	// IsNoShow is set when the shift is over without a clock-in.
+	IsNoShow bool
+}
+
+// Check compares the shift with the attendance of its day at now.
+// A shift still in progress is not a no-show yet.
+func (s *ShiftAssignment) Check(a *Attendance, now time.Time) *ShiftCheck {
+	check := &ShiftCheck{Shift: s, Attendance: a}
+	if a == nil || a.ClockedIn == nil {
+		check.Attendance = nil
+		check.IsNoShow = !now.Before(s.FinishedAt)
+		return check
+	}
+	check.IsLate = a.ClockedIn.PushedAt.After(s.StartedAt)
+	if a.Status() == AttendanceStatusFinished {
+		check.IsEarlyLeave = a.ClockedOut.PushedAt.Before(s.FinishedAt)
+	}
+	return check
+}
+
+func (c *ShiftCheck) HasDeviation() bool {
+	return c.IsLate || c.IsEarlyLeave || c.IsNoShow
+}
+
+// CheckShifts compares each shift with the attendance of the user on its day in loc.
+func CheckShifts(loc *time.Location, shifts []*ShiftAssignment, attendances Attendances, now time.Time) []*ShiftCheck {
+	byDate := make(map[string]*Attendance)
+	for _, a := range attendances {
+		byDate[a.UserID+a.AttendedAt.In(loc).Format(holidayKeyLayout)] = a
+	}
+	checks := make([]*ShiftCheck, 0)
+	for _, s := range shifts {
+		checks = append(checks, s.Check(byDate[s.UserID+s.WorkDate.In(loc).Format(holidayKeyLayout)], now))
+	}
+	return checks
+}
+
+// ShiftRoster is the shifts of everyone between the days of Start and End, published at once.
+type ShiftRoster struct {
+	Start  time.Time
+	End    time.Time
+	Shifts []*ShiftAssignment
+}
+
+func (r *ShiftRoster) Validate() error {
+	if r.End.Before(r.Start) {
+		return xerrors.New("end date is before start date")
+	}
+	if r.End.Sub(r.Start) >= MaxRosterDays*24*time.Hour {
+		return xerrors.Errorf("roster is longer than %d days", MaxRosterDays)
+	}
+	days := make(map[string]bool)
+	for _, s := range r.Shifts {
+		if s.WorkDate.Before(r.Start) || s.WorkDate.After(r.End) {
+			return xerrors.Errorf("shift on %s is out of the roster", s.WorkDate.Format(holidayKeyLayout))
+		}
+		key := s.UserID + s.WorkDate.Format(holidayKeyLayout)
+		if days[key] {
+			return xerrors.Errorf("user %s has two shifts on %s", s.UserID, s.WorkDate.Format(holidayKeyLayout))
+		}
+		days[key] = true
+	}
+	return nil
+}
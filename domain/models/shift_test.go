@@ -0,0 +1,124 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftTemplate_Assign(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       ShiftTemplate
+		wantStartedAt  time.Time
+		wantFinishedAt time.Time
+	}{
+		{
+			name:           "Should assign a day shift",
+			template:       ShiftTemplate{StartMinutes: 9 * 60, EndMinutes: 18 * 60, BreakMinutes: 60},
+			wantStartedAt:  date(2020, 6, 1).Add(9 * time.Hour),
+			wantFinishedAt: date(2020, 6, 1).Add(18 * time.Hour),
+		},
+		{
+			name:           "Should assign a night shift ending the next day",
+			template:       ShiftTemplate{StartMinutes: 22 * 60, EndMinutes: 6 * 60, BreakMinutes: 60},
+			wantStartedAt:  date(2020, 6, 1).Add(22 * time.Hour),
+			wantFinishedAt: date(2020, 6, 2).Add(6 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.template.Assign("user", date(2020, 6, 1).Add(15*time.Hour))
+			if !got.WorkDate.Equal(date(2020, 6, 1)) || !got.StartedAt.Equal(tt.wantStartedAt) || !got.FinishedAt.Equal(tt.wantFinishedAt) {
+				t.Errorf("Assign() = %v %v-%v, want %v-%v", got.WorkDate, got.StartedAt, got.FinishedAt, tt.wantStartedAt, tt.wantFinishedAt)
+			}
+		})
+	}
+}
+
+func TestShiftAssignment_Check(t *testing.T) {
+	shift := (&ShiftTemplate{StartMinutes: 9 * 60, EndMinutes: 18 * 60, BreakMinutes: 60}).Assign("user", date(2020, 6, 1))
+	working := &Attendance{}
+	working.SetTimes([]*AttendanceTime{{AttendanceKindID: uint8(AttendanceKindClockIn), PushedAt: date(2020, 6, 1).Add(9 * time.Hour)}})
+	tests := []struct {
+		name       string
+		attendance *Attendance
+		now        time.Time
+		want       ShiftCheck
+	}{
+		{
+			name:       "Should not flag attendance covering the shift",
+			attendance: newTestShift(date(2020, 6, 1), 9, 9, 1),
+			now:        date(2020, 6, 2),
+		},
+		{
+			name:       "Should flag late arrival and early leave",
+			attendance: newTestShift(date(2020, 6, 1), 10, 7, 1),
+			now:        date(2020, 6, 2),
+			want:       ShiftCheck{IsLate: true, IsEarlyLeave: true},
+		},
+		{
+			name:       "Should not flag early leave while working",
+			attendance: working,
+			now:        date(2020, 6, 1).Add(12 * time.Hour),
+		},
+		{
+			name: "Should flag no-show after the shift",
+			now:  date(2020, 6, 2),
+			want: ShiftCheck{IsNoShow: true},
+		},
+		{
+			name: "Should not flag no-show during the shift",
+			now:  date(2020, 6, 1).Add(12 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shift.Check(tt.attendance, tt.now)
+			if got.IsLate != tt.want.IsLate || got.IsEarlyLeave != tt.want.IsEarlyLeave || got.IsNoShow != tt.want.IsNoShow {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftRoster_Validate(t *testing.T) {
+	shift := func(userID string, d time.Time) *ShiftAssignment {
+		return &ShiftAssignment{UserID: userID, WorkDate: d}
+	}
+	tests := []struct {
+		name    string
+		roster  ShiftRoster
+		wantErr bool
+	}{
+		{
+			name:   "Should validate a week",
+			roster: ShiftRoster{Start: date(2020, 6, 1), End: date(2020, 6, 7), Shifts: []*ShiftAssignment{shift("a", date(2020, 6, 1)), shift("b", date(2020, 6, 1))}},
+		},
+		{
+			name:   "Should validate a month",
+			roster: ShiftRoster{Start: date(2020, 7, 1), End: date(2020, 7, 31)},
+		},
+		{
+			name:    "Should not validate more than a month",
+			roster:  ShiftRoster{Start: date(2020, 7, 1), End: date(2020, 8, 1)},
+			wantErr: true,
+		},
+		{
+			name:    "Should not validate a shift out of the roster",
+			roster:  ShiftRoster{Start: date(2020, 6, 1), End: date(2020, 6, 7), Shifts: []*ShiftAssignment{shift("a", date(2020, 6, 8))}},
+			wantErr: true,
+		},
+		{
+			name:    "Should not validate two shifts of a user on a day",
+			roster:  ShiftRoster{Start: date(2020, 6, 1), End: date(2020, 6, 7), Shifts: []*ShiftAssignment{shift("a", date(2020, 6, 1)), shift("a", date(2020, 6, 1))}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.roster.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
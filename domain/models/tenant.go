@@ -0,0 +1,33 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	"time"
+)
+
+var (
+	ErrTenantNotFound      = xerrors.New("tenant is not found")
+	ErrTenantUnresolved    = xerrors.New("tenant is not resolved")
+	ErrTenantAlreadyExists = xerrors.New("tenant already exists")
+)
+
+// Tenant is a company using the service, e.g. a subsidiary. Every data belongs to a tenant and is never shared.
+// AuthTenantID is the tenant of the identity platform its users sign in with, empty for the project's own users.
+type Tenant struct {
+	ID           int64
+	Name         string
+	AuthTenantID string
+	CreatedAt    time.Time `xorm:"created"`
+	UpdatedAt    time.Time `xorm:"updated"`
+}
+
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+func (t *Tenant) Validate() error {
+	if t.Name == "" {
+		return xerrors.New("name is empty")
+	}
+	return nil
+}
@@ -1,14 +1,19 @@
 package models
 
 import (
+	"golang.org/x/xerrors"
 	"time"
 )
 
+var ErrUserNotFound = xerrors.New("user is not found")
+
 type User struct {
 	ID        string
+	TenantID  int64
 	Name      string
 	Email     string
 	ImageURL  string
+	HiredAt   time.Time
 	CreatedAt time.Time `xorm:"created"`
 	UpdatedAt time.Time `xorm:"updated"`
 }
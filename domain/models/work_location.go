@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+type WorkLocation uint8
+
+const (
+	WorkLocationNone WorkLocation = iota
+	WorkLocationOffice
+	WorkLocationRemote
+	WorkLocationClientSite
+)
+
+// WorkLocation returns where the session of the clock-in was worked.
+// Clock-ins recorded before locations were recorded count as office work. Other punches have no location.
+func (t *AttendanceTime) WorkLocation() WorkLocation {
+	if AttendanceKind(t.AttendanceKindID) != AttendanceKindClockIn {
+		return WorkLocationNone
+	}
+	if t.WorkLocationID == uint8(WorkLocationNone) {
+		return WorkLocationOffice
+	}
+	return WorkLocation(t.WorkLocationID)
+}
+
+// WorkLocations returns the locations the sessions of the attendance were worked at, in the order first worked.
+func (a *Attendance) WorkLocations() []WorkLocation {
+	locations := make([]WorkLocation, 0)
+	seen := make(map[WorkLocation]bool)
+	for _, s := range a.Sessions {
+		l := s.ClockedIn.WorkLocation()
+		if !seen[l] {
+			seen[l] = true
+			locations = append(locations, l)
+		}
+	}
+	return locations
+}
+
+func (a *Attendance) WorkedAt(location WorkLocation) bool {
+	for _, l := range a.WorkLocations() {
+		if l == location {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByWorkLocation returns the attendances with a session worked at the location.
+func (attendances Attendances) FilterByWorkLocation(location WorkLocation) Attendances {
+	filtered := make(Attendances, 0)
+	for _, a := range attendances {
+		if a.WorkedAt(location) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// WorkLocationDays is the number of days worked at each location.
+// A day worked at several locations, e.g. at the office in the morning and at home in the afternoon, counts for each.
+type WorkLocationDays struct {
+	Office     int
+	Remote     int
+	ClientSite int
+}
+
+// CountWorkLocationDays counts the days the attendances were worked at each location in loc.
+func (attendances Attendances) CountWorkLocationDays(loc *time.Location) WorkLocationDays {
+	days := make(map[WorkLocation]map[string]bool)
+	for _, a := range attendances {
+		day := a.AttendedAt.In(loc).Format(holidayKeyLayout)
+		for _, l := range a.WorkLocations() {
+			if days[l] == nil {
+				days[l] = make(map[string]bool)
+			}
+			days[l][day] = true
+		}
+	}
+	return WorkLocationDays{
+		Office:     len(days[WorkLocationOffice]),
+		Remote:     len(days[WorkLocationRemote]),
+		ClientSite: len(days[WorkLocationClientSite]),
+	}
+}
+
+func (l WorkLocation) String() string {
+	switch l {
+	case WorkLocationOffice:
+		return "出社"
+	case WorkLocationRemote:
+		return "在宅勤務"
+	case WorkLocationClientSite:
+		return "客先常駐"
+	}
+	return "不明"
+}
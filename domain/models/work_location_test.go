@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestLocatedShift returns an attendance of the sessions, each clocked in at the location for an hour.
+func newTestLocatedShift(day time.Time, locations ...WorkLocation) *Attendance {
+	times := make([]*AttendanceTime, 0)
+	for i, l := range locations {
+		in := day.Add(time.Duration(9+2*i) * time.Hour)
+		times = append(times,
+			&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockIn), WorkLocationID: uint8(l), PushedAt: in},
+			&AttendanceTime{AttendanceKindID: uint8(AttendanceKindClockOut), PushedAt: in.Add(time.Hour)},
+		)
+	}
+	a := &Attendance{AttendedAt: day.Add(9 * time.Hour)}
+	a.SetTimes(times)
+	return a
+}
+
+func TestAttendance_WorkLocations(t *testing.T) {
+	tests := []struct {
+		name       string
+		attendance *Attendance
+		want       []WorkLocation
+	}{
+		{
+			name:       "Should count a clock-in without location as office",
+			attendance: newTestLocatedShift(date(2020, 6, 1), WorkLocationNone),
+			want:       []WorkLocation{WorkLocationOffice},
+		},
+		{
+			name:       "Should return each location once in order",
+			attendance: newTestLocatedShift(date(2020, 6, 1), WorkLocationRemote, WorkLocationOffice, WorkLocationRemote),
+			want:       []WorkLocation{WorkLocationRemote, WorkLocationOffice},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.attendance.WorkLocations()
+			if len(got) != len(tt.want) {
+				t.Fatalf("WorkLocations() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("WorkLocations() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAttendances_CountWorkLocationDays(t *testing.T) {
+	attendances := Attendances{
+		newTestLocatedShift(date(2020, 6, 1), WorkLocationOffice),
+		newTestLocatedShift(date(2020, 6, 2), WorkLocationRemote),
+		newTestLocatedShift(date(2020, 6, 3), WorkLocationOffice, WorkLocationRemote),
+		newTestLocatedShift(date(2020, 6, 4), WorkLocationClientSite),
+	}
+	want := WorkLocationDays{Office: 2, Remote: 2, ClientSite: 1}
+	if got := attendances.CountWorkLocationDays(time.UTC); got != want {
+		t.Errorf("CountWorkLocationDays() = %+v, want %+v", got, want)
+	}
+	if got := attendances.FilterByWorkLocation(WorkLocationRemote); len(got) != 2 {
+		t.Errorf("FilterByWorkLocation() got %d attendances, want %d", len(got), 2)
+	}
+}
@@ -4,6 +4,7 @@ import "time"
 
 type WorkingHour struct {
 	ID           int64
+	TenantID     int64
 	StartedAt    time.Time
 	FinishedAt   time.Time
 	WorkingHours float64
@@ -14,3 +15,9 @@ type WorkingHour struct {
 func (WorkingHour) TableName() string {
 	return "working_hours"
 }
+
+// RequiredHours returns the hours to work between the days of start and end:
+// the business days of the calendar times the daily working hours.
+func RequiredHours(cal *Calendar, start, end time.Time, dailyHours float64) float64 {
+	return float64(cal.CountBusinessDays(start, end)) * dailyHours
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequiredHours(t *testing.T) {
+	cal := NewCalendar(time.UTC, []*Holiday{
+		{Date: date(2020, 12, 29), Name: "年末休暇"},
+		{Date: date(2020, 12, 30), Name: "年末休暇"},
+		{Date: date(2020, 12, 31), Name: "年末休暇"},
+	})
+	tests := []struct {
+		name       string
+		start      time.Time
+		end        time.Time
+		dailyHours float64
+		want       float64
+	}{
+		{name: "Should exclude national holidays", start: date(2020, 1, 1), end: date(2020, 1, 31), dailyHours: 8, want: 168},
+		{name: "Should exclude substitute holidays", start: date(2020, 2, 1), end: date(2020, 2, 29), dailyHours: 8, want: 144},
+		{name: "Should exclude company closures", start: date(2020, 12, 1), end: date(2020, 12, 31), dailyHours: 7.5, want: 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequiredHours(cal, tt.start, tt.end, tt.dailyHours); got != tt.want {
+				t.Errorf("RequiredHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,22 +8,23 @@ require (
 	github.com/Songmu/flextime v0.0.6
 	github.com/docker/go-units v0.4.0 // indirect
 	github.com/gin-contrib/cors v1.3.0
-	github.com/gin-gonic/gin v1.5.0
+	github.com/gin-gonic/gin v1.7.7
 	github.com/go-ozzo/ozzo-validation/v3 v3.8.1
 	github.com/go-sql-driver/mysql v1.4.1
 	github.com/golang-migrate/migrate/v4 v4.8.0
 	github.com/golang/mock v1.2.0
 	github.com/google/go-cmp v0.3.0
 	github.com/mattn/go-sqlite3 v1.10.0
+	github.com/nfnt/resize v0.0.0-20180221191011-83c6a9932646
 	github.com/satori/go.uuid v1.2.0
 	github.com/sirupsen/logrus v1.4.1
 	github.com/stretchr/testify v1.4.0
-	golang.org/x/crypto v0.0.0-20190617133340-57b3e21c3d56 // indirect
 	golang.org/x/net v0.0.0-20190827160401-ba9fcec4b297 // indirect
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543
 	google.golang.org/api v0.15.0
 	google.golang.org/grpc v1.21.0 // indirect
+	gopkg.in/go-playground/validator.v9 v9.29.1 // indirect
 	xorm.io/core v0.7.2-0.20190928055935-90aeac8d08eb
 	xorm.io/xorm v1.0.1
 )
@@ -8,7 +8,15 @@ import (
 	"os"
 )
 
-const AuthorizedUserIDKey = "authorized_user_id"
+const (
+	AuthorizedUserIDKey     = "authorized_user_id"
+	AuthorizedKioskIDKey    = "authorized_kiosk_id"
+	AuthorizedPrincipalKey  = "authorized_principal"
+	AuthorizedAuthTenantKey = "authorized_auth_tenant"
+	// TenantIDKey is the tenant the queries of a request are scoped to.
+	// It is a string for the gin context to carry it as a context value.
+	TenantIDKey = "tenant_id"
+)
 
 func loadCredFromJSON() (*option.ClientOption, error) {
 	json := os.Getenv("FIREBASE_SERVICE_JSON")
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Job is a task run periodically in the server process.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Runner runs the registered jobs in the background, each at its interval.
+// A job is not run again before its previous run finished, and a failing or panicking run does not stop the others.
+type Runner interface {
+	Register(job Job)
+	Start(ctx context.Context)
+	Stop()
+}
+
+type runner struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewRunner() Runner {
+	return &runner{}
+}
+
+func (r *runner) Register(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+// Start runs each job once right away and then at its interval until ctx is done or Stop is called.
+func (r *runner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go func(job Job) {
+			defer r.wg.Done()
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+			for {
+				run(ctx, job)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(job)
+	}
+}
+
+// Stop cancels the jobs and waits for the runs in progress.
+func (r *runner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func run(ctx context.Context, job Job) {
+	defer func() {
+		if v := recover(); v != nil {
+			logger.NewWarn(logrus.Fields{"job": job.Name}, fmt.Sprintf("job panicked: %v", v))
+		}
+	}()
+	if err := job.Run(ctx); err != nil {
+		logger.NewWarn(logrus.Fields{"job": job.Name}, err.Error())
+	}
+}
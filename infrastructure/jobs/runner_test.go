@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	var runs, panics int32
+	r := NewRunner()
+	r.Register(Job{
+		Name:     "count",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	r.Register(Job{
+		Name:     "panic",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&panics, 1)
+			panic("failed")
+		},
+	})
+
+	r.Start(context.Background())
+	time.Sleep(55 * time.Millisecond)
+	r.Stop()
+
+	count := atomic.LoadInt32(&runs)
+	if count < 2 {
+		t.Errorf("Runner ran the job %d times, want at least 2", count)
+	}
+	if atomic.LoadInt32(&panics) < 2 {
+		t.Errorf("Runner should keep running a job after it panicked")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if atomic.LoadInt32(&runs) != count {
+		t.Errorf("Runner ran the job after Stop")
+	}
+}
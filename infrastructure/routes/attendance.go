@@ -1,23 +1,81 @@
 package routes
 
 import (
-	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/anomaly"
 	"github.com/KouT127/attendance-management/api/handler/v1/attendance"
 	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
 	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
 	"github.com/gin-gonic/gin"
+	"log"
+	"os"
+	"strconv"
+	"time"
 )
 
+func attendanceServiceOptions() []services.AttendanceServiceOption {
+	opts := make([]services.AttendanceServiceOption, 0)
+	if v := os.Getenv("MAX_SHIFT_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours <= 0 {
+			log.Printf("Warning: invalid MAX_SHIFT_HOURS %s", v)
+		} else {
+			opts = append(opts, services.WithMaxShiftLength(time.Duration(hours)*time.Hour))
+		}
+	}
+	if v := os.Getenv("GEOFENCE_MODE"); v != "" {
+		mode, err := models.ParseGeofenceMode(v)
+		if err != nil {
+			log.Printf("Warning: invalid GEOFENCE_MODE %s", v)
+		} else {
+			opts = append(opts, services.WithGeofenceMode(mode))
+		}
+	}
+	return opts
+}
+
+// anomalyServiceOptions gives up on a clock-out when the attendance service no longer accepts it.
+func anomalyServiceOptions() []services.AnomalyServiceOption {
+	rules := models.DefaultAnomalyRules()
+	if hours, ok := positiveIntEnv("MAX_SHIFT_HOURS"); ok {
+		rules.ClockOutCutoff = time.Duration(hours) * time.Hour
+	}
+	return []services.AnomalyServiceOption{
+		services.WithAnomalyRules(rules),
+	}
+}
+
+// newAttendanceService returns the attendance service punches are recorded with,
+// checking the overtime limits and pushing the punches to the live boards.
+func newAttendanceService(store sqlstore.SQLStore) services.AttendanceService {
+	overtimeLimitService := services.NewOvertimeLimitService(store, overtimeLimitServiceOptions()...)
+	opts := append(attendanceServiceOptions(),
+		services.WithOvertimeLimitService(overtimeLimitService),
+		services.WithPresenceBroker(presenceBroker),
+	)
+	return services.NewAttendanceService(store, opts...)
+}
+
 func configureAttendancesRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
-	attendanceService := services.NewAttendanceService(store)
+	attendanceService := newAttendanceService(store)
 	handler := attendance.NewAttendanceHandler(attendanceService)
+	anomalyService := services.NewAnomalyService(store, anomalyServiceOptions()...)
+	anomalyHandler := anomaly.NewAnomalyHandler(anomalyService)
 
-	funcs := []gin.HandlerFunc{
-		middlewares.AuthRequired(),
-	}
+	funcs := authRequired(store)
+
+	restricted := networkRestricted(store)
+	readable := readableUser(store)
 
 	attendances := v1.Group("/attendances", funcs...)
-	attendances.GET("", handler.ListHandler)
-	attendances.POST("", handler.CreateHandler)
-	attendances.GET("/summary", handler.SummaryHandler)
+	attendances.GET("", readable, handler.ListHandler)
+	attendances.POST("", restricted, handler.CreateHandler)
+	attendances.POST("/clock-in", restricted, handler.ClockInHandler)
+	attendances.POST("/clock-out", restricted, handler.ClockOutHandler)
+	attendances.POST("/breaks/start", restricted, handler.BreakStartHandler)
+	attendances.POST("/breaks/end", restricted, handler.BreakEndHandler)
+	attendances.GET("/summary", readable, handler.SummaryHandler)
+	attendances.GET("/overtime", readable, handler.WorkBreakdownHandler)
+	attendances.GET("/anomalies", readable, anomalyHandler.ListHandler)
+	attendances.GET("/:id/history", readable, handler.HistoryHandler)
 }
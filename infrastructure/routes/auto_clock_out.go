@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/autoclockout"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureAutoClockOutRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	autoClockOutService := services.NewAutoClockOutService(store)
+	handler := autoclockout.NewAutoClockOutHandler(autoClockOutService)
+
+	funcs := authRequired(store)
+
+	group := v1.Group("/auto-clock-out", funcs...)
+	group.GET("/policy", handler.PolicyHandler)
+	group.PUT("/policy", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.UpdatePolicyHandler)
+}
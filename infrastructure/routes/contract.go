@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/contract"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureContractsRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	contractService := services.NewContractService(store)
+	handler := contract.NewContractHandler(contractService)
+
+	funcs := authRequired(store)
+
+	contracts := v1.Group("/contracts", funcs...)
+	contracts.GET("", readableUser(store), handler.ListHandler)
+	contracts.POST("", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.CreateHandler)
+}
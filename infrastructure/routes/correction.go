@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/v1/correction"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureCorrectionsRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	correctionService := services.NewCorrectionService(store)
+	handler := correction.NewCorrectionHandler(correctionService)
+
+	funcs := authRequired(store)
+
+	corrections := v1.Group("/corrections", funcs...)
+	corrections.GET("", readableUser(store), handler.ListHandler)
+	corrections.POST("", handler.CreateHandler)
+	corrections.GET("/reviews", handler.ReviewListHandler)
+	corrections.POST("/:id/approve", handler.ApproveHandler)
+	corrections.POST("/:id/reject", handler.RejectHandler)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/flextime"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureFlextimeRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	flextimeService := services.NewFlextimeService(store)
+	handler := flextime.NewFlextimeHandler(flextimeService)
+
+	funcs := authRequired(store)
+
+	group := v1.Group("/flextime", funcs...)
+	group.GET("/rule", handler.RuleHandler)
+	group.PUT("/rule", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.UpdateRuleHandler)
+	group.GET("/summary", readableUser(store), handler.SummaryHandler)
+}
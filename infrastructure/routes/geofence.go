@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/geofence"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureGeofencesRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	geofenceService := services.NewGeofenceService(store)
+	handler := geofence.NewGeofenceHandler(geofenceService)
+
+	funcs := authRequired(store)
+
+	geofences := v1.Group("/geofences", append(funcs, middlewares.PermissionRequired(models.PermissionManageSystem))...)
+	geofences.GET("", handler.ListHandler)
+	geofences.POST("", handler.CreateHandler)
+	geofences.DELETE("/:id", handler.DeleteHandler)
+}
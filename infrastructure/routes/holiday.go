@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/holiday"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureHolidaysRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	holidayService := services.NewHolidayService(store)
+	handler := holiday.NewHolidayHandler(holidayService)
+
+	funcs := authRequired(store)
+
+	holidays := v1.Group("/holidays", funcs...)
+	holidays.GET("", handler.ListHandler)
+	holidays.POST("", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.CreateHandler)
+	holidays.DELETE("/:id", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.DeleteHandler)
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/infrastructure/jobs"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	defaultAutoClockOutInterval = 10 * time.Minute
+	defaultAnomalyScanInterval  = time.Hour
+)
+
+// InitJobRunner registers the background jobs of the server.
+func InitJobRunner(store sqlstore.SQLStore) jobs.Runner {
+	runner := jobs.NewRunner()
+
+	interval := defaultAutoClockOutInterval
+	if minutes, ok := positiveIntEnv("AUTO_CLOCK_OUT_INTERVAL_MINUTES"); ok {
+		interval = time.Duration(minutes) * time.Minute
+	}
+	autoClockOutService := services.NewAutoClockOutService(store, services.WithAutoClockOutPresenceBroker(presenceBroker))
+	tenantService := services.NewTenantService(store)
+	runner.Register(jobs.Job{
+		Name:     "auto-clock-out",
+		Interval: interval,
+		Run: forEachTenant(tenantService, func(ctx context.Context) error {
+			_, err := autoClockOutService.CloseOpenAttendances(ctx)
+			return err
+		}),
+	})
+
+	scanInterval := defaultAnomalyScanInterval
+	if minutes, ok := positiveIntEnv("ANOMALY_SCAN_INTERVAL_MINUTES"); ok {
+		scanInterval = time.Duration(minutes) * time.Minute
+	}
+	anomalyService := services.NewAnomalyService(store, anomalyServiceOptions()...)
+	runner.Register(jobs.Job{
+		Name:     "anomaly-scan",
+		Interval: scanInterval,
+		Run:      forEachTenant(tenantService, anomalyService.ScanAllAnomalies),
+	})
+	return runner
+}
+
+// forEachTenant runs the job once for every tenant, scoped to it.
+// A tenant failing does not keep the job from running for the others.
+func forEachTenant(service services.TenantService, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		tenants, err := service.GetTenants(ctx)
+		if err != nil {
+			return err
+		}
+		for _, t := range tenants {
+			if err := run(sqlstore.WithTenant(ctx, t.ID)); err != nil {
+				logger.NewWarn(logrus.Fields{"tenant_id": t.ID}, err.Error())
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"crypto/rand"
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/kiosk"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+	"log"
+	"os"
+	"time"
+)
+
+// punchCodeSigner signs the punch codes with PUNCH_CODE_SECRET.
+// Without it a random secret is used, and the codes issued are invalidated on restart.
+func punchCodeSigner() *models.PunchCodeSigner {
+	secret := []byte(os.Getenv("PUNCH_CODE_SECRET"))
+	if len(secret) == 0 {
+		log.Printf("Warning: PUNCH_CODE_SECRET environment variable not set.\n")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal(err)
+		}
+	}
+	lifetime := services.DefaultPunchCodeLifetime
+	if minutes, ok := positiveIntEnv("PUNCH_CODE_LIFETIME_MINUTES"); ok {
+		lifetime = time.Duration(minutes) * time.Minute
+	}
+	return models.NewPunchCodeSigner(secret, lifetime)
+}
+
+func configureKiosksRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	kioskService := services.NewKioskService(store, services.WithPunchCodeSigner(punchCodeSigner()))
+	handler := kiosk.NewKioskHandler(kioskService, newAttendanceService(store))
+
+	funcs := authRequired(store)
+
+	kiosks := v1.Group("/kiosks", append(funcs, middlewares.PermissionRequired(models.PermissionManageSystem))...)
+	kiosks.GET("", handler.ListHandler)
+	kiosks.POST("", handler.CreateHandler)
+	kiosks.DELETE("/:id", handler.DeleteHandler)
+
+	codes := v1.Group("/punch-codes", funcs...)
+	codes.GET("", handler.PunchCodeHandler)
+	codes.POST("", middlewares.PermissionRequired(models.PermissionWriteAll), handler.IssuePunchCodesHandler)
+
+	// The terminal authenticates as the kiosk, and the employee by the punch code of each punch.
+	terminal := v1.Group("/kiosk", middlewares.KioskRequired(kioskService), networkRestricted(store))
+	terminal.POST("/attendances", handler.PunchHandler)
+	terminal.POST("/attendances/clock-in", handler.ClockInHandler)
+	terminal.POST("/attendances/clock-out", handler.ClockOutHandler)
+	terminal.POST("/attendances/breaks/start", handler.BreakStartHandler)
+	terminal.POST("/attendances/breaks/end", handler.BreakEndHandler)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/v1/leave"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureLeavesRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	leaveService := services.NewLeaveService(store)
+	handler := leave.NewLeaveHandler(leaveService)
+
+	funcs := authRequired(store)
+
+	leaves := v1.Group("/leaves", funcs...)
+	leaves.GET("", readableUser(store), handler.ListHandler)
+	leaves.POST("", handler.CreateHandler)
+	leaves.GET("/reviews", handler.ReviewListHandler)
+	leaves.GET("/balance", readableUser(store), handler.BalanceHandler)
+	leaves.POST("/:id/approve", handler.ApproveHandler)
+	leaves.POST("/:id/reject", handler.RejectHandler)
+}
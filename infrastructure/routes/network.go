@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/network"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/netutil"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net"
+	"os"
+)
+
+// trustedProxies are the load balancers and proxies in front of the server whose X-Forwarded-For is believed.
+func trustedProxies() []*net.IPNet {
+	v := os.Getenv("TRUSTED_PROXIES")
+	proxies, err := netutil.ParseCIDRs(v)
+	if err != nil {
+		log.Printf("Warning: invalid TRUSTED_PROXIES %s", v)
+		return nil
+	}
+	return proxies
+}
+
+// networkRestricted is put in front of the punches.
+func networkRestricted(store sqlstore.SQLStore) gin.HandlerFunc {
+	networkService := services.NewAllowedNetworkService(store)
+	return middlewares.NetworkRestricted(networkService, trustedProxies())
+}
+
+func configureNetworksRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	networkService := services.NewAllowedNetworkService(store)
+	handler := network.NewNetworkHandler(networkService)
+
+	funcs := authRequired(store)
+
+	networks := v1.Group("/allowed-networks", append(funcs, middlewares.PermissionRequired(models.PermissionManageSystem))...)
+	networks.GET("", handler.ListHandler)
+	networks.POST("", handler.CreateHandler)
+	networks.DELETE("/:id", handler.DeleteHandler)
+	networks.GET("/restriction", handler.RestrictionHandler)
+	networks.PUT("/restriction", handler.UpdateRestrictionHandler)
+}
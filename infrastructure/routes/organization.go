@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/organization"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureOrganizationRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	organizationService := services.NewOrganizationService(store)
+	handler := organization.NewOrganizationHandler(organizationService)
+
+	funcs := authRequired(store)
+
+	manage := middlewares.PermissionRequired(models.PermissionManageOrganization)
+	readable := readableUser(store)
+
+	departments := v1.Group("/departments", funcs...)
+	departments.GET("", handler.DepartmentsHandler)
+	departments.POST("", manage, handler.CreateDepartmentHandler)
+	departments.PUT("/:id", manage, handler.UpdateDepartmentHandler)
+	departments.DELETE("/:id", manage, handler.DeleteDepartmentHandler)
+
+	teams := v1.Group("/teams", funcs...)
+	teams.GET("", handler.TeamsHandler)
+	teams.POST("", manage, handler.CreateTeamHandler)
+	teams.PUT("/:id", manage, handler.UpdateTeamHandler)
+	teams.DELETE("/:id", manage, handler.DeleteTeamHandler)
+	teams.GET("/:id/members", handler.TeamMembersHandler)
+
+	assignments := v1.Group("/assignments", funcs...)
+	assignments.GET("", readable, handler.AssignmentsHandler)
+	assignments.POST("", manage, handler.CreateAssignmentHandler)
+	assignments.GET("/reports", readable, handler.ReportsHandler)
+	assignments.GET("/managers", readable, handler.ManagersHandler)
+}
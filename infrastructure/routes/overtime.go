@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/overtime"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// overtimeLimitServiceOptions reads the limits agreed in the overtime agreement from the environment.
+func overtimeLimitServiceOptions() []services.OvertimeLimitServiceOption {
+	limits := models.DefaultOvertimeLimits()
+	if hours, ok := positiveIntEnv("OVERTIME_MONTHLY_LIMIT_HOURS"); ok {
+		limits.Monthly = time.Duration(hours) * time.Hour
+	}
+	if hours, ok := positiveIntEnv("OVERTIME_ANNUAL_LIMIT_HOURS"); ok {
+		limits.Annual = time.Duration(hours) * time.Hour
+	}
+	if month, ok := positiveIntEnv("OVERTIME_AGREEMENT_START_MONTH"); ok && month <= 12 {
+		limits.StartMonth = time.Month(month)
+	}
+	return []services.OvertimeLimitServiceOption{
+		services.WithOvertimeLimits(limits),
+	}
+}
+
+func positiveIntEnv(key string) (int, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Printf("Warning: invalid %s %s", key, v)
+		return 0, false
+	}
+	return n, true
+}
+
+func configureOvertimeRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	overtimeLimitService := services.NewOvertimeLimitService(store, overtimeLimitServiceOptions()...)
+	handler := overtime.NewOvertimeHandler(overtimeLimitService)
+
+	funcs := authRequired(store)
+
+	limits := v1.Group("/overtime-limits", funcs...)
+	limits.GET("/risks", middlewares.PermissionRequired(models.PermissionReadAll), handler.RisksHandler)
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/presence"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// presenceBroker passes the punches recorded by the server to the live boards it serves.
+// The boards only see the punches of the same process.
+var presenceBroker = services.NewPresenceBroker()
+
+func presenceServiceOptions() []services.PresenceServiceOption {
+	opts := make([]services.PresenceServiceOption, 0)
+	if hours, ok := positiveIntEnv("MAX_SHIFT_HOURS"); ok {
+		opts = append(opts, services.WithPresenceMaxShiftLength(time.Duration(hours)*time.Hour))
+	}
+	return opts
+}
+
+func configurePresenceRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	presenceService := services.NewPresenceService(store, presenceBroker, presenceServiceOptions()...)
+	handler := presence.NewPresenceHandler(presenceService)
+
+	funcs := authRequired(store)
+
+	teams := v1.Group("/teams", append(funcs, middlewares.PermissionRequired(models.PermissionReadReports))...)
+	teams.GET("/:id/attendances", handler.TeamAttendancesHandler)
+	teams.GET("/:id/attendances/live", handler.LiveHandler)
+}
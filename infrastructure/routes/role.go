@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+	"os"
+	"strings"
+)
+
+// roleServiceOptions makes the users of INITIAL_ADMIN_USER_IDS, separated by commas, the first system admins.
+func roleServiceOptions() []services.RoleServiceOption {
+	opts := make([]services.RoleServiceOption, 0)
+	userIDs := make([]string, 0)
+	for _, id := range strings.Split(os.Getenv("INITIAL_ADMIN_USER_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) != 0 {
+		opts = append(opts, services.WithInitialAdmins(userIDs...))
+	}
+	return opts
+}
+
+// authRequired authenticates the user, scopes the request to its tenant and loads its roles,
+// for the routes to authorize the principal with.
+func authRequired(store sqlstore.SQLStore) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		middlewares.AuthRequired(),
+		middlewares.TenantRequired(services.NewTenantService(store)),
+		middlewares.PrincipalRequired(services.NewRoleService(store, roleServiceOptions()...)),
+	}
+}
+
+// readableUser is put in front of the routes reading the data of a user, the principal unless another is given by user_id.
+func readableUser(store sqlstore.SQLStore) gin.HandlerFunc {
+	return middlewares.TargetUser(services.NewRoleService(store), models.AccessRead)
+}
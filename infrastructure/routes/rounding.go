@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/rounding"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureRoundingRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	roundingRuleService := services.NewRoundingRuleService(store)
+	handler := rounding.NewRoundingHandler(roundingRuleService)
+
+	funcs := authRequired(store)
+
+	rules := v1.Group("/rounding-rules", funcs...)
+	rules.GET("", handler.ListHandler)
+	rules.PUT("", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.UpdateHandler)
+}
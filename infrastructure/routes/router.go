@@ -20,10 +20,26 @@ func configureV1Router(r *gin.Engine, store sqlstore.SQLStore, upl uploader.Uplo
 	group := r.Group("/v1")
 	configureUsersRouter(group, store)
 	configureAttendancesRouter(group, store)
+	configureCorrectionsRouter(group, store)
+	configureLeavesRouter(group, store)
+	configureHolidaysRouter(group, store)
+	configureContractsRouter(group, store)
+	configureOvertimeRouter(group, store)
+	configureWorkWeekRouter(group, store)
+	configureRoundingRouter(group, store)
+	configureFlextimeRouter(group, store)
+	configureShiftsRouter(group, store)
+	configureAutoClockOutRouter(group, store)
+	configureGeofencesRouter(group, store)
+	configureNetworksRouter(group, store)
+	configureKiosksRouter(group, store)
+	configureOrganizationRouter(group, store)
+	configurePresenceRouter(group, store)
 	configureImagesRouter(group, store, upl)
 }
 
-func InitRouter(store sqlstore.SQLStore, upl uploader.Uploader) {
+// InitRouter returns the server of the API on PORT, for the caller to start and shut down.
+func InitRouter(store sqlstore.SQLStore, upl uploader.Uploader) *http.Server {
 	r := gin.Default()
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -38,6 +54,8 @@ func InitRouter(store sqlstore.SQLStore, upl uploader.Uploader) {
 
 	configureV1Router(r, store, upl)
 	configureDefaultRouter(r)
-	http.Handle("/", r)
-	log.Fatal(r.Run(":" + port))
+	return &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/shift"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureShiftsRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	shiftService := services.NewShiftService(store)
+	handler := shift.NewShiftHandler(shiftService)
+
+	funcs := authRequired(store)
+
+	shifts := v1.Group("/shifts", funcs...)
+	shifts.GET("", readableUser(store), handler.ListHandler)
+	shifts.GET("/roster", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.RosterHandler)
+	shifts.PUT("/roster", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.PublishRosterHandler)
+	shifts.GET("/templates", handler.TemplatesHandler)
+	shifts.POST("/templates", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.CreateTemplateHandler)
+}
@@ -2,8 +2,10 @@ package routes
 
 import (
 	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/role"
 	"github.com/KouT127/attendance-management/api/handler/v1/user"
 	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
 	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
 	"github.com/gin-gonic/gin"
 )
@@ -11,12 +13,14 @@ import (
 func configureUsersRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
 	userService := services.NewUserService(store)
 	handler := user.NewUserHandler(userService)
+	roleService := services.NewRoleService(store)
+	roleHandler := role.NewRoleHandler(roleService)
 
-	funcs := []gin.HandlerFunc{
-		middlewares.AuthRequired(),
-	}
+	funcs := authRequired(store)
 
 	users := v1.Group("/users", funcs...)
 	users.POST("/mine", handler.MineHandler)
+	users.GET("/mine/roles", roleHandler.MineHandler)
 	users.PUT("/:id", handler.UpdateHandler)
+	users.PUT("/:id/roles", middlewares.PermissionRequired(models.PermissionManageSystem), roleHandler.UpdateRolesHandler)
 }
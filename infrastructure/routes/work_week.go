@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/KouT127/attendance-management/api/handler/middlewares"
+	"github.com/KouT127/attendance-management/api/handler/v1/workweek"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/gin-gonic/gin"
+)
+
+func configureWorkWeekRouter(v1 *gin.RouterGroup, store sqlstore.SQLStore) {
+	workWeekRuleService := services.NewWorkWeekRuleService(store)
+	handler := workweek.NewWorkWeekHandler(workWeekRuleService)
+
+	funcs := authRequired(store)
+
+	rule := v1.Group("/work-week-rule", funcs...)
+	rule.GET("", handler.GetHandler)
+	rule.PUT("", middlewares.PermissionRequired(models.PermissionManageWorkRules), handler.UpdateHandler)
+}
@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type AllowedNetwork interface {
+	GetAllowedNetwork(ctx context.Context, id int64) (*models.AllowedNetwork, error)
+	GetAllowedNetworks(ctx context.Context) (models.AllowedNetworks, error)
+	CreateAllowedNetwork(ctx context.Context, network *models.AllowedNetwork) error
+	DeleteAllowedNetwork(ctx context.Context, id int64) error
+	GetNetworkRestriction(ctx context.Context) (*models.NetworkRestriction, error)
+	CreateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) error
+	UpdateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) error
+}
+
+func (sqlStore) GetAllowedNetwork(ctx context.Context, id int64) (*models.AllowedNetwork, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	network := &models.AllowedNetwork{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(network)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return network, nil
+}
+
+func (sqlStore) GetAllowedNetworks(ctx context.Context) (models.AllowedNetworks, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make(models.AllowedNetworks, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+func (sqlStore) CreateAllowedNetwork(ctx context.Context, network *models.AllowedNetwork) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	network.TenantID = tenantID
+	if _, err := sess.Insert(network); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteAllowedNetwork(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.AllowedNetwork{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetNetworkRestriction returns the network restriction of the company, or nil when it is not set.
+func (sqlStore) GetNetworkRestriction(ctx context.Context) (*models.NetworkRestriction, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := &models.NetworkRestriction{}
+	has, err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Get(restriction)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return restriction, nil
+}
+
+func (sqlStore) CreateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	restriction.TenantID = tenantID
+	if _, err := sess.Insert(restriction); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateNetworkRestriction(ctx context.Context, restriction *models.NetworkRestriction) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(restriction.ID).
+		Cols("network_restriction_mode_id").
+		Update(restriction); err != nil {
+		return err
+	}
+	return nil
+}
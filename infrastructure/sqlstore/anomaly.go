@@ -0,0 +1,58 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type Anomaly interface {
+	GetAttendanceAnomalies(ctx context.Context, userID string, start, end time.Time) ([]*models.AttendanceAnomaly, error)
+	CreateAttendanceAnomaly(ctx context.Context, anomaly *models.AttendanceAnomaly) error
+	UpdateAttendanceAnomalyResolved(ctx context.Context, anomaly *models.AttendanceAnomaly) error
+}
+
+// GetAttendanceAnomalies returns the anomalies of the user on the attendances between start and end,
+// including resolved ones, in the order the attendances were attended.
+func (sqlStore) GetAttendanceAnomalies(ctx context.Context, userID string, start, end time.Time) ([]*models.AttendanceAnomaly, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]*models.AttendanceAnomaly, 0)
+	err = sess.
+		Where("tenant_id = ?", tenantID).
+		Where("user_id = ?", userID).
+		Where("attended_at Between ? and ? ", start, end).
+		OrderBy("attended_at, pushed_at").
+		Find(&anomalies)
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+func (sqlStore) CreateAttendanceAnomaly(ctx context.Context, anomaly *models.AttendanceAnomaly) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	anomaly.TenantID = tenantID
+	if _, err := sess.Insert(anomaly); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateAttendanceAnomalyResolved writes when the anomaly was resolved, or reopens it with a zero time.
+func (sqlStore) UpdateAttendanceAnomalyResolved(ctx context.Context, anomaly *models.AttendanceAnomaly) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(anomaly.ID).Cols("resolved_at").Update(anomaly); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Assignment interface {
+	GetAssignments(ctx context.Context, params *models.GetAssignmentsParameters) (models.Assignments, error)
+	CreateAssignment(ctx context.Context, assignment *models.Assignment) error
+	UpdateAssignment(ctx context.Context, assignment *models.Assignment) error
+}
+
+// GetAssignments returns the assignments matching the parameters, per user in the order they started.
+func (sqlStore) GetAssignments(ctx context.Context, params *models.GetAssignmentsParameters) (models.Assignments, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.UserID != "" {
+		sess.Where("user_id = ?", params.UserID)
+	}
+	if params.TeamID != 0 {
+		sess.Where("team_id = ?", params.TeamID)
+	}
+	if params.ManagerID != "" {
+		sess.Where("manager_id = ?", params.ManagerID)
+	}
+	assignments := make(models.Assignments, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("user_id, started_at").Find(&assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (sqlStore) CreateAssignment(ctx context.Context, assignment *models.Assignment) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	assignment.TenantID = tenantID
+	if _, err := sess.Insert(assignment); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateAssignment(ctx context.Context, assignment *models.Assignment) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(assignment.ID).Update(assignment); err != nil {
+		return err
+	}
+	return nil
+}
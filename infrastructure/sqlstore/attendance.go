@@ -12,9 +12,17 @@ import (
 
 type Attendance interface {
 	GetAttendancesCount(ctx context.Context, query *models.GetAttendancesParameters) (int64, error)
+	GetAttendedDaysCount(ctx context.Context, userID string, start, end time.Time) (int64, error)
 	GetLatestAttendance(ctx context.Context, userID string) (*models.Attendance, error)
+	GetAttendanceByDate(ctx context.Context, userID string, date time.Time) (*models.Attendance, error)
+	GetOpenAttendance(ctx context.Context, userID string, since time.Time) (*models.Attendance, error)
+	GetUnclosedAttendances(ctx context.Context, since time.Time) (models.Attendances, error)
 	GetAttendances(ctx context.Context, userID string, month int) (models.Attendances, error)
+	GetAttendancesBetween(ctx context.Context, userID string, start, end time.Time) (models.Attendances, error)
+	GetAttendance(ctx context.Context, id int64) (*models.Attendance, error)
+	GetAttendanceTimes(ctx context.Context, attendanceID int64) ([]*models.AttendanceTime, error)
 	UpdateOldAttendanceTime(ctx context.Context, id int64, kindID uint8) error
+	UpdateAttendanceTimeModified(ctx context.Context, id int64) error
 	CreateAttendance(ctx context.Context, attendance *models.Attendance) error
 	CreateAttendanceTime(ctx context.Context, attendanceTime *models.AttendanceTime) error
 }
@@ -22,7 +30,7 @@ type Attendance interface {
 func (sqlStore) GetAttendancesCount(ctx context.Context, query *models.GetAttendancesParameters) (int64, error) {
 	var count int64
 
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -37,7 +45,7 @@ func (sqlStore) GetAttendancesCount(ctx context.Context, query *models.GetAttend
 	}
 	attendance := &models.Attendance{}
 	attendance.UserID = query.UserID
-	count, err = sess.Where("attendances.attended_at Between ? and ? ", start, end).Count(attendance)
+	count, err = sess.Where("attendances.tenant_id = ?", tenantID).Where("attendances.attended_at Between ? and ? ", start, end).Count(attendance)
 	if err != nil {
 		return 0, err
 	}
@@ -45,28 +53,45 @@ func (sqlStore) GetAttendancesCount(ctx context.Context, query *models.GetAttend
 	return count, nil
 }
 
-func (sqlStore) GetLatestAttendance(ctx context.Context, userID string) (*models.Attendance, error) {
+// GetAttendedDaysCount returns the number of days the user attended between start and end.
+func (sqlStore) GetAttendedDaysCount(ctx context.Context, userID string, start, end time.Time) (int64, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := sess.
+		Where("attendances.tenant_id = ?", tenantID).
+		Where("attendances.user_id = ?", userID).
+		Where("attendances.attended_at Between ? and ? ", start, end).
+		Count(&models.Attendance{})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (ss sqlStore) GetLatestAttendance(ctx context.Context, userID string) (*models.Attendance, error) {
+	return ss.GetAttendanceByDate(ctx, userID, flextime.Now())
+}
+
+// GetAttendanceByDate returns the latest attendance of the user attended on the JST calendar day of date.
+func (sqlStore) GetAttendanceByDate(ctx context.Context, userID string, date time.Time) (*models.Attendance, error) {
 	var (
-		attendance models.AttendanceDetail
+		attendance models.Attendance
 		has        bool
 	)
 
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return nil, err
 	}
-	now := flextime.Now()
-	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timezone.JSTLocation())
-	end := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 59, timezone.JSTLocation())
+	d := date.In(timezone.JSTLocation())
+	start := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, timezone.JSTLocation())
+	end := time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 59, timezone.JSTLocation())
 
-	has, err = sess.Select("attendances.*, clocked_in_time.*, clocked_out_time.*").
-		Table(AttendanceTable).
-		Join("left outer",
-			"attendances_time clocked_in_time",
-			"attendances.id = clocked_in_time.attendance_id and clocked_in_time.attendance_kind_id = 1 and clocked_in_time.is_modified = false").
-		Join("left outer",
-			"attendances_time clocked_out_time",
-			"attendances.id = clocked_out_time.attendance_id and clocked_out_time.attendance_kind_id = 2 and clocked_out_time.is_modified = false").
+	has, err = sess.
+		Where("attendances.tenant_id = ?", tenantID).
 		Where("attendances.user_id = ?", userID).
 		Where("attendances.attended_at Between ? and ? ", start, end).
 		Limit(1).
@@ -80,13 +105,59 @@ func (sqlStore) GetLatestAttendance(ctx context.Context, userID string) (*models
 	if !has {
 		return nil, nil
 	}
-	return attendance.ToAttendance(), nil
+	if err = fillAttendanceTimes(sess, tenantID, models.Attendances{&attendance}); err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// GetOpenAttendance returns the attendance whose latest session was clocked in since the given time
+// and is not clocked out yet. It is used to attach a clock-out to a shift that started on the previous day.
+func (sqlStore) GetOpenAttendance(ctx context.Context, userID string, since time.Time) (*models.Attendance, error) {
+	var (
+		latest     models.AttendanceTime
+		attendance models.Attendance
+	)
+
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	has, err := sess.Select("attendances_time.*").
+		Table(AttendanceTimeTable).
+		Join("inner", AttendanceTable, "attendances.id = attendances_time.attendance_id and attendances.tenant_id = attendances_time.tenant_id").
+		Where("attendances_time.tenant_id = ?", tenantID).
+		Where("attendances.user_id = ?", userID).
+		In("attendances_time.attendance_kind_id", uint8(models.AttendanceKindClockIn), uint8(models.AttendanceKindClockOut)).
+		Where("attendances_time.is_modified = false").
+		OrderBy("attendances_time.pushed_at desc, attendances_time.id desc").
+		Limit(1).
+		Get(&latest)
+	if err != nil {
+		return nil, err
+	}
+	if !has || models.AttendanceKind(latest.AttendanceKindID) != models.AttendanceKindClockIn || latest.PushedAt.Before(since) {
+		return nil, nil
+	}
+
+	has, err = sess.Where("tenant_id = ?", tenantID).Where("id = ?", latest.AttendanceID).Get(&attendance)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	if err = fillAttendanceTimes(sess, tenantID, models.Attendances{&attendance}); err != nil {
+		return nil, err
+	}
+	return &attendance, nil
 }
 
 func (sqlStore) GetAttendances(ctx context.Context, userID string, month int) (models.Attendances, error) {
 	attendances := make(models.Attendances, 0)
 	eng.NoAutoTime()
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -99,33 +170,149 @@ func (sqlStore) GetAttendances(ctx context.Context, userID string, month int) (m
 		return nil, err
 	}
 
-	err = sess.Select("attendances.*, clocked_in_time.*, clocked_out_time.*").
-		Table(AttendanceTable).
-		Join("left outer",
-			"attendances_time clocked_in_time",
-			"attendances.id = clocked_in_time.attendance_id and clocked_in_time.attendance_kind_id = 1 and clocked_in_time.is_modified = false").
-		Join("left outer",
-			"attendances_time clocked_out_time",
-			"attendances.id = clocked_out_time.attendance_id and clocked_out_time.attendance_kind_id = 2 and clocked_out_time.is_modified = false").
+	err = sess.
+		Where("attendances.tenant_id = ?", tenantID).
 		Where("attendances.attended_at Between ? and ? ", start, end).
 		Where("attendances.user_id = ?", userID).
 		OrderBy("-attendances.id").
-		Iterate(&models.AttendanceDetail{}, func(idx int, bean interface{}) error {
-			d := bean.(*models.AttendanceDetail)
-			a := d.ToAttendance()
-			attendances = append(attendances, a)
-			return nil
-		})
+		Find(&attendances)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = fillAttendanceTimes(sess, tenantID, attendances); err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+// GetUnclosedAttendances returns the attendances of everyone attended since the given time
+// whose latest session is not clocked out yet.
+func (sqlStore) GetUnclosedAttendances(ctx context.Context, since time.Time) (models.Attendances, error) {
+	attendances := make(models.Attendances, 0)
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.
+		Where("attendances.tenant_id = ?", tenantID).
+		Where("attendances.attended_at >= ?", since).
+		OrderBy("attendances.attended_at, attendances.id").
+		Find(&attendances)
+	if err != nil {
+		return nil, err
+	}
+	if err = fillAttendanceTimes(sess, tenantID, attendances); err != nil {
+		return nil, err
+	}
+
+	unclosed := make(models.Attendances, 0)
+	for _, a := range attendances {
+		if session := a.LatestSession(); session != nil && !session.IsClosed() {
+			unclosed = append(unclosed, a)
+		}
+	}
+	return unclosed, nil
+}
 
+// GetAttendancesBetween returns the attendances of the user attended between start and end in date order.
+func (sqlStore) GetAttendancesBetween(ctx context.Context, userID string, start, end time.Time) (models.Attendances, error) {
+	attendances := make(models.Attendances, 0)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	err = sess.
+		Where("attendances.tenant_id = ?", tenantID).
+		Where("attendances.attended_at Between ? and ? ", start, end).
+		Where("attendances.user_id = ?", userID).
+		OrderBy("attendances.attended_at, attendances.id").
+		Find(&attendances)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = fillAttendanceTimes(sess, tenantID, attendances); err != nil {
+		return nil, err
+	}
 	return attendances, nil
 }
 
+func (sqlStore) GetAttendance(ctx context.Context, id int64) (*models.Attendance, error) {
+	var attendance models.Attendance
+
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	has, err := sess.Where("tenant_id = ?", tenantID).ID(id).Get(&attendance)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	if err = fillAttendanceTimes(sess, tenantID, models.Attendances{&attendance}); err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// GetAttendanceTimes returns every punch of the attendance including superseded ones in the order they were recorded.
+func (sqlStore) GetAttendanceTimes(ctx context.Context, attendanceID int64) ([]*models.AttendanceTime, error) {
+	times := make([]*models.AttendanceTime, 0)
+
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Where("tenant_id = ?", tenantID).Table(AttendanceTimeTable).
+		Where("attendance_id = ?", attendanceID).
+		OrderBy("created_at, id").
+		Find(&times)
+	if err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+// fillAttendanceTimes loads the active punches of the attendances and builds their sessions and breaks.
+func fillAttendanceTimes(sess *DBSession, tenantID int64, attendances models.Attendances) error {
+	if len(attendances) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(attendances))
+	for _, a := range attendances {
+		ids = append(ids, a.ID)
+	}
+
+	times := make([]*models.AttendanceTime, 0)
+	err := sess.Where("tenant_id = ?", tenantID).Table(AttendanceTimeTable).
+		In("attendance_id", ids).
+		Where("is_modified = false").
+		OrderBy("pushed_at, id").
+		Find(&times)
+	if err != nil {
+		return err
+	}
+
+	grouped := make(map[int64][]*models.AttendanceTime)
+	for _, t := range times {
+		grouped[t.AttendanceID] = append(grouped[t.AttendanceID], t)
+	}
+	for _, a := range attendances {
+		a.SetTimes(grouped[a.ID])
+	}
+	return nil
+}
+
 func (sqlStore) UpdateOldAttendanceTime(ctx context.Context, id int64, kindID uint8) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
@@ -134,7 +321,7 @@ func (sqlStore) UpdateOldAttendanceTime(ctx context.Context, id int64, kindID ui
 		AttendanceKindID: kindID,
 		IsModified:       false,
 	}
-	_, err = sess.UseBool("is_modified").
+	_, err = sess.Where("tenant_id = ?", tenantID).UseBool("is_modified").
 		Update(&models.AttendanceTime{IsModified: true}, query)
 
 	if err != nil {
@@ -143,11 +330,28 @@ func (sqlStore) UpdateOldAttendanceTime(ctx context.Context, id int64, kindID ui
 	return nil
 }
 
+// UpdateAttendanceTimeModified marks a single punch as superseded.
+func (sqlStore) UpdateAttendanceTimeModified(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = sess.Where("tenant_id = ?", tenantID).ID(id).
+		UseBool("is_modified").
+		Update(&models.AttendanceTime{IsModified: true})
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (sqlStore) CreateAttendance(ctx context.Context, attendance *models.Attendance) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
+	attendance.TenantID = tenantID
 	if _, err := sess.Insert(attendance); err != nil {
 		return err
 	}
@@ -155,10 +359,11 @@ func (sqlStore) CreateAttendance(ctx context.Context, attendance *models.Attenda
 }
 
 func (sqlStore) CreateAttendanceTime(ctx context.Context, attendanceTime *models.AttendanceTime) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
+	attendanceTime.TenantID = tenantID
 	if _, err := sess.Insert(attendanceTime); err != nil {
 		return err
 	}
@@ -17,12 +17,16 @@ import (
 var IgnoreGlobalOptions = cmp.Options{
 	cmpopts.IgnoreFields(models.Attendance{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.Attendance{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.Attendance{}, "TenantID"),
 	cmpopts.IgnoreFields(models.AttendanceTime{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.AttendanceTime{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.AttendanceTime{}, "TenantID"),
 	cmpopts.IgnoreFields(models.User{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.User{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.User{}, "TenantID"),
 	cmpopts.IgnoreFields(models.WorkingHour{}, "CreatedAt"),
 	cmpopts.IgnoreFields(models.WorkingHour{}, "UpdatedAt"),
+	cmpopts.IgnoreFields(models.WorkingHour{}, "TenantID"),
 }
 
 func TestCreateAttendance(t *testing.T) {
@@ -33,7 +37,7 @@ func TestCreateAttendance(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -49,7 +53,7 @@ func TestCreateAttendance(t *testing.T) {
 		{
 			"Should not create attendance",
 			args{
-				ctx:        context.Background(),
+				ctx:        NewTestContext(),
 				attendance: &models.Attendance{},
 			},
 			true,
@@ -57,7 +61,7 @@ func TestCreateAttendance(t *testing.T) {
 		{
 			"Should create clocked in attendance",
 			args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				attendance: &models.Attendance{
 					UserID: user.ID,
 				},
@@ -85,7 +89,7 @@ func TestCreateAttendanceTime(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -93,7 +97,7 @@ func TestCreateAttendanceTime(t *testing.T) {
 		UserID: user.ID,
 	}
 
-	if err := store.CreateAttendance(context.Background(), attendance); err != nil {
+	if err := store.CreateAttendance(NewTestContext(), attendance); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -105,7 +109,7 @@ func TestCreateAttendanceTime(t *testing.T) {
 		{
 			"Should create attendance time",
 			args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				attendanceTime: &models.AttendanceTime{
 					Remark:           "test",
 					AttendanceKindID: uint8(models.AttendanceKindClockIn),
@@ -121,7 +125,7 @@ func TestCreateAttendanceTime(t *testing.T) {
 		{
 			"Should not create attendance time",
 			args{
-				ctx:            context.Background(),
+				ctx:            NewTestContext(),
 				attendanceTime: &models.AttendanceTime{},
 			},
 			true,
@@ -146,7 +150,7 @@ func TestFetchAttendances(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -157,7 +161,7 @@ func TestFetchAttendances(t *testing.T) {
 		UpdatedAt:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Second),
 	}
 
-	if err := store.CreateAttendance(context.Background(), attendance); err != nil {
+	if err := store.CreateAttendance(NewTestContext(), attendance); err != nil {
 		t.Errorf("CreateAttendance() failed%s", err)
 	}
 
@@ -171,11 +175,12 @@ func TestFetchAttendances(t *testing.T) {
 		UpdatedAt:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Second),
 	}
 
-	if err := store.CreateAttendanceTime(context.Background(), time); err != nil {
+	if err := store.CreateAttendanceTime(NewTestContext(), time); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
 	attendance.ClockedIn = time
+	attendance.Sessions = []*models.AttendanceSession{{ClockedIn: time}}
 
 	type args struct {
 		ctx    context.Context
@@ -191,7 +196,7 @@ func TestFetchAttendances(t *testing.T) {
 		{
 			name: "Should get attendances",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: userID,
 				month:  0,
 			},
@@ -201,7 +206,7 @@ func TestFetchAttendances(t *testing.T) {
 		{
 			name: "Should get attendances",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: "",
 				month:  202001,
 			},
@@ -211,7 +216,7 @@ func TestFetchAttendances(t *testing.T) {
 		{
 			name: "Should get attendances by params",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: userID,
 				month:  202001,
 			},
@@ -223,7 +228,7 @@ func TestFetchAttendances(t *testing.T) {
 		{
 			name: "Should not get attendances by params",
 			args: args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: userID,
 				month:  202002,
 			},
@@ -303,6 +308,80 @@ func TestFetchLatestAttendance(t *testing.T) {
 	}
 }
 
+func TestGetOpenAttendance(t *testing.T) {
+	store := InitTestDatabase()
+	timezone.Set("Asia/Tokyo")
+	userID := uuid.NewV4().String()
+
+	if err := store.CreateUser(NewTestContext(), &models.User{ID: userID, Name: "test1"}); err != nil {
+		t.Errorf("CreateUser() failed%s", err)
+	}
+
+	attendance := &models.Attendance{
+		UserID:     userID,
+		AttendedAt: time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+	if err := store.CreateAttendance(NewTestContext(), attendance); err != nil {
+		t.Errorf("CreateAttendance() failed%s", err)
+	}
+	clockedIn := &models.AttendanceTime{
+		Remark:           "test",
+		AttendanceKindID: uint8(models.AttendanceKindClockIn),
+		AttendanceID:     attendance.ID,
+		PushedAt:         time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+	if err := store.CreateAttendanceTime(NewTestContext(), clockedIn); err != nil {
+		t.Errorf("CreateAttendanceTime() failed%s", err)
+	}
+	attendance.ClockedIn = clockedIn
+	attendance.Sessions = []*models.AttendanceSession{{ClockedIn: clockedIn}}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		since  time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.Attendance
+		wantErr bool
+	}{
+		{
+			name: "Should get attendance opened on the previous day",
+			args: args{
+				ctx:    NewTestContext(),
+				userID: userID,
+				since:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want:    attendance,
+			wantErr: false,
+		},
+		{
+			name: "Should not get attendance opened before since",
+			args: args{
+				ctx:    NewTestContext(),
+				userID: userID,
+				since:  time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.GetOpenAttendance(tt.args.ctx, tt.args.userID, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetOpenAttendance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want, IgnoreGlobalOptions); diff != "" {
+				t.Errorf("GetOpenAttendance() diff %s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateOldAttendanceTime(t *testing.T) {
 	store := InitTestDatabase()
 	type args struct {
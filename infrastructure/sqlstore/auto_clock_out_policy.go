@@ -0,0 +1,55 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type AutoClockOutPolicy interface {
+	GetAutoClockOutPolicy(ctx context.Context) (*models.AutoClockOutPolicy, error)
+	CreateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) error
+	UpdateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) error
+}
+
+// GetAutoClockOutPolicy returns the auto clock-out policy of the company, or nil when it was never set.
+func (sqlStore) GetAutoClockOutPolicy(ctx context.Context) (*models.AutoClockOutPolicy, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &models.AutoClockOutPolicy{}
+	has, err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Get(policy)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+func (sqlStore) CreateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	policy.TenantID = tenantID
+	if _, err := sess.Insert(policy); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateAutoClockOutPolicy(ctx context.Context, policy *models.AutoClockOutPolicy) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(policy.ID).
+		Cols("is_enabled", "cutoff_minutes", "remark").
+		Update(policy); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Contract interface {
+	GetEmploymentContracts(ctx context.Context, userID string) (models.EmploymentContracts, error)
+	CreateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) error
+	UpdateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) error
+}
+
+// GetEmploymentContracts returns the contract history of the user in the order the contracts started.
+func (sqlStore) GetEmploymentContracts(ctx context.Context, userID string) (models.EmploymentContracts, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(models.EmploymentContracts, 0)
+	err = sess.
+		Where("tenant_id = ?", tenantID).
+		Where("user_id = ?", userID).
+		OrderBy("started_at").
+		Find(&contracts)
+	if err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+func (sqlStore) CreateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	contract.TenantID = tenantID
+	if _, err := sess.Insert(contract); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateEmploymentContract(ctx context.Context, contract *models.EmploymentContract) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(contract.ID).Update(contract); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Correction interface {
+	GetCorrectionRequest(ctx context.Context, id int64) (*models.CorrectionRequest, error)
+	GetCorrectionRequests(ctx context.Context, params *models.GetCorrectionRequestsParameters) ([]*models.CorrectionRequest, error)
+	CreateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) error
+	UpdateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) error
+}
+
+func (sqlStore) GetCorrectionRequest(ctx context.Context, id int64) (*models.CorrectionRequest, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.CorrectionRequest{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(request)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return request, nil
+}
+
+func (sqlStore) GetCorrectionRequests(ctx context.Context, params *models.GetCorrectionRequestsParameters) ([]*models.CorrectionRequest, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*models.CorrectionRequest, 0)
+	if params.UserID != "" {
+		sess.Where("user_id = ?", params.UserID)
+	}
+	if params.UserIDs != nil {
+		sess.In("user_id", params.UserIDs)
+	}
+	if params.ExcludeUserID != "" {
+		sess.Where("user_id <> ?", params.ExcludeUserID)
+	}
+	if params.Status != models.CorrectionStatusNone {
+		sess.Where("correction_status_id = ?", uint8(params.Status))
+	}
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("-id").Find(&requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (sqlStore) CreateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	request.TenantID = tenantID
+	if _, err := sess.Insert(request); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateCorrectionRequest(ctx context.Context, request *models.CorrectionRequest) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(request.ID).Update(request); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	uuid "github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func TestGetCorrectionRequests(t *testing.T) {
+	store := InitTestDatabase()
+	userID := uuid.NewV4().String()
+	otherID := uuid.NewV4().String()
+
+	pending := &models.CorrectionRequest{
+		UserID:             userID,
+		AttendanceKindID:   uint8(models.AttendanceKindClockIn),
+		TargetDate:         time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		PushedAt:           time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		Reason:             "test",
+		CorrectionStatusID: uint8(models.CorrectionStatusPending),
+	}
+	approved := &models.CorrectionRequest{
+		UserID:             otherID,
+		AttendanceKindID:   uint8(models.AttendanceKindClockOut),
+		TargetDate:         time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		PushedAt:           time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC),
+		Reason:             "test",
+		CorrectionStatusID: uint8(models.CorrectionStatusApproved),
+	}
+	for _, r := range []*models.CorrectionRequest{pending, approved} {
+		if err := store.CreateCorrectionRequest(NewTestContext(), r); err != nil {
+			t.Errorf("CreateCorrectionRequest() failed %s", err)
+		}
+	}
+
+	options := cmp.Options{
+		cmpopts.IgnoreFields(models.CorrectionRequest{}, "CreatedAt", "UpdatedAt"),
+	}
+	tests := []struct {
+		name    string
+		params  *models.GetCorrectionRequestsParameters
+		want    []*models.CorrectionRequest
+		wantErr bool
+	}{
+		{
+			name:    "Should get requests of user",
+			params:  &models.GetCorrectionRequestsParameters{UserID: userID},
+			want:    []*models.CorrectionRequest{pending},
+			wantErr: false,
+		},
+		{
+			name:    "Should get pending requests of others",
+			params:  &models.GetCorrectionRequestsParameters{ExcludeUserID: otherID, Status: models.CorrectionStatusPending},
+			want:    []*models.CorrectionRequest{pending},
+			wantErr: false,
+		},
+		{
+			name:    "Should not get approved requests as pending",
+			params:  &models.GetCorrectionRequestsParameters{UserID: otherID, Status: models.CorrectionStatusPending},
+			want:    []*models.CorrectionRequest{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.GetCorrectionRequests(NewTestContext(), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCorrectionRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want, options); diff != "" {
+				t.Errorf("GetCorrectionRequests() diff %s", diff)
+			}
+		})
+	}
+}
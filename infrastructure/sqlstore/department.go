@@ -0,0 +1,78 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Department interface {
+	GetDepartment(ctx context.Context, id int64) (*models.Department, error)
+	GetDepartments(ctx context.Context) (models.Departments, error)
+	CreateDepartment(ctx context.Context, department *models.Department) error
+	UpdateDepartment(ctx context.Context, department *models.Department) error
+	DeleteDepartment(ctx context.Context, id int64) error
+}
+
+func (sqlStore) GetDepartment(ctx context.Context, id int64) (*models.Department, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	department := &models.Department{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(department)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return department, nil
+}
+
+func (sqlStore) GetDepartments(ctx context.Context) (models.Departments, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	departments := make(models.Departments, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&departments); err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (sqlStore) CreateDepartment(ctx context.Context, department *models.Department) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	department.TenantID = tenantID
+	if _, err := sess.Insert(department); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateDepartment(ctx context.Context, department *models.Department) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(department.ID).Cols("parent_id", "name").Update(department); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteDepartment(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.Department{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type FlextimeRule interface {
+	GetFlextimeRule(ctx context.Context) (*models.FlextimeRule, error)
+	CreateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) error
+	UpdateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) error
+}
+
+// GetFlextimeRule returns the flextime rule of the company, or nil when flextime is not used.
+func (sqlStore) GetFlextimeRule(ctx context.Context) (*models.FlextimeRule, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.FlextimeRule{}
+	has, err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Get(rule)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return rule, nil
+}
+
+func (sqlStore) CreateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+	if _, err := sess.Insert(rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateFlextimeRule(ctx context.Context, rule *models.FlextimeRule) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(rule.ID).
+		Cols("period_months", "start_month", "core_start_minutes", "core_end_minutes", "carry_over_surplus").
+		Update(rule); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Geofence interface {
+	GetGeofence(ctx context.Context, id int64) (*models.Geofence, error)
+	GetGeofences(ctx context.Context) (models.Geofences, error)
+	CreateGeofence(ctx context.Context, geofence *models.Geofence) error
+	DeleteGeofence(ctx context.Context, id int64) error
+}
+
+func (sqlStore) GetGeofence(ctx context.Context, id int64) (*models.Geofence, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	geofence := &models.Geofence{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(geofence)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return geofence, nil
+}
+
+func (sqlStore) GetGeofences(ctx context.Context) (models.Geofences, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	geofences := make(models.Geofences, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&geofences); err != nil {
+		return nil, err
+	}
+	return geofences, nil
+}
+
+func (sqlStore) CreateGeofence(ctx context.Context, geofence *models.Geofence) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	geofence.TenantID = tenantID
+	if _, err := sess.Insert(geofence); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteGeofence(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.Geofence{}); err != nil {
+		return err
+	}
+	return nil
+}
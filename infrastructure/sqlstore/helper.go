@@ -1,15 +1,42 @@
 package sqlstore
 
 import (
+	"context"
 	"fmt"
+	"github.com/KouT127/attendance-management/domain/models"
 )
 
+// testTenantID is the tenant the test database is created with.
+const testTenantID = 1
+
 func deleteData() error {
 	tables := []string{
 		WorkingHourTable,
+		CorrectionTable,
+		LeaveRequestTable,
+		LeaveGrantTable,
+		HolidayTable,
+		ContractTable,
+		OvertimeAlertTable,
+		RoundingRuleTable,
+		FlextimeRuleTable,
+		WorkWeekRuleTable,
+		ShiftTable,
+		ShiftTemplateTable,
+		AnomalyTable,
+		AutoClockOutTable,
+		GeofenceTable,
+		NetworkTable,
+		RestrictionTable,
+		KioskTable,
+		UserRoleTable,
+		AssignmentTable,
+		TeamTable,
+		DepartmentTable,
 		AttendanceTimeTable,
 		AttendanceTable,
 		UserTable,
+		TenantTable,
 		"schema_migrations",
 	}
 	for _, table := range tables {
@@ -25,5 +52,13 @@ func DeleteTestData() error {
 	if err := deleteData(); err != nil {
 		return err
 	}
+	if _, err := eng.Insert(&models.Tenant{ID: testTenantID, Name: "test"}); err != nil {
+		return err
+	}
 	return nil
 }
+
+// NewTestContext returns the context of the tests, scoped to the tenant of the test database.
+func NewTestContext() context.Context {
+	return WithTenant(context.Background(), testTenantID)
+}
@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type Holiday interface {
+	GetHoliday(ctx context.Context, id int64) (*models.Holiday, error)
+	GetHolidays(ctx context.Context, start, end time.Time) ([]*models.Holiday, error)
+	CreateHoliday(ctx context.Context, holiday *models.Holiday) error
+	DeleteHoliday(ctx context.Context, id int64) error
+}
+
+func (sqlStore) GetHoliday(ctx context.Context, id int64) (*models.Holiday, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday := &models.Holiday{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(holiday)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return holiday, nil
+}
+
+// GetHolidays returns the company closure days between start and end.
+func (sqlStore) GetHolidays(ctx context.Context, start, end time.Time) ([]*models.Holiday, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make([]*models.Holiday, 0)
+	err = sess.
+		Where("tenant_id = ?", tenantID).
+		Where("date Between ? and ? ", start, end).
+		OrderBy("date").
+		Find(&holidays)
+	if err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (sqlStore) CreateHoliday(ctx context.Context, holiday *models.Holiday) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	holiday.TenantID = tenantID
+	if _, err := sess.Insert(holiday); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteHoliday(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.Holiday{}); err != nil {
+		return err
+	}
+	return nil
+}
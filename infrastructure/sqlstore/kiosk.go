@@ -0,0 +1,99 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type Kiosk interface {
+	GetKiosk(ctx context.Context, id int64) (*models.Kiosk, error)
+	GetKioskByTokenHash(ctx context.Context, tokenHash string) (*models.Kiosk, error)
+	GetKiosks(ctx context.Context) ([]*models.Kiosk, error)
+	CreateKiosk(ctx context.Context, kiosk *models.Kiosk) error
+	UpdateKioskLastUsedAt(ctx context.Context, id int64, usedAt time.Time) error
+	DeleteKiosk(ctx context.Context, id int64) error
+}
+
+func (sqlStore) GetKiosk(ctx context.Context, id int64) (*models.Kiosk, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kiosk := &models.Kiosk{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(kiosk)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return kiosk, nil
+}
+
+// GetKioskByTokenHash is not scoped to a tenant: a kiosk has no user, and the tenant of the kiosk is the one it punches for.
+func (sqlStore) GetKioskByTokenHash(ctx context.Context, tokenHash string) (*models.Kiosk, error) {
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kiosk := &models.Kiosk{}
+	has, err := sess.Where("token_hash = ?", tokenHash).Get(kiosk)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return kiosk, nil
+}
+
+func (sqlStore) GetKiosks(ctx context.Context) ([]*models.Kiosk, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kiosks := make([]*models.Kiosk, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&kiosks); err != nil {
+		return nil, err
+	}
+	return kiosks, nil
+}
+
+func (sqlStore) CreateKiosk(ctx context.Context, kiosk *models.Kiosk) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	kiosk.TenantID = tenantID
+	if _, err := sess.Insert(kiosk); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateKioskLastUsedAt(ctx context.Context, id int64, usedAt time.Time) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	kiosk := &models.Kiosk{LastUsedAt: usedAt}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Cols("last_used_at").Update(kiosk); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteKiosk(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.Kiosk{}); err != nil {
+		return err
+	}
+	return nil
+}
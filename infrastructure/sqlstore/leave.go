@@ -0,0 +1,125 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Leave interface {
+	GetLeaveRequest(ctx context.Context, id int64) (*models.LeaveRequest, error)
+	GetLeaveRequests(ctx context.Context, params *models.GetLeaveRequestsParameters) ([]*models.LeaveRequest, error)
+	CreateLeaveRequest(ctx context.Context, request *models.LeaveRequest) error
+	UpdateLeaveRequest(ctx context.Context, request *models.LeaveRequest) error
+	GetLeaveGrants(ctx context.Context, userID string) (models.LeaveGrants, error)
+	CreateLeaveGrant(ctx context.Context, grant *models.LeaveGrant) error
+	UpdateLeaveGrant(ctx context.Context, grant *models.LeaveGrant) error
+}
+
+func (sqlStore) GetLeaveRequest(ctx context.Context, id int64) (*models.LeaveRequest, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.LeaveRequest{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(request)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return request, nil
+}
+
+// GetLeaveRequests returns the leave requests matching the parameters.
+// From and To select the requests overlapping the period.
+func (sqlStore) GetLeaveRequests(ctx context.Context, params *models.GetLeaveRequestsParameters) ([]*models.LeaveRequest, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*models.LeaveRequest, 0)
+	if params.UserID != "" {
+		sess.Where("user_id = ?", params.UserID)
+	}
+	if params.UserIDs != nil {
+		sess.In("user_id", params.UserIDs)
+	}
+	if params.ExcludeUserID != "" {
+		sess.Where("user_id <> ?", params.ExcludeUserID)
+	}
+	if params.Status != models.LeaveStatusNone {
+		sess.Where("leave_status_id = ?", uint8(params.Status))
+	}
+	if !params.From.IsZero() {
+		sess.Where("end_date >= ?", params.From)
+	}
+	if !params.To.IsZero() {
+		sess.Where("start_date <= ?", params.To)
+	}
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("-id").Find(&requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (sqlStore) CreateLeaveRequest(ctx context.Context, request *models.LeaveRequest) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	request.TenantID = tenantID
+	if _, err := sess.Insert(request); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateLeaveRequest(ctx context.Context, request *models.LeaveRequest) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(request.ID).Update(request); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) GetLeaveGrants(ctx context.Context, userID string) (models.LeaveGrants, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make(models.LeaveGrants, 0)
+	if err = sess.Where("tenant_id = ?", tenantID).Where("user_id = ?", userID).OrderBy("granted_at").Find(&grants); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (sqlStore) CreateLeaveGrant(ctx context.Context, grant *models.LeaveGrant) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	grant.TenantID = tenantID
+	if _, err := sess.Insert(grant); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateLeaveGrant(ctx context.Context, grant *models.LeaveGrant) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(grant.ID).Cols("used_days").Update(grant); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+alter table attendances_time
+    drop column correction_request_id;
+
+alter table attendances_time
+    drop column approved_by;
+
+drop table correction_requests;
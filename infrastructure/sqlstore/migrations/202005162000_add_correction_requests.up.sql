@@ -0,0 +1,27 @@
+create table correction_requests
+(
+    id                   int unsigned auto_increment comment '修正申請ID',
+    user_id              varchar(100)     not null comment 'ユーザーID',
+    attendance_id        int unsigned     null comment '勤怠ID',
+    attendance_time_id   int unsigned     null comment '修正対象の打刻ID',
+    attendance_kind_id   tinyint unsigned not null comment '勤怠区分',
+    target_date          datetime         not null comment '対象日',
+    pushed_at            datetime         not null comment '申請する打刻時間',
+    reason               varchar(250)     not null comment '申請理由',
+    correction_status_id tinyint unsigned not null comment '申請状態',
+    reviewer_id          varchar(100)     null comment '承認者ID',
+    review_comment       varchar(250)     null comment '承認コメント',
+    reviewed_at          datetime         null comment '承認日',
+    created_at           datetime         null comment '作成日',
+    updated_at           datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '勤怠修正申請テーブル';
+
+create index correction_requests_index_user_id
+    on correction_requests (user_id);
+
+alter table attendances_time
+    add approved_by varchar(100) null comment '承認者ID';
+
+alter table attendances_time
+    add correction_request_id int unsigned null comment '修正申請ID';
@@ -0,0 +1,6 @@
+drop table leave_grants;
+
+drop table leave_requests;
+
+alter table users
+    drop column hired_at;
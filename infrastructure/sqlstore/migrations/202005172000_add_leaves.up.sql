@@ -0,0 +1,41 @@
+alter table users
+    add hired_at datetime null comment '入社日';
+
+create table leave_requests
+(
+    id              int unsigned auto_increment comment '休暇申請ID',
+    user_id         varchar(100)     not null comment 'ユーザーID',
+    leave_kind_id   tinyint unsigned not null comment '休暇区分',
+    leave_unit_id   tinyint unsigned not null comment '取得単位',
+    start_date      datetime         not null comment '開始日',
+    end_date        datetime         not null comment '終了日',
+    hours           decimal(4, 2)    not null default 0 comment '時間単位の取得時間',
+    days            decimal(5, 3)    not null default 0 comment '取得日数',
+    reason          varchar(250)     null comment '申請理由',
+    leave_status_id tinyint unsigned not null comment '申請状態',
+    reviewer_id     varchar(100)     null comment '承認者ID',
+    review_comment  varchar(250)     null comment '承認コメント',
+    reviewed_at     datetime         null comment '承認日',
+    created_at      datetime         null comment '作成日',
+    updated_at      datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '休暇申請テーブル';
+
+create index leave_requests_index_user_id
+    on leave_requests (user_id);
+
+create table leave_grants
+(
+    id         int unsigned auto_increment comment '有給付与ID',
+    user_id    varchar(100)  not null comment 'ユーザーID',
+    granted_at datetime      not null comment '付与日',
+    expires_at datetime      not null comment '失効日',
+    days       decimal(5, 3) not null comment '付与日数',
+    used_days  decimal(5, 3) not null default 0 comment '取得済日数',
+    created_at datetime      null comment '作成日',
+    updated_at datetime      null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '有給休暇付与テーブル';
+
+create index leave_grants_index_user_id
+    on leave_grants (user_id);
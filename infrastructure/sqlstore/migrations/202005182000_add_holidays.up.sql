@@ -0,0 +1,12 @@
+create table holidays
+(
+    id         int unsigned auto_increment comment '休日ID',
+    date       datetime    not null comment '日付',
+    name       varchar(50) not null comment '名称',
+    created_at datetime    null comment '作成日',
+    updated_at datetime    null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '会社休日テーブル';
+
+create unique index holidays_index_date
+    on holidays (date);
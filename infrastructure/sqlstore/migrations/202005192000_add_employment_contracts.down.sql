@@ -0,0 +1 @@
+drop table employment_contracts;
@@ -0,0 +1,16 @@
+create table employment_contracts
+(
+    id                 int unsigned auto_increment comment '雇用契約ID',
+    user_id            varchar(100)     not null comment 'ユーザーID',
+    employment_type_id tinyint unsigned not null comment '雇用区分',
+    weekly_hours       decimal(5, 2)    not null comment '週の所定労働時間',
+    workdays           tinyint unsigned not null comment '所定労働日(曜日ごとのビット)',
+    started_at         datetime         not null comment '開始日',
+    finished_at        datetime         null comment '終了日(この日を含まない)',
+    created_at         datetime         null comment '作成日',
+    updated_at         datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '雇用契約テーブル';
+
+create index employment_contracts_index_user_id
+    on employment_contracts (user_id);
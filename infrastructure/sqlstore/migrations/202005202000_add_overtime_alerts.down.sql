@@ -0,0 +1 @@
+drop table overtime_alerts;
@@ -0,0 +1,16 @@
+create table overtime_alerts
+(
+    id                      int unsigned auto_increment comment '警告ID',
+    user_id                 varchar(100)     not null comment 'ユーザーID',
+    month                   int unsigned     not null comment '対象期間(yyyymm)',
+    overtime_limit_kind_id  tinyint unsigned not null comment '上限の種類',
+    overtime_alert_level_id tinyint unsigned not null comment '警告レベル',
+    value                   decimal(7, 2)    not null comment '実績',
+    threshold               decimal(7, 2)    not null comment '上限',
+    created_at              datetime         null comment '作成日',
+    updated_at              datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '36協定の上限に対する警告テーブル';
+
+create index overtime_alerts_index_user_id_month
+    on overtime_alerts (user_id, month);
@@ -0,0 +1,4 @@
+alter table attendances_time
+    drop column rounded_at;
+
+drop table rounding_rules;
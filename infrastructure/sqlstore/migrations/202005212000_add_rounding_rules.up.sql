@@ -0,0 +1,16 @@
+create table rounding_rules
+(
+    id                    int unsigned auto_increment comment '丸めルールID',
+    attendance_kind_id    tinyint unsigned not null comment '勤怠区分',
+    unit_minutes          int unsigned     not null comment '丸め単位(分)',
+    rounding_direction_id tinyint unsigned not null comment '丸め方向',
+    created_at            datetime         null comment '作成日',
+    updated_at            datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '打刻の丸めルールテーブル';
+
+create unique index rounding_rules_index_attendance_kind_id
+    on rounding_rules (attendance_kind_id);
+
+alter table attendances_time
+    add rounded_at datetime null comment '丸め後の打刻時間';
@@ -0,0 +1 @@
+drop table flextime_rules;
@@ -0,0 +1,12 @@
+create table flextime_rules
+(
+    id                 int unsigned auto_increment comment 'フレックスタイムルールID',
+    period_months      int unsigned not null comment '清算期間(月)',
+    start_month        int unsigned not null comment '清算期間の起算月',
+    core_start_minutes int unsigned not null default 0 comment 'コアタイム開始(0時からの分)',
+    core_end_minutes   int unsigned not null default 0 comment 'コアタイム終了(0時からの分)',
+    carry_over_surplus bool         default false not null comment '超過時間を繰り越すか',
+    created_at         datetime     null comment '作成日',
+    updated_at         datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'フレックスタイム制のルールテーブル';
@@ -0,0 +1,3 @@
+drop table shift_assignments;
+
+drop table shift_templates;
@@ -0,0 +1,31 @@
+create table shift_templates
+(
+    id            int unsigned auto_increment comment 'シフトパターンID',
+    name          varchar(50)  not null comment 'シフト名',
+    start_minutes int unsigned not null comment '開始時刻(0時からの分)',
+    end_minutes   int unsigned not null comment '終了時刻(0時からの分)',
+    break_minutes int unsigned not null default 0 comment '休憩時間(分)',
+    created_at    datetime     null comment '作成日',
+    updated_at    datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'シフトパターンテーブル';
+
+create table shift_assignments
+(
+    id                int unsigned auto_increment comment 'シフトID',
+    user_id           varchar(100) not null comment 'ユーザーID',
+    shift_template_id int unsigned not null comment 'シフトパターンID',
+    work_date         datetime     not null comment '勤務日',
+    started_at        datetime     not null comment '開始予定時間',
+    finished_at       datetime     not null comment '終了予定時間',
+    break_minutes     int unsigned not null default 0 comment '休憩時間(分)',
+    created_at        datetime     null comment '作成日',
+    updated_at        datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'シフト表テーブル';
+
+create unique index shift_assignments_index_user_id_work_date
+    on shift_assignments (user_id, work_date);
+
+create index shift_assignments_index_work_date
+    on shift_assignments (work_date);
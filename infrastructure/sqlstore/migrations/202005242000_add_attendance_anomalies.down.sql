@@ -0,0 +1 @@
+drop table attendance_anomalies;
@@ -0,0 +1,20 @@
+create table attendance_anomalies
+(
+    id                 int unsigned auto_increment comment '異常ID',
+    user_id            varchar(100)     not null comment 'ユーザーID',
+    attendance_id      int unsigned     not null comment '勤怠ID',
+    attendance_time_id int unsigned     not null comment '対象の打刻ID',
+    anomaly_kind_id    tinyint unsigned not null comment '異常区分',
+    attended_at        datetime         not null comment '勤怠日',
+    pushed_at          datetime         not null comment '対象の打刻時間',
+    resolved_at        datetime         null comment '解消日',
+    created_at         datetime         null comment '作成日',
+    updated_at         datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '勤怠の異常テーブル';
+
+create unique index attendance_anomalies_index_kind_attendance_time
+    on attendance_anomalies (anomaly_kind_id, attendance_id, attendance_time_id);
+
+create index attendance_anomalies_index_user_id_attended_at
+    on attendance_anomalies (user_id, attended_at);
@@ -0,0 +1,4 @@
+alter table attendances_time
+    drop column is_automatic;
+
+drop table auto_clock_out_policies;
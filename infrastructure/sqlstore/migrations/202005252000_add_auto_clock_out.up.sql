@@ -0,0 +1,13 @@
+create table auto_clock_out_policies
+(
+    id             int unsigned auto_increment comment '自動退勤ポリシーID',
+    is_enabled     bool         default false not null comment '自動退勤を行うか',
+    cutoff_minutes int unsigned not null comment '翌日の締め時刻(0時からの分)',
+    remark         varchar(100) not null default '' comment '自動退勤の備考',
+    created_at     datetime     null comment '作成日',
+    updated_at     datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '自動退勤ポリシーテーブル';
+
+alter table attendances_time
+    add is_automatic bool default false not null comment '自動退勤による打刻か';
@@ -0,0 +1,2 @@
+alter table attendances_time
+    drop column work_location_id;
@@ -0,0 +1,2 @@
+alter table attendances_time
+    add work_location_id tinyint unsigned not null default 0 comment '勤務場所';
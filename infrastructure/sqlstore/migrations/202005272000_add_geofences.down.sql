@@ -0,0 +1,9 @@
+alter table attendances_time
+    drop column has_location,
+    drop column latitude,
+    drop column longitude,
+    drop column location_accuracy,
+    drop column geofence_id,
+    drop column geofence_status_id;
+
+drop table geofences;
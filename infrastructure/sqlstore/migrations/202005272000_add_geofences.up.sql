@@ -0,0 +1,19 @@
+create table geofences
+(
+    id            int unsigned auto_increment comment 'ジオフェンスID',
+    name          varchar(50)  not null comment 'オフィス名',
+    latitude      double       not null comment '中心の緯度',
+    longitude     double       not null comment '中心の経度',
+    radius_meters double       not null comment '半径(メートル)',
+    created_at    datetime     null comment '作成日',
+    updated_at    datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'ジオフェンステーブル';
+
+alter table attendances_time
+    add has_location       bool default false not null comment '位置情報があるか',
+    add latitude           double           not null default 0 comment '打刻時の緯度',
+    add longitude          double           not null default 0 comment '打刻時の経度',
+    add location_accuracy  double           not null default 0 comment '位置情報の精度(メートル)',
+    add geofence_id        int unsigned     not null default 0 comment '打刻したジオフェンスID',
+    add geofence_status_id tinyint unsigned not null default 0 comment 'ジオフェンスの確認結果';
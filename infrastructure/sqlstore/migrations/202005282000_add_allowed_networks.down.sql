@@ -0,0 +1,7 @@
+alter table attendances_time
+    drop column ip_address,
+    drop column network_status_id;
+
+drop table network_restrictions;
+
+drop table allowed_networks;
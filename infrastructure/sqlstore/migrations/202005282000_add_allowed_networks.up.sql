@@ -0,0 +1,22 @@
+create table allowed_networks
+(
+    id         int unsigned auto_increment comment '許可ネットワークID',
+    name       varchar(50) not null comment 'ネットワーク名',
+    cidr       varchar(50) not null comment 'ネットワーク(CIDR表記)',
+    created_at datetime    null comment '作成日',
+    updated_at datetime    null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '打刻を許可するネットワークテーブル';
+
+alter table attendances_time
+    add ip_address        varchar(45)      not null default '' comment '打刻したIPアドレス',
+    add network_status_id tinyint unsigned not null default 0 comment 'ネットワークの確認結果';
+
+create table network_restrictions
+(
+    id                          int unsigned auto_increment comment 'ネットワーク制限ID',
+    network_restriction_mode_id tinyint unsigned not null default 1 comment '許可ネットワーク外からの打刻の扱い',
+    created_at                  datetime null comment '作成日',
+    updated_at                  datetime null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '許可ネットワーク外からの打刻の扱いテーブル';
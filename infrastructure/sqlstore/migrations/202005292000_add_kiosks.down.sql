@@ -0,0 +1,4 @@
+alter table attendances_time
+    drop column kiosk_id;
+
+drop table kiosks;
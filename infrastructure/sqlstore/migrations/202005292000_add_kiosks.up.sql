@@ -0,0 +1,16 @@
+create table kiosks
+(
+    id           int unsigned auto_increment comment 'キオスク端末ID',
+    name         varchar(50) not null comment '端末名',
+    token_hash   varchar(64) not null comment '端末トークンのハッシュ',
+    last_used_at datetime    null comment '最終利用日時',
+    created_at   datetime    null comment '作成日',
+    updated_at   datetime    null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'キオスク端末テーブル';
+
+create unique index kiosks_index_token_hash
+    on kiosks (token_hash);
+
+alter table attendances_time
+    add kiosk_id int unsigned not null default 0 comment '打刻したキオスク端末ID';
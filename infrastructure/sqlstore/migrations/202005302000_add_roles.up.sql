@@ -0,0 +1,12 @@
+create table user_roles
+(
+    id         int unsigned auto_increment comment 'ユーザーロールID',
+    user_id    varchar(100)     not null comment 'ユーザーID',
+    role_id    tinyint unsigned not null comment 'ロールID',
+    created_at datetime         null comment '作成日',
+    updated_at datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'ユーザーロールテーブル';
+
+create unique index user_roles_index_user_id_role_id
+    on user_roles (user_id, role_id);
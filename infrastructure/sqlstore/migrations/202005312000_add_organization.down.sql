@@ -0,0 +1,5 @@
+drop table user_assignments;
+
+drop table teams;
+
+drop table departments;
@@ -0,0 +1,47 @@
+create table departments
+(
+    id         int unsigned auto_increment comment '部署ID',
+    parent_id  int unsigned not null default 0 comment '親部署ID',
+    name       varchar(50)  not null comment '部署名',
+    created_at datetime     null comment '作成日',
+    updated_at datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '部署テーブル';
+
+create index departments_index_parent_id
+    on departments (parent_id);
+
+create table teams
+(
+    id            int unsigned auto_increment comment 'チームID',
+    department_id int unsigned not null comment '部署ID',
+    name          varchar(50)  not null comment 'チーム名',
+    created_at    datetime     null comment '作成日',
+    updated_at    datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'チームテーブル';
+
+create index teams_index_department_id
+    on teams (department_id);
+
+create table user_assignments
+(
+    id          int unsigned auto_increment comment '所属ID',
+    user_id     varchar(100) not null comment 'ユーザーID',
+    team_id     int unsigned not null default 0 comment 'チームID',
+    manager_id  varchar(100) not null default '' comment '上長のユーザーID',
+    started_at  datetime     not null comment '開始日',
+    finished_at datetime     null comment '終了日',
+    created_at  datetime     null comment '作成日',
+    updated_at  datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '所属テーブル';
+
+create index user_assignments_index_user_id_started_at
+    on user_assignments (user_id, started_at);
+
+create index user_assignments_index_team_id
+    on user_assignments (team_id);
+
+create index user_assignments_index_manager_id
+    on user_assignments (manager_id);
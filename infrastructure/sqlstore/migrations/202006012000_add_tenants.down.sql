@@ -0,0 +1,131 @@
+drop index rounding_rules_index_tenant_id_attendance_kind_id on rounding_rules;
+
+create unique index rounding_rules_index_attendance_kind_id
+    on rounding_rules (attendance_kind_id);
+
+drop index holidays_index_tenant_id_date on holidays;
+
+create unique index holidays_index_date
+    on holidays (date);
+
+drop index user_assignments_index_tenant_id on user_assignments;
+
+alter table user_assignments
+    drop column tenant_id;
+
+drop index teams_index_tenant_id on teams;
+
+alter table teams
+    drop column tenant_id;
+
+drop index departments_index_tenant_id on departments;
+
+alter table departments
+    drop column tenant_id;
+
+drop index user_roles_index_tenant_id on user_roles;
+
+alter table user_roles
+    drop column tenant_id;
+
+drop index kiosks_index_tenant_id on kiosks;
+
+alter table kiosks
+    drop column tenant_id;
+
+drop index network_restrictions_index_tenant_id on network_restrictions;
+
+alter table network_restrictions
+    drop column tenant_id;
+
+drop index allowed_networks_index_tenant_id on allowed_networks;
+
+alter table allowed_networks
+    drop column tenant_id;
+
+drop index geofences_index_tenant_id on geofences;
+
+alter table geofences
+    drop column tenant_id;
+
+drop index auto_clock_out_policies_index_tenant_id on auto_clock_out_policies;
+
+alter table auto_clock_out_policies
+    drop column tenant_id;
+
+drop index attendance_anomalies_index_tenant_id on attendance_anomalies;
+
+alter table attendance_anomalies
+    drop column tenant_id;
+
+drop index shift_assignments_index_tenant_id on shift_assignments;
+
+alter table shift_assignments
+    drop column tenant_id;
+
+drop index shift_templates_index_tenant_id on shift_templates;
+
+alter table shift_templates
+    drop column tenant_id;
+
+drop index flextime_rules_index_tenant_id on flextime_rules;
+
+alter table flextime_rules
+    drop column tenant_id;
+
+drop index rounding_rules_index_tenant_id on rounding_rules;
+
+alter table rounding_rules
+    drop column tenant_id;
+
+drop index overtime_alerts_index_tenant_id on overtime_alerts;
+
+alter table overtime_alerts
+    drop column tenant_id;
+
+drop index employment_contracts_index_tenant_id on employment_contracts;
+
+alter table employment_contracts
+    drop column tenant_id;
+
+drop index holidays_index_tenant_id on holidays;
+
+alter table holidays
+    drop column tenant_id;
+
+drop index leave_grants_index_tenant_id on leave_grants;
+
+alter table leave_grants
+    drop column tenant_id;
+
+drop index leave_requests_index_tenant_id on leave_requests;
+
+alter table leave_requests
+    drop column tenant_id;
+
+drop index correction_requests_index_tenant_id on correction_requests;
+
+alter table correction_requests
+    drop column tenant_id;
+
+drop index working_hours_index_tenant_id on working_hours;
+
+alter table working_hours
+    drop column tenant_id;
+
+drop index attendances_time_index_tenant_id on attendances_time;
+
+alter table attendances_time
+    drop column tenant_id;
+
+drop index attendances_index_tenant_id on attendances;
+
+alter table attendances
+    drop column tenant_id;
+
+drop index users_index_tenant_id on users;
+
+alter table users
+    drop column tenant_id;
+
+drop table tenants;
@@ -0,0 +1,171 @@
+create table tenants
+(
+    id             int unsigned auto_increment comment 'テナントID',
+    name           varchar(100) not null comment 'テナント名',
+    auth_tenant_id varchar(100) not null default '' comment '認証基盤のテナントID',
+    created_at     datetime     null comment '作成日',
+    updated_at     datetime     null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment 'テナントテーブル';
+
+create unique index tenants_index_auth_tenant_id
+    on tenants (auth_tenant_id);
+
+-- 既存のデータは認証基盤のテナントを持たない既定のテナントに属する。
+insert into tenants (id, name, auth_tenant_id, created_at, updated_at)
+values (1, 'default', '', utc_timestamp(), utc_timestamp());
+
+alter table users
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index users_index_tenant_id
+    on users (tenant_id);
+
+alter table attendances
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index attendances_index_tenant_id
+    on attendances (tenant_id);
+
+alter table attendances_time
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index attendances_time_index_tenant_id
+    on attendances_time (tenant_id);
+
+alter table working_hours
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index working_hours_index_tenant_id
+    on working_hours (tenant_id);
+
+alter table correction_requests
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index correction_requests_index_tenant_id
+    on correction_requests (tenant_id);
+
+alter table leave_requests
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index leave_requests_index_tenant_id
+    on leave_requests (tenant_id);
+
+alter table leave_grants
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index leave_grants_index_tenant_id
+    on leave_grants (tenant_id);
+
+alter table holidays
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index holidays_index_tenant_id
+    on holidays (tenant_id);
+
+alter table employment_contracts
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index employment_contracts_index_tenant_id
+    on employment_contracts (tenant_id);
+
+alter table overtime_alerts
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index overtime_alerts_index_tenant_id
+    on overtime_alerts (tenant_id);
+
+alter table rounding_rules
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index rounding_rules_index_tenant_id
+    on rounding_rules (tenant_id);
+
+alter table flextime_rules
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index flextime_rules_index_tenant_id
+    on flextime_rules (tenant_id);
+
+alter table shift_templates
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index shift_templates_index_tenant_id
+    on shift_templates (tenant_id);
+
+alter table shift_assignments
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index shift_assignments_index_tenant_id
+    on shift_assignments (tenant_id);
+
+alter table attendance_anomalies
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index attendance_anomalies_index_tenant_id
+    on attendance_anomalies (tenant_id);
+
+alter table auto_clock_out_policies
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index auto_clock_out_policies_index_tenant_id
+    on auto_clock_out_policies (tenant_id);
+
+alter table geofences
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index geofences_index_tenant_id
+    on geofences (tenant_id);
+
+alter table allowed_networks
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index allowed_networks_index_tenant_id
+    on allowed_networks (tenant_id);
+
+alter table network_restrictions
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index network_restrictions_index_tenant_id
+    on network_restrictions (tenant_id);
+
+alter table kiosks
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index kiosks_index_tenant_id
+    on kiosks (tenant_id);
+
+alter table user_roles
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index user_roles_index_tenant_id
+    on user_roles (tenant_id);
+
+alter table departments
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index departments_index_tenant_id
+    on departments (tenant_id);
+
+alter table teams
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index teams_index_tenant_id
+    on teams (tenant_id);
+
+alter table user_assignments
+    add tenant_id int unsigned not null default 1 comment 'テナントID' after id;
+
+create index user_assignments_index_tenant_id
+    on user_assignments (tenant_id);
+
+-- テナントごとに一意とする。
+drop index holidays_index_date on holidays;
+
+create unique index holidays_index_tenant_id_date
+    on holidays (tenant_id, date);
+
+drop index rounding_rules_index_attendance_kind_id on rounding_rules;
+
+create unique index rounding_rules_index_tenant_id_attendance_kind_id
+    on rounding_rules (tenant_id, attendance_kind_id);
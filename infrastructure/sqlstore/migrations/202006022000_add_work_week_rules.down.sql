@@ -0,0 +1 @@
+drop table work_week_rules;
@@ -0,0 +1,12 @@
+create table work_week_rules
+(
+    id                        int unsigned auto_increment comment '週の規則ID',
+    tenant_id                 int unsigned     not null comment 'テナントID',
+    statutory_holiday_weekday tinyint unsigned not null default 0 comment '法定休日の曜日(0: 日曜日)',
+    created_at                datetime         null comment '作成日',
+    updated_at                datetime         null comment '更新日',
+    primary key (id)
+) default charset = utf8 comment '法定休日と週の起算日のテーブル';
+
+create index work_week_rules_index_tenant_id
+    on work_week_rules (tenant_id);
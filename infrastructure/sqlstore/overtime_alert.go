@@ -0,0 +1,43 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type OvertimeAlert interface {
+	GetOvertimeAlerts(ctx context.Context, userID string, since int) ([]*models.OvertimeAlert, error)
+	CreateOvertimeAlert(ctx context.Context, alert *models.OvertimeAlert) error
+}
+
+// GetOvertimeAlerts returns the alerts raised for the user for the periods from the month of since (yyyymm).
+func (sqlStore) GetOvertimeAlerts(ctx context.Context, userID string, since int) ([]*models.OvertimeAlert, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*models.OvertimeAlert, 0)
+	err = sess.
+		Where("tenant_id = ?", tenantID).
+		Where("user_id = ?", userID).
+		Where("month >= ?", since).
+		OrderBy("month, id").
+		Find(&alerts)
+	if err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (sqlStore) CreateOvertimeAlert(ctx context.Context, alert *models.OvertimeAlert) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	alert.TenantID = tenantID
+	if _, err := sess.Insert(alert); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Role interface {
+	GetUserRoles(ctx context.Context, userID string) ([]*models.UserRole, error)
+	GetUserRolesCount(ctx context.Context, role models.Role) (int64, error)
+	CreateUserRole(ctx context.Context, role *models.UserRole) error
+	UpdateUserRoles(ctx context.Context, userID string, roles []*models.UserRole) error
+}
+
+func (sqlStore) GetUserRoles(ctx context.Context, userID string) ([]*models.UserRole, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]*models.UserRole, 0)
+	if err = sess.Where("tenant_id = ?", tenantID).Where("user_id = ?", userID).OrderBy("role_id").Find(&roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetUserRolesCount returns how many users of the tenant have the role.
+func (sqlStore) GetUserRolesCount(ctx context.Context, role models.Role) (int64, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return sess.Where("tenant_id = ?", tenantID).Where("role_id = ?", uint8(role)).Count(&models.UserRole{})
+}
+
+func (sqlStore) CreateUserRole(ctx context.Context, role *models.UserRole) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	role.TenantID = tenantID
+	if _, err := sess.Insert(role); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateUserRoles replaces the roles of the user.
+func (sqlStore) UpdateUserRoles(ctx context.Context, userID string, roles []*models.UserRole) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).Where("user_id = ?", userID).Delete(&models.UserRole{}); err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+	for _, r := range roles {
+		r.TenantID = tenantID
+	}
+	if _, err := sess.Insert(&roles); err != nil {
+		return err
+	}
+	return nil
+}
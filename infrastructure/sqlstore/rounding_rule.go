@@ -0,0 +1,49 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type RoundingRule interface {
+	GetRoundingRules(ctx context.Context) (models.RoundingRules, error)
+	CreateRoundingRule(ctx context.Context, rule *models.RoundingRule) error
+	DeleteRoundingRules(ctx context.Context) error
+}
+
+func (sqlStore) GetRoundingRules(ctx context.Context) (models.RoundingRules, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(models.RoundingRules, 0)
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("attendance_kind_id").Find(&rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (sqlStore) CreateRoundingRule(ctx context.Context, rule *models.RoundingRule) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+	if _, err := sess.Insert(rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteRoundingRules deletes all the rounding rules.
+func (sqlStore) DeleteRoundingRules(ctx context.Context) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).Where("id > 0").Delete(&models.RoundingRule{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"time"
+)
+
+type Shift interface {
+	GetShiftTemplates(ctx context.Context) ([]*models.ShiftTemplate, error)
+	GetShiftTemplate(ctx context.Context, id int64) (*models.ShiftTemplate, error)
+	CreateShiftTemplate(ctx context.Context, template *models.ShiftTemplate) error
+	GetShiftAssignments(ctx context.Context, userID string, start, end time.Time) ([]*models.ShiftAssignment, error)
+	CreateShiftAssignment(ctx context.Context, shift *models.ShiftAssignment) error
+	DeleteShiftAssignments(ctx context.Context, start, end time.Time) error
+}
+
+func (sqlStore) GetShiftTemplates(ctx context.Context) ([]*models.ShiftTemplate, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*models.ShiftTemplate, 0)
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("start_minutes").Find(&templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (sqlStore) GetShiftTemplate(ctx context.Context, id int64) (*models.ShiftTemplate, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &models.ShiftTemplate{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(template)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return template, nil
+}
+
+func (sqlStore) CreateShiftTemplate(ctx context.Context, template *models.ShiftTemplate) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	template.TenantID = tenantID
+	if _, err := sess.Insert(template); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetShiftAssignments returns the shifts of the user on the days between start and end in date order.
+// The shifts of everyone are returned when the user id is empty.
+func (sqlStore) GetShiftAssignments(ctx context.Context, userID string, start, end time.Time) ([]*models.ShiftAssignment, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts := make([]*models.ShiftAssignment, 0)
+	sess.Where("work_date Between ? and ? ", start, end)
+	if userID != "" {
+		sess.Where("user_id = ?", userID)
+	}
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("work_date, started_at").Find(&shifts); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+func (sqlStore) CreateShiftAssignment(ctx context.Context, shift *models.ShiftAssignment) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	shift.TenantID = tenantID
+	if _, err := sess.Insert(shift); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteShiftAssignments deletes the shifts of everyone on the days between start and end.
+func (sqlStore) DeleteShiftAssignments(ctx context.Context, start, end time.Time) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).Where("work_date Between ? and ? ", start, end).Delete(&models.ShiftAssignment{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -17,13 +17,54 @@ const (
 	AttendanceTable     = "attendances"
 	AttendanceTimeTable = "attendances_time"
 	WorkingHourTable    = "working_hours"
+	CorrectionTable     = "correction_requests"
+	LeaveRequestTable   = "leave_requests"
+	LeaveGrantTable     = "leave_grants"
+	HolidayTable        = "holidays"
+	ContractTable       = "employment_contracts"
+	OvertimeAlertTable  = "overtime_alerts"
+	RoundingRuleTable   = "rounding_rules"
+	FlextimeRuleTable   = "flextime_rules"
+	WorkWeekRuleTable   = "work_week_rules"
+	ShiftTemplateTable  = "shift_templates"
+	ShiftTable          = "shift_assignments"
+	AnomalyTable        = "attendance_anomalies"
+	AutoClockOutTable   = "auto_clock_out_policies"
+	GeofenceTable       = "geofences"
+	NetworkTable        = "allowed_networks"
+	RestrictionTable    = "network_restrictions"
+	KioskTable          = "kiosks"
+	UserRoleTable       = "user_roles"
+	DepartmentTable     = "departments"
+	TeamTable           = "teams"
+	AssignmentTable     = "user_assignments"
+	TenantTable         = "tenants"
 )
 
 type SQLStore interface {
 	Transaction
+	Tenant
 	User
 	Attendance
 	WorkingHour
+	Correction
+	Leave
+	Holiday
+	Contract
+	OvertimeAlert
+	RoundingRule
+	FlextimeRule
+	WorkWeekRule
+	Shift
+	Anomaly
+	AutoClockOutPolicy
+	Geofence
+	AllowedNetwork
+	Kiosk
+	Role
+	Department
+	Team
+	Assignment
 }
 
 type sqlStore struct {
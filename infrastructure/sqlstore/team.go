@@ -0,0 +1,82 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type Team interface {
+	GetTeam(ctx context.Context, id int64) (*models.Team, error)
+	GetTeams(ctx context.Context, departmentID int64) ([]*models.Team, error)
+	CreateTeam(ctx context.Context, team *models.Team) error
+	UpdateTeam(ctx context.Context, team *models.Team) error
+	DeleteTeam(ctx context.Context, id int64) error
+}
+
+func (sqlStore) GetTeam(ctx context.Context, id int64) (*models.Team, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &models.Team{}
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", id).Get(team)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return team, nil
+}
+
+// GetTeams returns the teams of the department, or of every department when departmentID is zero.
+func (sqlStore) GetTeams(ctx context.Context, departmentID int64) ([]*models.Team, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if departmentID != 0 {
+		sess.Where("department_id = ?", departmentID)
+	}
+	teams := make([]*models.Team, 0)
+	if err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (sqlStore) CreateTeam(ctx context.Context, team *models.Team) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	team.TenantID = tenantID
+	if _, err := sess.Insert(team); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateTeam(ctx context.Context, team *models.Team) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(team.ID).Cols("department_id", "name").Update(team); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) DeleteTeam(ctx context.Context, id int64) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(id).Delete(&models.Team{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/auth"
+)
+
+// Tenant is not scoped to a tenant: it resolves the tenant the other queries are scoped to.
+type Tenant interface {
+	GetTenant(ctx context.Context, id int64) (*models.Tenant, error)
+	GetTenantByAuthTenantID(ctx context.Context, authTenantID string) (*models.Tenant, error)
+	GetTenants(ctx context.Context) ([]*models.Tenant, error)
+	CreateTenant(ctx context.Context, tenant *models.Tenant) error
+}
+
+// WithTenant returns the context whose queries are scoped to the tenant, e.g. to run a job for each tenant.
+func WithTenant(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, auth.TenantIDKey, tenantID)
+}
+
+// TenantID returns the tenant the queries of the context are scoped to.
+func TenantID(ctx context.Context) (int64, error) {
+	tenantID, ok := ctx.Value(auth.TenantIDKey).(int64)
+	if !ok || tenantID == 0 {
+		return 0, models.ErrTenantUnresolved
+	}
+	return tenantID, nil
+}
+
+// getTenantSession returns the session of the context and the tenant its queries must be scoped to.
+// It fails without a tenant, so that no query can read or write across tenants.
+func getTenantSession(ctx context.Context) (*DBSession, int64, error) {
+	tenantID, err := TenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sess, tenantID, nil
+}
+
+func (sqlStore) GetTenant(ctx context.Context, id int64) (*models.Tenant, error) {
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := &models.Tenant{}
+	has, err := sess.Where("id = ?", id).Get(tenant)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return tenant, nil
+}
+
+func (sqlStore) GetTenantByAuthTenantID(ctx context.Context, authTenantID string) (*models.Tenant, error) {
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := &models.Tenant{}
+	has, err := sess.Where("auth_tenant_id = ?", authTenantID).Get(tenant)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return tenant, nil
+}
+
+func (sqlStore) GetTenants(ctx context.Context) ([]*models.Tenant, error) {
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]*models.Tenant, 0)
+	if err := sess.OrderBy("id").Find(&tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (sqlStore) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
+	sess, err := getDBSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Insert(tenant); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+	"golang.org/x/xerrors"
+	"testing"
+)
+
+func TestTenantScope(t *testing.T) {
+	store := InitTestDatabase()
+	other := &models.Tenant{Name: "other", AuthTenantID: "other-tenant"}
+	if err := store.CreateTenant(context.Background(), other); err != nil {
+		t.Errorf("CreateTenant() failed %s", err)
+	}
+	user := &models.User{
+		ID:   "asdiekawei42lasedi356ladfkjfity",
+		Name: "test1",
+	}
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
+		t.Errorf("CreateUser() failed %s", err)
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		wantID         string
+		wantErr        bool
+		wantUnresolved bool
+	}{
+		{
+			name:   "Should get user of the tenant",
+			ctx:    NewTestContext(),
+			wantID: user.ID,
+		},
+		{
+			name:   "Should not get user of another tenant",
+			ctx:    WithTenant(context.Background(), other.ID),
+			wantID: "",
+		},
+		{
+			name:           "Should not query without tenant",
+			ctx:            context.Background(),
+			wantErr:        true,
+			wantUnresolved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.GetUser(tt.ctx, user.ID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantUnresolved && !xerrors.Is(err, models.ErrTenantUnresolved) {
+				t.Errorf("GetUser() error = %v, want %v", err, models.ErrTenantUnresolved)
+			}
+			if err == nil && got.ID != tt.wantID {
+				t.Errorf("GetUser() got = %v, want %v", got.ID, tt.wantID)
+			}
+		})
+	}
+
+	t.Run("Should not update user of another tenant", func(t *testing.T) {
+		updated := &models.User{ID: user.ID, Name: "updatedName"}
+		if err := store.UpdateUser(WithTenant(context.Background(), other.ID), updated); err == nil {
+			t.Errorf("UpdateUser() error = %v, wantErr %v", err, true)
+		}
+	})
+}
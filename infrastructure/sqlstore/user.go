@@ -9,18 +9,19 @@ import (
 
 type User interface {
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, user *models.User) error
 }
 
 func (sqlStore) GetUser(ctx context.Context, userID string) (*models.User, error) {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	user := &models.User{}
-	_, err = sess.Where("id = ?", userID).Get(user)
+	_, err = sess.Where("tenant_id = ?", tenantID).Where("id = ?", userID).Get(user)
 	if err != nil {
 		return nil, err
 	}
@@ -28,12 +29,26 @@ func (sqlStore) GetUser(ctx context.Context, userID string) (*models.User, error
 	return user, nil
 }
 
+func (sqlStore) GetUsers(ctx context.Context) ([]*models.User, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.User, 0)
+	if err = sess.Where("tenant_id = ?", tenantID).OrderBy("id").Find(&users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (sqlStore) CreateUser(ctx context.Context, user *models.User) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
 
+	user.TenantID = tenantID
 	if _, err := sess.Insert(user); err != nil {
 		return err
 	}
@@ -41,12 +56,12 @@ func (sqlStore) CreateUser(ctx context.Context, user *models.User) error {
 }
 
 func (sqlStore) UpdateUser(ctx context.Context, user *models.User) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
 
-	has, err := sess.Where("id = ?", user.ID).Exist(&models.User{})
+	has, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", user.ID).Exist(&models.User{})
 	if err != nil {
 		return err
 	}
@@ -54,7 +69,7 @@ func (sqlStore) UpdateUser(ctx context.Context, user *models.User) error {
 		return xerrors.New("user is not exists")
 	}
 
-	if _, err := sess.Where("id = ?", user.ID).Update(user); err != nil {
+	if _, err := sess.Where("tenant_id = ?", tenantID).Where("id = ?", user.ID).Update(user); err != nil {
 		return err
 	}
 	logger.NewInfo("updated user_id: " + user.ID)
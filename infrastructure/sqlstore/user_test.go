@@ -22,7 +22,7 @@ func TestCreateUser(t *testing.T) {
 		{
 			"Should create user",
 			args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				user: &models.User{
 					ID:   "asdiekawei42lasedi356ladfkjfity",
 					Name: "test1",
@@ -48,7 +48,7 @@ func TestUpdateUser(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -64,7 +64,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			"Should not create user when have not created user",
 			args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				user: &models.User{
 					ID:   "qawsedreftgyhujuiqadnsrt2376sd",
 					Name: "test1",
@@ -75,7 +75,7 @@ func TestUpdateUser(t *testing.T) {
 		{
 			"Should create user",
 			args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				user: &models.User{
 					ID:       "asdiekawei42lasedi356ladfkjfity",
 					Name:     "updatedName",
@@ -110,7 +110,7 @@ func TestGetUser(t *testing.T) {
 		Name: "test1",
 	}
 
-	if err := store.CreateUser(context.Background(), user); err != nil {
+	if err := store.CreateUser(NewTestContext(), user); err != nil {
 		t.Errorf("CreateAttendanceTime() failed%s", err)
 	}
 
@@ -127,7 +127,7 @@ func TestGetUser(t *testing.T) {
 		{
 			"Should get user",
 			args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: "asdiekawei42lasedi356ladfkjfity",
 			},
 			user,
@@ -136,7 +136,7 @@ func TestGetUser(t *testing.T) {
 		{
 			"Should not get user",
 			args{
-				ctx:    context.Background(),
+				ctx:    NewTestContext(),
 				userID: "asdiekawei42lasedi356ladfkjfity",
 			},
 			user,
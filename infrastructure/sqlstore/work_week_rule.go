@@ -0,0 +1,55 @@
+package sqlstore
+
+import (
+	"context"
+	"github.com/KouT127/attendance-management/domain/models"
+)
+
+type WorkWeekRule interface {
+	GetWorkWeekRule(ctx context.Context) (*models.WorkWeekRule, error)
+	CreateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) error
+	UpdateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) error
+}
+
+// GetWorkWeekRule returns the work week rule of the company, or nil when it is not set.
+func (sqlStore) GetWorkWeekRule(ctx context.Context) (*models.WorkWeekRule, error) {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.WorkWeekRule{}
+	has, err := sess.Where("tenant_id = ?", tenantID).OrderBy("id").Get(rule)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return rule, nil
+}
+
+func (sqlStore) CreateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+	if _, err := sess.Insert(rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sqlStore) UpdateWorkWeekRule(ctx context.Context, rule *models.WorkWeekRule) error {
+	sess, tenantID, err := getTenantSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := sess.Where("tenant_id = ?", tenantID).ID(rule.ID).
+		Cols("statutory_holiday_weekday").
+		Update(rule); err != nil {
+		return err
+	}
+	return nil
+}
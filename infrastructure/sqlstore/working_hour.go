@@ -12,13 +12,14 @@ type WorkingHour interface {
 }
 
 func (sqlStore) GetWorkingHours(ctx context.Context, now time.Time) (*models.WorkingHour, error) {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	wh := &models.WorkingHour{}
 	has, err := sess.
+		Where("tenant_id = ?", tenantID).
 		Where("started_at < ?", now).
 		And("finished_at > ?", now).
 		Get(wh)
@@ -33,10 +34,11 @@ func (sqlStore) GetWorkingHours(ctx context.Context, now time.Time) (*models.Wor
 }
 
 func (sqlStore) CreateWorkingHour(ctx context.Context, hour *models.WorkingHour) error {
-	sess, err := getDBSession(ctx)
+	sess, tenantID, err := getTenantSession(ctx)
 	if err != nil {
 		return err
 	}
+	hour.TenantID = tenantID
 	_, err = sess.
 		Insert(hour)
 
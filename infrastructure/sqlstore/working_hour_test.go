@@ -32,7 +32,7 @@ func Test_sqlStore_CreateWorkingHours(t *testing.T) {
 				engine: eng,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				hour: &models.WorkingHour{
 					StartedAt:    flextime.Now(),
 					FinishedAt:   flextime.Now(),
@@ -47,7 +47,7 @@ func Test_sqlStore_CreateWorkingHours(t *testing.T) {
 				engine: eng,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				hour: &models.WorkingHour{
 					StartedAt: flextime.Now(),
 				},
@@ -60,7 +60,7 @@ func Test_sqlStore_CreateWorkingHours(t *testing.T) {
 				engine: eng,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				hour: &models.WorkingHour{
 					FinishedAt: flextime.Now(),
 				},
@@ -87,7 +87,7 @@ func Test_sqlStore_GetWorkingHour(t *testing.T) {
 		FinishedAt:   time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC),
 		WorkingHours: 180,
 	}
-	if err := store.CreateWorkingHour(context.Background(), wh); err != nil {
+	if err := store.CreateWorkingHour(NewTestContext(), wh); err != nil {
 		t.Errorf("CreateWorkingHour() err = %v", err)
 	}
 
@@ -111,7 +111,7 @@ func Test_sqlStore_GetWorkingHour(t *testing.T) {
 				engine: eng,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				now: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
 			},
 			want:    wh,
@@ -123,7 +123,7 @@ func Test_sqlStore_GetWorkingHour(t *testing.T) {
 				engine: eng,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: NewTestContext(),
 				now: time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC),
 			},
 			want:    nil,
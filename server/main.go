@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/KouT127/attendance-management/infrastructure/routes"
 	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
 	"github.com/KouT127/attendance-management/infrastructure/uploader"
@@ -8,8 +9,15 @@ import (
 	"github.com/KouT127/attendance-management/utilities/timezone"
 	_ "github.com/go-sql-driver/mysql"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout is how long the requests in flight are waited for on shutdown.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	logger.SetUp()
 	timezone.Set("Asia/Tokyo")
@@ -17,5 +25,31 @@ func main() {
 	if err != nil {
 		log.Fatalf("%v", err)
 	}
-	routes.InitRouter(sqlstore.InitDatabase(), upl)
+	store := sqlstore.InitDatabase()
+	runner := routes.InitJobRunner(store)
+	runner.Start(context.Background())
+
+	srv := routes.InitRouter(store, upl)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+
+	// The jobs are stopped on the way out, so that a job running, e.g. an auto clock-out, is finished rather than killed.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-errc:
+		// The server failed to start or serve: Shutdown has not been called, so it is never http.ErrServerClosed.
+		runner.Stop()
+		log.Fatalf("%v", err)
+	case sig := <-quit:
+		log.Printf("Shutting down on %s", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("Warning: server shutdown %v", err)
+		}
+		cancel()
+	}
+	runner.Stop()
 }
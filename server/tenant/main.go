@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/KouT127/attendance-management/application/services"
+	"github.com/KouT127/attendance-management/domain/models"
+	"github.com/KouT127/attendance-management/infrastructure/sqlstore"
+	"github.com/KouT127/attendance-management/utilities/logger"
+	_ "github.com/go-sql-driver/mysql"
+	"log"
+)
+
+// main registers a subsidiary as a tenant and makes the user its first system admin, e.g.
+// go run ./server/tenant -name "Subsidiary" -auth-tenant-id subsidiary-xxxx -admin-user-id UID
+func main() {
+	name := flag.String("name", "", "name of the tenant")
+	authTenantID := flag.String("auth-tenant-id", "", "tenant of the identity platform its users sign in with")
+	adminUserID := flag.String("admin-user-id", "", "user id of its first system admin")
+	flag.Parse()
+
+	logger.SetUp()
+	store := sqlstore.InitDatabase()
+	service := services.NewTenantService(store)
+	tenant, err := service.CreateTenant(context.Background(), &models.Tenant{Name: *name, AuthTenantID: *authTenantID}, *adminUserID)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	log.Printf("tenant %d is created", tenant.ID)
+}
@@ -0,0 +1,78 @@
+package netutil
+
+import (
+	"golang.org/x/xerrors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ParseCIDRs parses a comma separated list of networks. A single address is taken as a network of itself.
+func ParseCIDRs(s string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0)
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, xerrors.Errorf("address %s is invalid", v)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, n)
+	}
+	return networks, nil
+}
+
+// ClientIP returns the address of the client of the request.
+// X-Forwarded-For is only believed when the request came through the trusted proxies:
+// it is read from the right, and the first address not of a trusted proxy is the client.
+// It returns nil when the address can not be parsed.
+func ClientIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isTrusted(ip, trustedProxies) {
+		return ip
+	}
+
+	hops := make([]string, 0)
+	for _, h := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(h, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			return ip
+		}
+		ip = hop
+		if !isTrusted(ip, trustedProxies) {
+			return ip
+		}
+	}
+	return ip
+}
+
+func isTrusted(ip net.IP, trustedProxies []*net.IPNet) bool {
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
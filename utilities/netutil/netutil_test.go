@@ -0,0 +1,61 @@
+package netutil
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseCIDRs("10.0.0.0/8, 192.168.1.1")
+	if err != nil {
+		t.Fatalf("ParseCIDRs() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{
+			name:       "Should return the remote address without proxy",
+			remoteAddr: "203.0.113.10:51234",
+			want:       "203.0.113.10",
+		},
+		{
+			name:         "Should ignore forwarded address from untrusted client",
+			remoteAddr:   "203.0.113.10:51234",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "203.0.113.10",
+		},
+		{
+			name:         "Should return forwarded address through trusted proxy",
+			remoteAddr:   "10.0.0.2:51234",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "Should skip trusted proxies from the right",
+			remoteAddr:   "10.0.0.2:51234",
+			forwardedFor: []string{"198.51.100.7, 198.51.100.1", "192.168.1.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "Should stop at an invalid forwarded address",
+			remoteAddr:   "10.0.0.2:51234",
+			forwardedFor: []string{"unknown"},
+			want:         "10.0.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}}
+			for _, h := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", h)
+			}
+			if got := ClientIP(r, trusted); got.String() != tt.want {
+				t.Errorf("ClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}